@@ -86,6 +86,16 @@ type ArmClient struct {
 	environment              az.Environment
 	skipProviderRegistration bool
 
+	// storageUseAzureAD specifies whether Azure Active Directory should be used to authenticate to the Blob and Queue data planes
+	storageUseAzureAD     bool
+	storageAuthorizerFunc func() (autorest.Authorizer, error)
+	storageAuthorizerMu   sync.Mutex
+	storageAuthorizer     autorest.Authorizer
+
+	// storageDataPlaneClient sends the requests to the Storage data plane which aren't available in the Storage SDK,
+	// which are authenticated prior to being sent
+	storageDataPlaneClient autorest.Client
+
 	StopContext context.Context
 
 	cosmosDBClient documentdb.DatabaseAccountsClient
@@ -105,6 +115,7 @@ type ArmClient struct {
 
 	containerRegistryClient             containerregistry.RegistriesClient
 	containerRegistryReplicationsClient containerregistry.ReplicationsClient
+	containerRegistryWebhooksClient     containerregistry.WebhooksClient
 	containerServicesClient             containerservice.ContainerServicesClient
 	kubernetesClustersClient            containerservice.ManagedClustersClient
 	containerGroupsClient               containerinstance.ContainerGroupsClient
@@ -144,19 +155,23 @@ type ArmClient struct {
 	cognitiveAccountsClient cognitiveservices.AccountsClient
 
 	// Compute
-	availSetClient             compute.AvailabilitySetsClient
-	diskClient                 compute.DisksClient
-	imageClient                compute.ImagesClient
-	galleriesClient            compute.GalleriesClient
-	galleryImagesClient        compute.GalleryImagesClient
-	galleryImageVersionsClient compute.GalleryImageVersionsClient
-	snapshotsClient            compute.SnapshotsClient
-	usageOpsClient             compute.UsageClient
-	vmExtensionImageClient     compute.VirtualMachineExtensionImagesClient
-	vmExtensionClient          compute.VirtualMachineExtensionsClient
-	vmScaleSetClient           compute.VirtualMachineScaleSetsClient
-	vmImageClient              compute.VirtualMachineImagesClient
-	vmClient                   compute.VirtualMachinesClient
+	availSetClient                  compute.AvailabilitySetsClient
+	diskClient                      compute.DisksClient
+	imageClient                     compute.ImagesClient
+	galleriesClient                 compute.GalleriesClient
+	galleryImagesClient             compute.GalleryImagesClient
+	galleryImageVersionsClient      compute.GalleryImageVersionsClient
+	snapshotsClient                 compute.SnapshotsClient
+	usageOpsClient                  compute.UsageClient
+	vmExtensionImageClient          compute.VirtualMachineExtensionImagesClient
+	vmExtensionClient               compute.VirtualMachineExtensionsClient
+	vmScaleSetClient                compute.VirtualMachineScaleSetsClient
+	vmScaleSetExtensionsClient      compute.VirtualMachineScaleSetExtensionsClient
+	vmScaleSetRollingUpgradesClient compute.VirtualMachineScaleSetRollingUpgradesClient
+	vmScaleSetVMsClient             compute.VirtualMachineScaleSetVMsClient
+	vmImageClient                   compute.VirtualMachineImagesClient
+	vmClient                        compute.VirtualMachinesClient
+	vmRunCommandsClient             compute.VirtualMachineRunCommandsClient
 
 	// Devices
 	iothubResourceClient devices.IotHubResourceClient
@@ -233,27 +248,31 @@ type ArmClient struct {
 	userAssignedIdentitiesClient msi.UserAssignedIdentitiesClient
 
 	// Networking
-	applicationGatewayClient        network.ApplicationGatewaysClient
-	applicationSecurityGroupsClient network.ApplicationSecurityGroupsClient
-	azureFirewallsClient            network.AzureFirewallsClient
-	expressRouteAuthsClient         network.ExpressRouteCircuitAuthorizationsClient
-	expressRouteCircuitClient       network.ExpressRouteCircuitsClient
-	expressRoutePeeringsClient      network.ExpressRouteCircuitPeeringsClient
-	ifaceClient                     network.InterfacesClient
-	loadBalancerClient              network.LoadBalancersClient
-	localNetConnClient              network.LocalNetworkGatewaysClient
-	packetCapturesClient            network.PacketCapturesClient
-	publicIPClient                  network.PublicIPAddressesClient
-	routesClient                    network.RoutesClient
-	routeTablesClient               network.RouteTablesClient
-	secGroupClient                  network.SecurityGroupsClient
-	secRuleClient                   network.SecurityRulesClient
-	subnetClient                    network.SubnetsClient
-	vnetGatewayConnectionsClient    network.VirtualNetworkGatewayConnectionsClient
-	vnetGatewayClient               network.VirtualNetworkGatewaysClient
-	vnetClient                      network.VirtualNetworksClient
-	vnetPeeringsClient              network.VirtualNetworkPeeringsClient
-	watcherClient                   network.WatchersClient
+	applicationGatewayClient                  network.ApplicationGatewaysClient
+	applicationSecurityGroupsClient           network.ApplicationSecurityGroupsClient
+	azureFirewallsClient                      network.AzureFirewallsClient
+	connectionMonitorsClient                  network.ConnectionMonitorsClient
+	expressRouteAuthsClient                   network.ExpressRouteCircuitAuthorizationsClient
+	expressRouteCircuitClient                 network.ExpressRouteCircuitsClient
+	expressRouteConnectionsClient             network.ExpressRouteCircuitConnectionsClient
+	expressRouteCrossConnectionsClient        network.ExpressRouteCrossConnectionsClient
+	expressRouteCrossConnectionPeeringsClient network.ExpressRouteCrossConnectionPeeringsClient
+	expressRoutePeeringsClient                network.ExpressRouteCircuitPeeringsClient
+	ifaceClient                               network.InterfacesClient
+	loadBalancerClient                        network.LoadBalancersClient
+	localNetConnClient                        network.LocalNetworkGatewaysClient
+	packetCapturesClient                      network.PacketCapturesClient
+	publicIPClient                            network.PublicIPAddressesClient
+	routesClient                              network.RoutesClient
+	routeTablesClient                         network.RouteTablesClient
+	secGroupClient                            network.SecurityGroupsClient
+	secRuleClient                             network.SecurityRulesClient
+	subnetClient                              network.SubnetsClient
+	vnetGatewayConnectionsClient              network.VirtualNetworkGatewayConnectionsClient
+	vnetGatewayClient                         network.VirtualNetworkGatewaysClient
+	vnetClient                                network.VirtualNetworksClient
+	vnetPeeringsClient                        network.VirtualNetworkPeeringsClient
+	watcherClient                             network.WatchersClient
 
 	// Notification Hubs
 	notificationHubsClient       notificationhubs.Client
@@ -407,6 +426,16 @@ func getArmClient(c *authentication.Config, skipProviderRegistration bool) (*Arm
 		return nil, err
 	}
 
+	// Storage Endpoints - the token is only retrieved if Azure Active Directory is used for the Storage data plane
+	client.storageAuthorizerFunc = func() (autorest.Authorizer, error) {
+		storageAuth, err := c.GetAuthorizationToken(oauthConfig, storageAzureADResource)
+		if err != nil {
+			return nil, err
+		}
+
+		return storageAuth, nil
+	}
+
 	// Key Vault Endpoints
 	sender := azure.BuildSender()
 	keyVaultAuth := autorest.NewBearerAuthorizerCallback(sender, func(tenantID, resource string) (*autorest.BearerAuthorizer, error) {
@@ -594,10 +623,26 @@ func (c *ArmClient) registerComputeClients(endpoint, subscriptionId string, auth
 	c.configureClient(&scaleSetsClient.Client, auth)
 	c.vmScaleSetClient = scaleSetsClient
 
+	scaleSetExtensionsClient := compute.NewVirtualMachineScaleSetExtensionsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&scaleSetExtensionsClient.Client, auth)
+	c.vmScaleSetExtensionsClient = scaleSetExtensionsClient
+
+	scaleSetRollingUpgradesClient := compute.NewVirtualMachineScaleSetRollingUpgradesClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&scaleSetRollingUpgradesClient.Client, auth)
+	c.vmScaleSetRollingUpgradesClient = scaleSetRollingUpgradesClient
+
+	scaleSetVMsClient := compute.NewVirtualMachineScaleSetVMsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&scaleSetVMsClient.Client, auth)
+	c.vmScaleSetVMsClient = scaleSetVMsClient
+
 	virtualMachinesClient := compute.NewVirtualMachinesClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&virtualMachinesClient.Client, auth)
 	c.vmClient = virtualMachinesClient
 
+	virtualMachineRunCommandsClient := compute.NewVirtualMachineRunCommandsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&virtualMachineRunCommandsClient.Client, auth)
+	c.vmRunCommandsClient = virtualMachineRunCommandsClient
+
 	galleriesClient := compute.NewGalleriesClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&galleriesClient.Client, auth)
 	c.galleriesClient = galleriesClient
@@ -626,6 +671,10 @@ func (c *ArmClient) registerContainerRegistryClients(endpoint, subscriptionId st
 	crrc := containerregistry.NewReplicationsClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&crrc.Client, auth)
 	c.containerRegistryReplicationsClient = crrc
+
+	crwc := containerregistry.NewWebhooksClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&crwc.Client, auth)
+	c.containerRegistryWebhooksClient = crwc
 }
 
 func (c *ArmClient) registerContainerServicesClients(endpoint, subscriptionId string, auth autorest.Authorizer) {
@@ -879,6 +928,10 @@ func (c *ArmClient) registerNetworkingClients(endpoint, subscriptionId string, a
 	c.configureClient(&azureFirewallsClient.Client, auth)
 	c.azureFirewallsClient = azureFirewallsClient
 
+	connectionMonitorsClient := network.NewConnectionMonitorsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&connectionMonitorsClient.Client, auth)
+	c.connectionMonitorsClient = connectionMonitorsClient
+
 	expressRouteAuthsClient := network.NewExpressRouteCircuitAuthorizationsClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&expressRouteAuthsClient.Client, auth)
 	c.expressRouteAuthsClient = expressRouteAuthsClient
@@ -887,6 +940,18 @@ func (c *ArmClient) registerNetworkingClients(endpoint, subscriptionId string, a
 	c.configureClient(&expressRouteCircuitsClient.Client, auth)
 	c.expressRouteCircuitClient = expressRouteCircuitsClient
 
+	expressRouteConnectionsClient := network.NewExpressRouteCircuitConnectionsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&expressRouteConnectionsClient.Client, auth)
+	c.expressRouteConnectionsClient = expressRouteConnectionsClient
+
+	expressRouteCrossConnectionsClient := network.NewExpressRouteCrossConnectionsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&expressRouteCrossConnectionsClient.Client, auth)
+	c.expressRouteCrossConnectionsClient = expressRouteCrossConnectionsClient
+
+	expressRouteCrossConnectionPeeringsClient := network.NewExpressRouteCrossConnectionPeeringsClientWithBaseURI(endpoint, subscriptionId)
+	c.configureClient(&expressRouteCrossConnectionPeeringsClient.Client, auth)
+	c.expressRouteCrossConnectionPeeringsClient = expressRouteCrossConnectionPeeringsClient
+
 	expressRoutePeeringsClient := network.NewExpressRouteCircuitPeeringsClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&expressRoutePeeringsClient.Client, auth)
 	c.expressRoutePeeringsClient = expressRoutePeeringsClient
@@ -1118,6 +1183,10 @@ func (c *ArmClient) registerStorageClients(endpoint, subscriptionId string, auth
 	usageClient := storage.NewUsageClientWithBaseURI(endpoint, subscriptionId)
 	c.configureClient(&usageClient.Client, auth)
 	c.storageUsageClient = usageClient
+
+	dataPlaneClient := autorest.NewClientWithUserAgent("")
+	c.configureClient(&dataPlaneClient, autorest.NullAuthorizer{})
+	c.storageDataPlaneClient = dataPlaneClient
 }
 
 func (c *ArmClient) registerTrafficManagerClients(endpoint, subscriptionId string, auth autorest.Authorizer) {
@@ -1167,8 +1236,33 @@ func (c *ArmClient) registerManagementGroupClients(endpoint string, auth autores
 var (
 	storageKeyCacheMu sync.RWMutex
 	storageKeyCache   = make(map[string]string)
+
+	// storageKeyCacheInvalidations counts the number of times the key for each Storage Account (by name) has been
+	// removed from the cache, which allows callers to determine if the key for the Storage Account they're using
+	// was found to be stale (e.g. rotated out-of-band) during an operation
+	storageKeyCacheInvalidations = make(map[string]uint64)
 )
 
+// invalidateKeyForStorageAccount removes the cached key for the specified Storage Account, such
+// that the key is retrieved again the next time a client is requested for it
+func invalidateKeyForStorageAccount(resourceGroupName, storageAccountName string) {
+	cacheIndex := resourceGroupName + "/" + storageAccountName
+
+	storageKeyCacheMu.Lock()
+	delete(storageKeyCache, cacheIndex)
+	storageKeyCacheInvalidations[storageAccountName]++
+	storageKeyCacheMu.Unlock()
+}
+
+// getKeyInvalidationsForStorageAccount returns the number of times the cached key for the specified Storage Account
+// has been invalidated
+func getKeyInvalidationsForStorageAccount(storageAccountName string) uint64 {
+	storageKeyCacheMu.RLock()
+	defer storageKeyCacheMu.RUnlock()
+
+	return storageKeyCacheInvalidations[storageAccountName]
+}
+
 func (c *ArmClient) getKeyForStorageAccount(ctx context.Context, resourceGroupName, storageAccountName string) (string, bool, error) {
 	cacheIndex := resourceGroupName + "/" + storageAccountName
 	storageKeyCacheMu.RLock()
@@ -1187,6 +1281,9 @@ func (c *ArmClient) getKeyForStorageAccount(ctx context.Context, resourceGroupNa
 		if utils.ResponseWasNotFound(accountKeys.Response) {
 			return "", false, nil
 		}
+		if utils.ResponseWasForbidden(accountKeys.Response) {
+			return "", true, errStorageAccountKeysForbidden
+		}
 		if err != nil {
 			// We assume this is a transient error rather than a 404 (which is caught above),  so assume the
 			// storeAccount still exists.
@@ -1214,8 +1311,19 @@ func (c *ArmClient) getKeyForStorageAccount(ctx context.Context, resourceGroupNa
 	return key, true, nil
 }
 
-func (c *ArmClient) getBlobStorageClientForStorageAccount(ctx context.Context, resourceGroupName, storageAccountName string) (*mainStorage.BlobStorageClient, bool, error) {
+// getStorageClientForStorageAccount returns a client for the Storage Account's data plane. When `supportsAzureAD` is true
+// (the Blob and Queue services) and `storage_use_azuread` is enabled an Azure Active Directory token is used, otherwise
+// an Access Key is used - falling back to an Account SAS when the Access Keys can't be listed
+func (c *ArmClient) getStorageClientForStorageAccount(ctx context.Context, resourceGroupName, storageAccountName string, supportsAzureAD bool) (*mainStorage.Client, bool, error) {
+	if supportsAzureAD && c.storageUseAzureAD {
+		return c.getAzureADStorageClientForStorageAccount(ctx, resourceGroupName, storageAccountName)
+	}
+
 	key, accountExists, err := c.getKeyForStorageAccount(ctx, resourceGroupName, storageAccountName)
+	if err == errStorageAccountKeysForbidden {
+		log.Printf("[DEBUG] Unable to list the Access Keys for Storage Account %q (Resource Group %q) - falling back to an Account SAS", storageAccountName, resourceGroupName)
+		return c.getSASStorageClientForStorageAccount(ctx, resourceGroupName, storageAccountName)
+	}
 	if err != nil {
 		return nil, accountExists, err
 	}
@@ -1229,62 +1337,50 @@ func (c *ArmClient) getBlobStorageClientForStorageAccount(ctx context.Context, r
 		return nil, true, fmt.Errorf("Error creating storage client for storage storeAccount %q: %s", storageAccountName, err)
 	}
 
+	storageClient.Sender = storageAuthenticationFailedSender{
+		sender:             storageClient.Sender,
+		resourceGroupName:  resourceGroupName,
+		storageAccountName: storageAccountName,
+	}
+
+	return &storageClient, true, nil
+}
+
+func (c *ArmClient) getBlobStorageClientForStorageAccount(ctx context.Context, resourceGroupName, storageAccountName string) (*mainStorage.BlobStorageClient, bool, error) {
+	storageClient, accountExists, err := c.getStorageClientForStorageAccount(ctx, resourceGroupName, storageAccountName, true)
+	if err != nil || !accountExists {
+		return nil, accountExists, err
+	}
+
 	blobClient := storageClient.GetBlobService()
 	return &blobClient, true, nil
 }
 
 func (c *ArmClient) getFileServiceClientForStorageAccount(ctx context.Context, resourceGroupName, storageAccountName string) (*mainStorage.FileServiceClient, bool, error) {
-	key, accountExists, err := c.getKeyForStorageAccount(ctx, resourceGroupName, storageAccountName)
-	if err != nil {
+	storageClient, accountExists, err := c.getStorageClientForStorageAccount(ctx, resourceGroupName, storageAccountName, false)
+	if err != nil || !accountExists {
 		return nil, accountExists, err
 	}
-	if !accountExists {
-		return nil, false, nil
-	}
-
-	storageClient, err := mainStorage.NewClient(storageAccountName, key, c.environment.StorageEndpointSuffix,
-		mainStorage.DefaultAPIVersion, true)
-	if err != nil {
-		return nil, true, fmt.Errorf("Error creating storage client for storage storeAccount %q: %s", storageAccountName, err)
-	}
 
 	fileClient := storageClient.GetFileService()
 	return &fileClient, true, nil
 }
 
 func (c *ArmClient) getTableServiceClientForStorageAccount(ctx context.Context, resourceGroupName, storageAccountName string) (*mainStorage.TableServiceClient, bool, error) {
-	key, accountExists, err := c.getKeyForStorageAccount(ctx, resourceGroupName, storageAccountName)
-	if err != nil {
+	storageClient, accountExists, err := c.getStorageClientForStorageAccount(ctx, resourceGroupName, storageAccountName, false)
+	if err != nil || !accountExists {
 		return nil, accountExists, err
 	}
-	if !accountExists {
-		return nil, false, nil
-	}
-
-	storageClient, err := mainStorage.NewClient(storageAccountName, key, c.environment.StorageEndpointSuffix,
-		mainStorage.DefaultAPIVersion, true)
-	if err != nil {
-		return nil, true, fmt.Errorf("Error creating storage client for storage storeAccount %q: %s", storageAccountName, err)
-	}
 
 	tableClient := storageClient.GetTableService()
 	return &tableClient, true, nil
 }
 
 func (c *ArmClient) getQueueServiceClientForStorageAccount(ctx context.Context, resourceGroupName, storageAccountName string) (*mainStorage.QueueServiceClient, bool, error) {
-	key, accountExists, err := c.getKeyForStorageAccount(ctx, resourceGroupName, storageAccountName)
-	if err != nil {
+	storageClient, accountExists, err := c.getStorageClientForStorageAccount(ctx, resourceGroupName, storageAccountName, true)
+	if err != nil || !accountExists {
 		return nil, accountExists, err
 	}
-	if !accountExists {
-		return nil, false, nil
-	}
-
-	storageClient, err := mainStorage.NewClient(storageAccountName, key, c.environment.StorageEndpointSuffix,
-		mainStorage.DefaultAPIVersion, true)
-	if err != nil {
-		return nil, true, fmt.Errorf("Error creating storage client for storage storeAccount %q: %s", storageAccountName, err)
-	}
 
 	queueClient := storageClient.GetQueueService()
 	return &queueClient, true, nil
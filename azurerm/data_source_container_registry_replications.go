@@ -0,0 +1,133 @@
+package azurerm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceArmContainerRegistryReplications() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmContainerRegistryReplicationsRead,
+
+		Schema: map[string]*schema.Schema{
+			"registry_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAzureRMContainerRegistryName,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"replication": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"location": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"provisioning_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status_message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"tags": tagsForDataSourceSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmContainerRegistryReplicationsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).containerRegistryReplicationsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	registryName := d.Get("registry_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	results, err := client.ListComplete(ctx, resourceGroup, registryName)
+	if err != nil {
+		return fmt.Errorf("Error listing Replications of Container Registry %q (Resource Group %q): %+v", registryName, resourceGroup, err)
+	}
+
+	replications := make([]interface{}, 0)
+	for results.NotDone() {
+		val := results.Value()
+
+		replication := map[string]interface{}{
+			"tags": flattenContainerRegistryReplicationTags(val.Tags),
+		}
+
+		if v := val.ID; v != nil {
+			replication["id"] = *v
+		}
+		if v := val.Name; v != nil {
+			replication["name"] = *v
+		}
+		if v := val.Location; v != nil {
+			replication["location"] = azureRMNormalizeLocation(*v)
+		}
+
+		if props := val.ReplicationProperties; props != nil {
+			replication["provisioning_state"] = string(props.ProvisioningState)
+
+			if status := props.Status; status != nil {
+				if v := status.DisplayStatus; v != nil {
+					replication["status"] = *v
+				}
+				if v := status.Message; v != nil {
+					replication["status_message"] = *v
+				}
+			}
+		}
+
+		replications = append(replications, replication)
+
+		if err = results.Next(); err != nil {
+			return fmt.Errorf("Error going to next Replication of Container Registry %q (Resource Group %q): %+v", registryName, resourceGroup, err)
+		}
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("replication", replications); err != nil {
+		return fmt.Errorf("Error setting `replication`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenContainerRegistryReplicationTags(input map[string]*string) map[string]interface{} {
+	tags := make(map[string]interface{})
+	for k, v := range input {
+		if v != nil {
+			tags[k] = *v
+		}
+	}
+	return tags
+}
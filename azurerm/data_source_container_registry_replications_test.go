@@ -0,0 +1,46 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureRMContainerRegistryReplications_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_container_registry_replications.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+	altLocation := testAltLocation()
+	config := testAccDataSourceAzureRMContainerRegistryReplications_basic(ri, location, altLocation)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					// the main location is returned as a replication alongside the geo-replicated location
+					resource.TestCheckResourceAttr(dataSourceName, "replication.#", "2"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "replication.0.id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "replication.0.location"),
+					resource.TestCheckResourceAttr(dataSourceName, "replication.0.provisioning_state", "Succeeded"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMContainerRegistryReplications_basic(rInt int, location string, altLocation string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_container_registry_replications" "test" {
+  registry_name       = "${azurerm_container_registry.test.name}"
+  resource_group_name = "${azurerm_container_registry.test.resource_group_name}"
+}
+`, testAccAzureRMContainerRegistry_geoReplication(rInt, location, "Premium", fmt.Sprintf(`"%s"`, altLocation)))
+}
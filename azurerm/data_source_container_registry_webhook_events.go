@@ -0,0 +1,155 @@
+package azurerm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceArmContainerRegistryWebhookEvents() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmContainerRegistryWebhookEventsRead,
+
+		Schema: map[string]*schema.Schema{
+			"webhook_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAzureRMContainerRegistryWebhookName,
+			},
+
+			"registry_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAzureRMContainerRegistryName,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"event": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"timestamp": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"repository": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"tag": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"digest": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"actor": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"response_status_code": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"response_reason_phrase": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmContainerRegistryWebhookEventsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).containerRegistryWebhooksClient
+	ctx := meta.(*ArmClient).StopContext
+
+	webhookName := d.Get("webhook_name").(string)
+	registryName := d.Get("registry_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	results, err := client.ListEventsComplete(ctx, resourceGroup, registryName, webhookName)
+	if err != nil {
+		return fmt.Errorf("Error listing Events for Webhook %q (Container Registry %q / Resource Group %q): %+v", webhookName, registryName, resourceGroup, err)
+	}
+
+	events := make([]interface{}, 0)
+	for results.NotDone() {
+		val := results.Value()
+
+		event := make(map[string]interface{})
+		if v := val.ID; v != nil {
+			event["id"] = *v
+		}
+
+		if request := val.EventRequestMessage; request != nil && request.Content != nil {
+			content := request.Content
+
+			if v := content.Action; v != nil {
+				event["action"] = *v
+			}
+			if v := content.Timestamp; v != nil {
+				event["timestamp"] = v.Format(time.RFC3339)
+			}
+			if target := content.Target; target != nil {
+				if v := target.Repository; v != nil {
+					event["repository"] = *v
+				}
+				if v := target.Tag; v != nil {
+					event["tag"] = *v
+				}
+				if v := target.Digest; v != nil {
+					event["digest"] = *v
+				}
+			}
+			if actor := content.Actor; actor != nil && actor.Name != nil {
+				event["actor"] = *actor.Name
+			}
+		}
+
+		if response := val.EventResponseMessage; response != nil {
+			if v := response.StatusCode; v != nil {
+				event["response_status_code"] = *v
+			}
+			if v := response.ReasonPhrase; v != nil {
+				event["response_reason_phrase"] = *v
+			}
+		}
+
+		events = append(events, event)
+
+		if err = results.Next(); err != nil {
+			return fmt.Errorf("Error going to next Event for Webhook %q (Container Registry %q / Resource Group %q): %+v", webhookName, registryName, resourceGroup, err)
+		}
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("event", events); err != nil {
+		return fmt.Errorf("Error setting `event`: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureRMContainerRegistryWebhookEvents_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_container_registry_webhook_events.test"
+	ri := acctest.RandInt()
+	config := testAccDataSourceAzureRMContainerRegistryWebhookEvents_basic(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryWebhookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					// nothing has been pushed to the registry, so no events have been sent
+					resource.TestCheckResourceAttr(dataSourceName, "event.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMContainerRegistryWebhookEvents_basic(rInt int, location string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_container_registry_webhook_events" "test" {
+  webhook_name        = "${azurerm_container_registry_webhook.test.name}"
+  registry_name       = "${azurerm_container_registry_webhook.test.registry_name}"
+  resource_group_name = "${azurerm_container_registry_webhook.test.resource_group_name}"
+}
+`, testAccAzureRMContainerRegistryWebhook_basic(rInt, location))
+}
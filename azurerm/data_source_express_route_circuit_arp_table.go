@@ -0,0 +1,137 @@
+package azurerm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+)
+
+func dataSourceArmExpressRouteCircuitArpTable() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmExpressRouteCircuitArpTableRead,
+
+		Schema: map[string]*schema.Schema{
+			"express_route_circuit_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"peering_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(network.AzurePrivatePeering),
+					string(network.AzurePublicPeering),
+					string(network.MicrosoftPeering),
+				}, false),
+			},
+
+			"device_path": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"primary",
+					"secondary",
+				}, false),
+			},
+
+			"entry": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"age": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"interface": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"mac_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmExpressRouteCircuitArpTableRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).expressRouteCircuitClient
+	ctx := meta.(*ArmClient).StopContext
+
+	circuitName := d.Get("express_route_circuit_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	peeringName := d.Get("peering_name").(string)
+	devicePath := d.Get("device_path").(string)
+
+	future, err := client.ListArpTable(ctx, resourceGroup, circuitName, peeringName, devicePath)
+	if err != nil {
+		return fmt.Errorf("Error retrieving ARP Table for Peering %q / Device Path %q (Express Route Circuit %q / Resource Group %q): %+v", peeringName, devicePath, circuitName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for ARP Table for Peering %q / Device Path %q (Express Route Circuit %q / Resource Group %q): %+v", peeringName, devicePath, circuitName, resourceGroup, err)
+	}
+
+	resp, err := future.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving ARP Table result for Peering %q / Device Path %q (Express Route Circuit %q / Resource Group %q): %+v", peeringName, devicePath, circuitName, resourceGroup, err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("entry", flattenArmExpressRouteCircuitArpTable(resp.Value)); err != nil {
+		return fmt.Errorf("Error setting `entry`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenArmExpressRouteCircuitArpTable(input *[]network.ExpressRouteCircuitArpTable) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		result := make(map[string]interface{})
+
+		if age := item.Age; age != nil {
+			result["age"] = int(*age)
+		}
+
+		if iface := item.Interface; iface != nil {
+			result["interface"] = *iface
+		}
+
+		if ipAddress := item.IPAddress; ipAddress != nil {
+			result["ip_address"] = *ipAddress
+		}
+
+		if macAddress := item.MacAddress; macAddress != nil {
+			result["mac_address"] = *macAddress
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
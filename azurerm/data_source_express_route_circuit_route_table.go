@@ -0,0 +1,146 @@
+package azurerm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+)
+
+func dataSourceArmExpressRouteCircuitRouteTable() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmExpressRouteCircuitRouteTableRead,
+
+		Schema: map[string]*schema.Schema{
+			"express_route_circuit_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"peering_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(network.AzurePrivatePeering),
+					string(network.AzurePublicPeering),
+					string(network.MicrosoftPeering),
+				}, false),
+			},
+
+			"device_path": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"primary",
+					"secondary",
+				}, false),
+			},
+
+			"route": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"next_hop": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"local_preference": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"weight": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmExpressRouteCircuitRouteTableRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).expressRouteCircuitClient
+	ctx := meta.(*ArmClient).StopContext
+
+	circuitName := d.Get("express_route_circuit_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	peeringName := d.Get("peering_name").(string)
+	devicePath := d.Get("device_path").(string)
+
+	future, err := client.ListRoutesTable(ctx, resourceGroup, circuitName, peeringName, devicePath)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Route Table for Peering %q / Device Path %q (Express Route Circuit %q / Resource Group %q): %+v", peeringName, devicePath, circuitName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for Route Table for Peering %q / Device Path %q (Express Route Circuit %q / Resource Group %q): %+v", peeringName, devicePath, circuitName, resourceGroup, err)
+	}
+
+	resp, err := future.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Route Table result for Peering %q / Device Path %q (Express Route Circuit %q / Resource Group %q): %+v", peeringName, devicePath, circuitName, resourceGroup, err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("route", flattenArmExpressRouteCircuitRouteTable(resp.Value)); err != nil {
+		return fmt.Errorf("Error setting `route`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenArmExpressRouteCircuitRouteTable(input *[]network.ExpressRouteCircuitRoutesTable) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		result := make(map[string]interface{})
+
+		if v := item.NetworkProperty; v != nil {
+			result["network"] = *v
+		}
+
+		if v := item.NextHop; v != nil {
+			result["next_hop"] = *v
+		}
+
+		if v := item.LocPrf; v != nil {
+			result["local_preference"] = *v
+		}
+
+		if v := item.Weight; v != nil {
+			result["weight"] = int(*v)
+		}
+
+		if v := item.Path; v != nil {
+			result["path"] = *v
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
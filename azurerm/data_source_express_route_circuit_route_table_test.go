@@ -0,0 +1,44 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func testAccDataSourceAzureRMExpressRouteCircuitRouteTable_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_express_route_circuit_route_table.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAzureRMExpressRouteCircuitRouteTable_basicConfig(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "peering_name", "AzurePrivatePeering"),
+					resource.TestCheckResourceAttr(dataSourceName, "device_path", "primary"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "route.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMExpressRouteCircuitRouteTable_basicConfig(rInt int, location string) string {
+	config := testAccAzureRMExpressRouteCircuitPeering_privatePeering(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_express_route_circuit_route_table" "test" {
+  express_route_circuit_name = "${azurerm_express_route_circuit_peering.test.express_route_circuit_name}"
+  resource_group_name        = "${azurerm_express_route_circuit_peering.test.resource_group_name}"
+  peering_name               = "${azurerm_express_route_circuit_peering.test.peering_type}"
+  device_path                = "primary"
+}
+`, config)
+}
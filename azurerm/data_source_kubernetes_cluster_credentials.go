@@ -0,0 +1,194 @@
+package azurerm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2018-03-31/containerservice"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/kubernetes"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmKubernetesClusterCredentials() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmKubernetesClusterCredentialsRead,
+
+		Schema: map[string]*schema.Schema{
+			"cluster": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+						"admin": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"context_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"user_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"namespace": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"exec_plugin": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"command": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"args": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"api_version": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  kubernetes.DefaultExecAPIVersion,
+						},
+
+						"env": {
+							Type:     schema.TypeMap,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"current_context": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"contexts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"kube_config_raw": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceArmKubernetesClusterCredentialsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).kubernetesClustersClient
+	ctx := meta.(*ArmClient).StopContext
+
+	execPlugin := expandKubernetesClusterCredentialsExecPlugin(d.Get("exec_plugin").([]interface{}))
+
+	entries := make([]kubernetes.KubeConfigEntry, 0)
+	for _, raw := range d.Get("cluster").([]interface{}) {
+		v := raw.(map[string]interface{})
+		name := v["name"].(string)
+		resourceGroup := v["resource_group_name"].(string)
+
+		var resp containerservice.CredentialResults
+		var err error
+		if v["admin"].(bool) {
+			resp, err = client.ListClusterAdminCredentials(ctx, resourceGroup, name)
+		} else {
+			resp, err = client.ListClusterUserCredentials(ctx, resourceGroup, name)
+		}
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Error: Managed Kubernetes Cluster %q was not found in Resource Group %q", name, resourceGroup)
+			}
+
+			return fmt.Errorf("Error retrieving Credentials for Managed Kubernetes Cluster %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		if resp.Kubeconfigs == nil || len(*resp.Kubeconfigs) == 0 || (*resp.Kubeconfigs)[0].Value == nil {
+			return fmt.Errorf("Error: no Credentials were returned for Managed Kubernetes Cluster %q (Resource Group %q)", name, resourceGroup)
+		}
+
+		entries = append(entries, kubernetes.KubeConfigEntry{
+			RawConfig:   string(*(*resp.Kubeconfigs)[0].Value),
+			ContextName: v["context_name"].(string),
+			UserName:    v["user_name"].(string),
+			Namespace:   v["namespace"].(string),
+			ExecPlugin:  execPlugin,
+		})
+	}
+
+	currentContext := d.Get("current_context").(string)
+	kubeConfig, contexts, err := kubernetes.RenderKubeConfig(entries, currentContext)
+	if err != nil {
+		return fmt.Errorf("Error rendering Kube Config: %+v", err)
+	}
+
+	if currentContext == "" {
+		currentContext = contexts[0]
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	d.Set("kube_config_raw", kubeConfig)
+	d.Set("current_context", currentContext)
+	if err := d.Set("contexts", contexts); err != nil {
+		return fmt.Errorf("Error setting `contexts`: %+v", err)
+	}
+
+	return nil
+}
+
+func expandKubernetesClusterCredentialsExecPlugin(input []interface{}) *kubernetes.ExecPlugin {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+
+	args := make([]string, 0)
+	for _, arg := range v["args"].([]interface{}) {
+		args = append(args, arg.(string))
+	}
+
+	env := make(map[string]string)
+	for key, value := range v["env"].(map[string]interface{}) {
+		env[key] = value.(string)
+	}
+
+	return &kubernetes.ExecPlugin{
+		APIVersion: v["api_version"].(string),
+		Command:    v["command"].(string),
+		Args:       args,
+		Env:        env,
+	}
+}
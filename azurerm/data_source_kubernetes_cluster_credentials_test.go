@@ -0,0 +1,107 @@
+package azurerm
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureRMKubernetesClusterCredentials_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_kubernetes_cluster_credentials.test"
+	ri := acctest.RandInt()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	location := testLocation()
+	config := testAccDataSourceAzureRMKubernetesClusterCredentials_basic(ri, clientId, clientSecret, location)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "contexts.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "contexts.0", fmt.Sprintf("acctestaks%d", ri)),
+					resource.TestCheckResourceAttr(dataSourceName, "contexts.1", "admin"),
+					resource.TestCheckResourceAttr(dataSourceName, "current_context", "admin"),
+					resource.TestMatchResourceAttr(dataSourceName, "kube_config_raw", regexp.MustCompile("name: cluster-admin")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceAzureRMKubernetesClusterCredentials_execPlugin(t *testing.T) {
+	dataSourceName := "data.azurerm_kubernetes_cluster_credentials.test"
+	ri := acctest.RandInt()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	tenantId := os.Getenv("ARM_TENANT_ID")
+	location := testLocation()
+	config := testAccDataSourceAzureRMKubernetesClusterCredentials_execPlugin(ri, location, clientId, clientSecret, tenantId)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "contexts.#", "1"),
+					resource.TestMatchResourceAttr(dataSourceName, "kube_config_raw", regexp.MustCompile("command: kubelogin")),
+					resource.TestMatchResourceAttr(dataSourceName, "kube_config_raw", regexp.MustCompile("get-token")),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMKubernetesClusterCredentials_basic(rInt int, clientId string, clientSecret string, location string) string {
+	r := testAccAzureRMKubernetesCluster_basic(rInt, clientId, clientSecret, location)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_kubernetes_cluster_credentials" "test" {
+  cluster {
+    name                = "${azurerm_kubernetes_cluster.test.name}"
+    resource_group_name = "${azurerm_kubernetes_cluster.test.resource_group_name}"
+  }
+
+  cluster {
+    name                = "${azurerm_kubernetes_cluster.test.name}"
+    resource_group_name = "${azurerm_kubernetes_cluster.test.resource_group_name}"
+    admin               = true
+    context_name        = "admin"
+    user_name           = "cluster-admin"
+    namespace           = "kube-system"
+  }
+
+  current_context = "admin"
+}
+`, r)
+}
+
+func testAccDataSourceAzureRMKubernetesClusterCredentials_execPlugin(rInt int, location, clientId, clientSecret, tenantId string) string {
+	r := testAccAzureRMKubernetesCluster_roleBasedAccessControlAAD(rInt, location, clientId, clientSecret, tenantId)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_kubernetes_cluster_credentials" "test" {
+  cluster {
+    name                = "${azurerm_kubernetes_cluster.test.name}"
+    resource_group_name = "${azurerm_kubernetes_cluster.test.resource_group_name}"
+  }
+
+  exec_plugin {
+    command = "kubelogin"
+  }
+}
+`, r)
+}
@@ -0,0 +1,147 @@
+package azurerm
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerservice/mgmt/2018-03-31/containerservice"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmKubernetesClusterUpgradeVersions() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmKubernetesClusterUpgradeVersionsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"kubernetes_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"upgrades": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"agent_pool_profile": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"kubernetes_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"os_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"upgrades": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmKubernetesClusterUpgradeVersionsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).kubernetesClustersClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	resp, err := client.GetUpgradeProfile(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("Error: Managed Kubernetes Cluster %q was not found in Resource Group %q", name, resourceGroup)
+		}
+
+		return fmt.Errorf("Error retrieving Upgrade Profile for Managed Kubernetes Cluster %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if resp.ID == nil {
+		return fmt.Errorf("Error retrieving Upgrade Profile for Managed Kubernetes Cluster %q (Resource Group %q): ID was nil", name, resourceGroup)
+	}
+
+	d.SetId(*resp.ID)
+
+	upgrades := make([]interface{}, 0)
+	agentPoolProfiles := make([]interface{}, 0)
+	if props := resp.ManagedClusterUpgradeProfileProperties; props != nil {
+		if controlPlane := props.ControlPlaneProfile; controlPlane != nil {
+			d.Set("kubernetes_version", controlPlane.KubernetesVersion)
+			upgrades = flattenKubernetesClusterUpgradeVersions(controlPlane.Upgrades)
+		}
+
+		agentPoolProfiles = flattenKubernetesClusterAgentPoolUpgradeProfiles(props.AgentPoolProfiles)
+	}
+
+	if err := d.Set("upgrades", upgrades); err != nil {
+		return fmt.Errorf("Error setting `upgrades`: %+v", err)
+	}
+
+	if err := d.Set("agent_pool_profile", agentPoolProfiles); err != nil {
+		return fmt.Errorf("Error setting `agent_pool_profile`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenKubernetesClusterAgentPoolUpgradeProfiles(input *[]containerservice.ManagedClusterPoolUpgradeProfile) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, profile := range *input {
+		result := map[string]interface{}{
+			"os_type":  string(profile.OsType),
+			"upgrades": flattenKubernetesClusterUpgradeVersions(profile.Upgrades),
+		}
+
+		if profile.Name != nil {
+			result["name"] = *profile.Name
+		}
+
+		if profile.KubernetesVersion != nil {
+			result["kubernetes_version"] = *profile.KubernetesVersion
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func flattenKubernetesClusterUpgradeVersions(input *[]string) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, version := range *input {
+		results = append(results, version)
+	}
+
+	return results
+}
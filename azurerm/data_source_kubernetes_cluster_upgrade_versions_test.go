@@ -0,0 +1,49 @@
+package azurerm
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureRMKubernetesClusterUpgradeVersions_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_kubernetes_cluster_upgrade_versions.test"
+	ri := acctest.RandInt()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	location := testLocation()
+	config := testAccDataSourceAzureRMKubernetesClusterUpgradeVersions_basic(ri, clientId, clientSecret, location)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "kubernetes_version", "1.10.9"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "upgrades.#"),
+					resource.TestCheckResourceAttr(dataSourceName, "agent_pool_profile.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "agent_pool_profile.0.name", "default"),
+					resource.TestCheckResourceAttr(dataSourceName, "agent_pool_profile.0.kubernetes_version", "1.10.9"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMKubernetesClusterUpgradeVersions_basic(rInt int, clientId string, clientSecret string, location string) string {
+	r := testAccAzureRMKubernetesCluster_upgrade(rInt, location, clientId, clientSecret, "1.10.9")
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_kubernetes_cluster_upgrade_versions" "test" {
+  name                = "${azurerm_kubernetes_cluster.test.name}"
+  resource_group_name = "${azurerm_kubernetes_cluster.test.resource_group_name}"
+}
+`, r)
+}
@@ -0,0 +1,108 @@
+package azurerm
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+)
+
+func dataSourceArmLoadBalancerBackendAddressPool() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmLoadBalancerBackendAddressPoolRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"loadbalancer_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"backend_ip_configurations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"load_balancing_rules": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"outbound_rule_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceArmLoadBalancerBackendAddressPoolRead(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("name").(string)
+	loadBalancerId := d.Get("loadbalancer_id").(string)
+
+	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerId, meta)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Load Balancer by ID: %+v", err)
+	}
+	if !exists {
+		return fmt.Errorf("Error: Load Balancer %q was not found", loadBalancerId)
+	}
+
+	pool, _, exists := findLoadBalancerBackEndAddressPoolByName(loadBalancer, name)
+	if !exists {
+		return fmt.Errorf("Error: Backend Address Pool %q was not found in Load Balancer %q", name, loadBalancerId)
+	}
+
+	if pool.ID == nil {
+		return fmt.Errorf("Error: ID was nil for Backend Address Pool %q in Load Balancer %q", name, loadBalancerId)
+	}
+
+	d.SetId(*pool.ID)
+
+	backendIpConfigurations := make([]string, 0)
+	loadBalancingRules := make([]string, 0)
+	outboundRuleId := ""
+
+	if props := pool.BackendAddressPoolPropertiesFormat; props != nil {
+		if configs := props.BackendIPConfigurations; configs != nil {
+			for _, config := range *configs {
+				if config.ID != nil {
+					backendIpConfigurations = append(backendIpConfigurations, *config.ID)
+				}
+			}
+		}
+
+		if rules := props.LoadBalancingRules; rules != nil {
+			for _, rule := range *rules {
+				if rule.ID != nil {
+					loadBalancingRules = append(loadBalancingRules, *rule.ID)
+				}
+			}
+		}
+
+		if rule := props.OutboundNatRule; rule != nil && rule.ID != nil {
+			outboundRuleId = *rule.ID
+		}
+	}
+
+	if err := d.Set("backend_ip_configurations", backendIpConfigurations); err != nil {
+		return fmt.Errorf("Error setting `backend_ip_configurations`: %+v", err)
+	}
+
+	if err := d.Set("load_balancing_rules", loadBalancingRules); err != nil {
+		return fmt.Errorf("Error setting `load_balancing_rules`: %+v", err)
+	}
+
+	d.Set("outbound_rule_id", outboundRuleId)
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureRMLoadBalancerBackEndAddressPool_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_lb_backend_address_pool.test"
+	ri := acctest.RandInt()
+	outboundRuleName := fmt.Sprintf("OutboundRule-%d", ri)
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAzureRMLoadBalancerBackEndAddressPool_basic(ri, outboundRuleName, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "outbound_rule_id"),
+					resource.TestCheckResourceAttr(dataSourceName, "backend_ip_configurations.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMLoadBalancerBackEndAddressPool_basic(rInt int, outboundRuleName string, location string) string {
+	resource := testAccAzureRMLoadBalancerOutboundRule_basic(rInt, outboundRuleName, 1024, location)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_lb_backend_address_pool" "test" {
+  name            = "${azurerm_lb_backend_address_pool.test.name}"
+  loadbalancer_id = "${azurerm_lb_outbound_rule.test.loadbalancer_id}"
+}
+`, resource)
+}
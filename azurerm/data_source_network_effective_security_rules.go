@@ -0,0 +1,220 @@
+package azurerm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmNetworkEffectiveSecurityRules() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmNetworkEffectiveSecurityRulesRead,
+
+		Schema: map[string]*schema.Schema{
+			"network_watcher_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"virtual_machine_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"network_interface": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"effective_security_rule": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+
+									"priority": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+
+									"direction": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+
+									"access": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+
+									"protocol": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+
+									"source_port_ranges": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+
+									"destination_port_ranges": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+
+									"source_address_prefixes": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+
+									"destination_address_prefixes": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+
+									"expanded_source_address_prefixes": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+
+									"expanded_destination_address_prefixes": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmNetworkEffectiveSecurityRulesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).watcherClient
+	ctx := meta.(*ArmClient).StopContext
+
+	watcherName := d.Get("network_watcher_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	virtualMachineId := d.Get("virtual_machine_id").(string)
+
+	parameters := network.SecurityGroupViewParameters{
+		TargetResourceID: utils.String(virtualMachineId),
+	}
+
+	future, err := client.GetVMSecurityRules(ctx, resourceGroup, watcherName, parameters)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Effective Security Rules for Virtual Machine %q (Watcher %q / Resource Group %q): %+v", virtualMachineId, watcherName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for Effective Security Rules for Virtual Machine %q (Watcher %q / Resource Group %q): %+v", virtualMachineId, watcherName, resourceGroup, err)
+	}
+
+	resp, err := future.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Effective Security Rules result for Virtual Machine %q (Watcher %q / Resource Group %q): %+v", virtualMachineId, watcherName, resourceGroup, err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("network_interface", flattenArmNetworkEffectiveSecurityRulesInterfaces(resp.NetworkInterfaces)); err != nil {
+		return fmt.Errorf("Error setting `network_interface`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenArmNetworkEffectiveSecurityRulesInterfaces(input *[]network.SecurityGroupNetworkInterface) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		result := make(map[string]interface{})
+
+		if id := item.ID; id != nil {
+			result["id"] = *id
+		}
+
+		rules := make([]interface{}, 0)
+		if associations := item.SecurityRuleAssociations; associations != nil && associations.EffectiveSecurityRules != nil {
+			for _, rule := range *associations.EffectiveSecurityRules {
+				rules = append(rules, flattenArmNetworkEffectiveSecurityRule(rule))
+			}
+		}
+		result["effective_security_rule"] = rules
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func flattenArmNetworkEffectiveSecurityRule(input network.EffectiveNetworkSecurityRule) map[string]interface{} {
+	output := map[string]interface{}{
+		"direction": string(input.Direction),
+		"access":    string(input.Access),
+		"protocol":  string(input.Protocol),
+	}
+
+	if name := input.Name; name != nil {
+		output["name"] = *name
+	}
+
+	if priority := input.Priority; priority != nil {
+		output["priority"] = int(*priority)
+	}
+
+	// the API returns either the singular or the plural form of these fields, so combine them
+	output["source_port_ranges"] = flattenArmNetworkEffectiveSecurityRuleValues(input.SourcePortRange, input.SourcePortRanges)
+	output["destination_port_ranges"] = flattenArmNetworkEffectiveSecurityRuleValues(input.DestinationPortRange, input.DestinationPortRanges)
+	output["source_address_prefixes"] = flattenArmNetworkEffectiveSecurityRuleValues(input.SourceAddressPrefix, input.SourceAddressPrefixes)
+	output["destination_address_prefixes"] = flattenArmNetworkEffectiveSecurityRuleValues(input.DestinationAddressPrefix, input.DestinationAddressPrefixes)
+	output["expanded_source_address_prefixes"] = flattenArmNetworkEffectiveSecurityRuleValues(nil, input.ExpandedSourceAddressPrefix)
+	output["expanded_destination_address_prefixes"] = flattenArmNetworkEffectiveSecurityRuleValues(nil, input.ExpandedDestinationAddressPrefix)
+
+	return output
+}
+
+func flattenArmNetworkEffectiveSecurityRuleValues(single *string, multiple *[]string) []interface{} {
+	results := make([]interface{}, 0)
+
+	if single != nil && *single != "" {
+		results = append(results, *single)
+	}
+
+	if multiple != nil {
+		for _, v := range *multiple {
+			results = append(results, v)
+		}
+	}
+
+	return results
+}
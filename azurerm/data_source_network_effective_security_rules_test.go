@@ -0,0 +1,46 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func testAccDataSourceAzureRMNetworkEffectiveSecurityRules_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_network_effective_security_rules.test"
+
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAzureRMNetworkEffectiveSecurityRules_basicConfig(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "network_interface.#", "1"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "network_interface.0.id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "network_interface.0.effective_security_rule.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMNetworkEffectiveSecurityRules_basicConfig(rInt int, location string) string {
+	config := testAzureRMPacketCapture_base(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_network_effective_security_rules" "test" {
+  network_watcher_name = "${azurerm_network_watcher.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  virtual_machine_id   = "${azurerm_virtual_machine.test.id}"
+
+  depends_on = ["azurerm_virtual_machine_extension.test"]
+}
+`, config)
+}
@@ -0,0 +1,184 @@
+package azurerm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmNetworkTopology() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmNetworkTopologyRead,
+
+		Schema: map[string]*schema.Schema{
+			"network_watcher_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"target_resource_group_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validate.NoEmptyStrings,
+				ConflictsWith: []string{"target_virtual_network_id", "target_subnet_id"},
+			},
+
+			"target_virtual_network_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  azure.ValidateResourceID,
+				ConflictsWith: []string{"target_resource_group_name", "target_subnet_id"},
+			},
+
+			"target_subnet_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  azure.ValidateResourceID,
+				ConflictsWith: []string{"target_resource_group_name", "target_virtual_network_id"},
+			},
+
+			"resource": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"location": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"association": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+
+									"resource_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmNetworkTopologyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).watcherClient
+	ctx := meta.(*ArmClient).StopContext
+
+	watcherName := d.Get("network_watcher_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	parameters := network.TopologyParameters{}
+
+	targetResourceGroup := d.Get("target_resource_group_name").(string)
+	targetVirtualNetworkId := d.Get("target_virtual_network_id").(string)
+	targetSubnetId := d.Get("target_subnet_id").(string)
+
+	switch {
+	case targetResourceGroup != "":
+		parameters.TargetResourceGroupName = utils.String(targetResourceGroup)
+	case targetVirtualNetworkId != "":
+		parameters.TargetVirtualNetwork = &network.SubResource{
+			ID: utils.String(targetVirtualNetworkId),
+		}
+	case targetSubnetId != "":
+		parameters.TargetSubnet = &network.SubResource{
+			ID: utils.String(targetSubnetId),
+		}
+	default:
+		return fmt.Errorf("One of `target_resource_group_name`, `target_virtual_network_id` or `target_subnet_id` must be specified")
+	}
+
+	resp, err := client.GetTopology(ctx, resourceGroup, watcherName, parameters)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Network Topology (Watcher %q / Resource Group %q): %+v", watcherName, resourceGroup, err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("resource", flattenArmNetworkTopologyResources(resp.Resources)); err != nil {
+		return fmt.Errorf("Error setting `resource`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenArmNetworkTopologyResources(input *[]network.TopologyResource) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		result := make(map[string]interface{})
+
+		if name := item.Name; name != nil {
+			result["name"] = *name
+		}
+
+		if id := item.ID; id != nil {
+			result["id"] = *id
+		}
+
+		if location := item.Location; location != nil {
+			result["location"] = azureRMNormalizeLocation(*location)
+		}
+
+		associations := make([]interface{}, 0)
+		if item.Associations != nil {
+			for _, association := range *item.Associations {
+				output := map[string]interface{}{
+					"type": string(association.AssociationType),
+				}
+
+				if name := association.Name; name != nil {
+					output["name"] = *name
+				}
+
+				if resourceId := association.ResourceID; resourceId != nil {
+					output["resource_id"] = *resourceId
+				}
+
+				associations = append(associations, output)
+			}
+		}
+		result["association"] = associations
+
+		results = append(results, result)
+	}
+
+	return results
+}
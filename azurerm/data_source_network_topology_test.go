@@ -0,0 +1,45 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func testAccDataSourceAzureRMNetworkTopology_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_network_topology.test"
+
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAzureRMNetworkTopology_basicConfig(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "resource.#"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "resource.0.id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMNetworkTopology_basicConfig(rInt int, location string) string {
+	config := testAzureRMPacketCapture_base(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_network_topology" "test" {
+  network_watcher_name      = "${azurerm_network_watcher.test.name}"
+  resource_group_name       = "${azurerm_resource_group.test.name}"
+  target_virtual_network_id = "${azurerm_virtual_network.test.id}"
+
+  depends_on = ["azurerm_virtual_machine.test"]
+}
+`, config)
+}
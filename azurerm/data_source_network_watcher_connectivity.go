@@ -0,0 +1,307 @@
+package azurerm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmNetworkWatcherConnectivity() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmNetworkWatcherConnectivityRead,
+
+		Schema: map[string]*schema.Schema{
+			"network_watcher_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"protocol": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(network.ProtocolTCP),
+					string(network.ProtocolHTTP),
+					string(network.ProtocolHTTPS),
+					string(network.ProtocolIcmp),
+				}, false),
+			},
+
+			"source": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"virtual_machine_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validate.PortNumberOrZero,
+						},
+					},
+				},
+			},
+
+			"destination": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"virtual_machine_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"address": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validate.PortNumberOrZero,
+						},
+					},
+				},
+			},
+
+			"connection_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"avg_latency_in_ms": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"min_latency_in_ms": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"max_latency_in_ms": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"probes_sent": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"probes_failed": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"hop": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"resource_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"next_hop_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"issue": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"origin": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+
+									"severity": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmNetworkWatcherConnectivityRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).watcherClient
+	ctx := meta.(*ArmClient).StopContext
+
+	watcherName := d.Get("network_watcher_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	sources := d.Get("source").([]interface{})
+	source := sources[0].(map[string]interface{})
+	connectivitySource := network.ConnectivitySource{
+		ResourceID: utils.String(source["virtual_machine_id"].(string)),
+	}
+	if v := source["port"].(int); v != 0 {
+		connectivitySource.Port = utils.Int32(int32(v))
+	}
+
+	destinations := d.Get("destination").([]interface{})
+	destination := destinations[0].(map[string]interface{})
+	connectivityDestination := network.ConnectivityDestination{}
+	if v := destination["virtual_machine_id"].(string); v != "" {
+		connectivityDestination.ResourceID = utils.String(v)
+	}
+	if v := destination["address"].(string); v != "" {
+		connectivityDestination.Address = utils.String(v)
+	}
+	if connectivityDestination.ResourceID == nil && connectivityDestination.Address == nil {
+		return fmt.Errorf("One of `destination.0.virtual_machine_id` or `destination.0.address` must be specified")
+	}
+	if v := destination["port"].(int); v != 0 {
+		connectivityDestination.Port = utils.Int32(int32(v))
+	}
+
+	parameters := network.ConnectivityParameters{
+		Source:      &connectivitySource,
+		Destination: &connectivityDestination,
+	}
+
+	if v := d.Get("protocol").(string); v != "" {
+		parameters.Protocol = network.Protocol(v)
+	}
+
+	future, err := client.CheckConnectivity(ctx, resourceGroup, watcherName, parameters)
+	if err != nil {
+		return fmt.Errorf("Error checking Connectivity (Watcher %q / Resource Group %q): %+v", watcherName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for Connectivity check (Watcher %q / Resource Group %q): %+v", watcherName, resourceGroup, err)
+	}
+
+	resp, err := future.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Connectivity check result (Watcher %q / Resource Group %q): %+v", watcherName, resourceGroup, err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	d.Set("connection_status", string(resp.ConnectionStatus))
+	if v := resp.AvgLatencyInMs; v != nil {
+		d.Set("avg_latency_in_ms", int(*v))
+	}
+	if v := resp.MinLatencyInMs; v != nil {
+		d.Set("min_latency_in_ms", int(*v))
+	}
+	if v := resp.MaxLatencyInMs; v != nil {
+		d.Set("max_latency_in_ms", int(*v))
+	}
+	if v := resp.ProbesSent; v != nil {
+		d.Set("probes_sent", int(*v))
+	}
+	if v := resp.ProbesFailed; v != nil {
+		d.Set("probes_failed", int(*v))
+	}
+
+	if err := d.Set("hop", flattenArmNetworkWatcherConnectivityHops(resp.Hops)); err != nil {
+		return fmt.Errorf("Error setting `hop`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenArmNetworkWatcherConnectivityHops(input *[]network.ConnectivityHop) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, hop := range *input {
+		result := make(map[string]interface{})
+
+		if id := hop.ID; id != nil {
+			result["id"] = *id
+		}
+
+		if hopType := hop.Type; hopType != nil {
+			result["type"] = *hopType
+		}
+
+		if address := hop.Address; address != nil {
+			result["address"] = *address
+		}
+
+		if resourceId := hop.ResourceID; resourceId != nil {
+			result["resource_id"] = *resourceId
+		}
+
+		nextHopIds := make([]interface{}, 0)
+		if hop.NextHopIds != nil {
+			for _, v := range *hop.NextHopIds {
+				nextHopIds = append(nextHopIds, v)
+			}
+		}
+		result["next_hop_ids"] = nextHopIds
+
+		issues := make([]interface{}, 0)
+		if hop.Issues != nil {
+			for _, issue := range *hop.Issues {
+				issues = append(issues, map[string]interface{}{
+					"origin":   string(issue.Origin),
+					"severity": string(issue.Severity),
+					"type":     string(issue.Type),
+				})
+			}
+		}
+		result["issue"] = issues
+
+		results = append(results, result)
+	}
+
+	return results
+}
@@ -0,0 +1,55 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func testAccDataSourceAzureRMNetworkWatcherConnectivity_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_network_watcher_connectivity.test"
+
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAzureRMNetworkWatcherConnectivity_basicConfig(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "connection_status"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "probes_sent"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "hop.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMNetworkWatcherConnectivity_basicConfig(rInt int, location string) string {
+	config := testAzureRMPacketCapture_base(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_network_watcher_connectivity" "test" {
+  network_watcher_name = "${azurerm_network_watcher.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  protocol             = "Tcp"
+
+  source {
+    virtual_machine_id = "${azurerm_virtual_machine.test.id}"
+  }
+
+  destination {
+    address = "terraform.io"
+    port    = 443
+  }
+
+  depends_on = ["azurerm_virtual_machine_extension.test"]
+}
+`, config)
+}
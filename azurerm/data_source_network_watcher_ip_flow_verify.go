@@ -0,0 +1,137 @@
+package azurerm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmNetworkWatcherIPFlowVerify() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmNetworkWatcherIPFlowVerifyRead,
+
+		Schema: map[string]*schema.Schema{
+			"network_watcher_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"target_resource_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"target_network_interface_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"direction": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(network.Inbound),
+					string(network.Outbound),
+				}, false),
+			},
+
+			"protocol": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(network.IPFlowProtocolTCP),
+					string(network.IPFlowProtocolUDP),
+				}, false),
+			},
+
+			"local_ip_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.IPv4Address,
+			},
+
+			"local_port": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"remote_ip_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.IPv4Address,
+			},
+
+			"remote_port": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"access": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"rule_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceArmNetworkWatcherIPFlowVerifyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).watcherClient
+	ctx := meta.(*ArmClient).StopContext
+
+	watcherName := d.Get("network_watcher_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	targetResourceId := d.Get("target_resource_id").(string)
+
+	parameters := network.VerificationIPFlowParameters{
+		TargetResourceID: utils.String(targetResourceId),
+		Direction:        network.Direction(d.Get("direction").(string)),
+		Protocol:         network.IPFlowProtocol(d.Get("protocol").(string)),
+		LocalIPAddress:   utils.String(d.Get("local_ip_address").(string)),
+		LocalPort:        utils.String(d.Get("local_port").(string)),
+		RemoteIPAddress:  utils.String(d.Get("remote_ip_address").(string)),
+		RemotePort:       utils.String(d.Get("remote_port").(string)),
+	}
+
+	if v := d.Get("target_network_interface_id").(string); v != "" {
+		parameters.TargetNicResourceID = utils.String(v)
+	}
+
+	future, err := client.VerifyIPFlow(ctx, resourceGroup, watcherName, parameters)
+	if err != nil {
+		return fmt.Errorf("Error verifying IP Flow for %q (Watcher %q / Resource Group %q): %+v", targetResourceId, watcherName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for IP Flow verification for %q (Watcher %q / Resource Group %q): %+v", targetResourceId, watcherName, resourceGroup, err)
+	}
+
+	resp, err := future.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving IP Flow verification result for %q (Watcher %q / Resource Group %q): %+v", targetResourceId, watcherName, resourceGroup, err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	d.Set("access", string(resp.Access))
+	d.Set("rule_name", resp.RuleName)
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package azurerm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmNetworkWatcherNextHop() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmNetworkWatcherNextHopRead,
+
+		Schema: map[string]*schema.Schema{
+			"network_watcher_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"target_resource_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"target_network_interface_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"source_ip_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.IPv4Address,
+			},
+
+			"destination_ip_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.IPv4Address,
+			},
+
+			"next_hop_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"next_hop_ip_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"route_table_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceArmNetworkWatcherNextHopRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).watcherClient
+	ctx := meta.(*ArmClient).StopContext
+
+	watcherName := d.Get("network_watcher_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	targetResourceId := d.Get("target_resource_id").(string)
+
+	parameters := network.NextHopParameters{
+		TargetResourceID:     utils.String(targetResourceId),
+		SourceIPAddress:      utils.String(d.Get("source_ip_address").(string)),
+		DestinationIPAddress: utils.String(d.Get("destination_ip_address").(string)),
+	}
+
+	if v := d.Get("target_network_interface_id").(string); v != "" {
+		parameters.TargetNicResourceID = utils.String(v)
+	}
+
+	future, err := client.GetNextHop(ctx, resourceGroup, watcherName, parameters)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Next Hop for %q (Watcher %q / Resource Group %q): %+v", targetResourceId, watcherName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for Next Hop for %q (Watcher %q / Resource Group %q): %+v", targetResourceId, watcherName, resourceGroup, err)
+	}
+
+	resp, err := future.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Next Hop result for %q (Watcher %q / Resource Group %q): %+v", targetResourceId, watcherName, resourceGroup, err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	d.Set("next_hop_type", string(resp.NextHopType))
+	d.Set("next_hop_ip_address", resp.NextHopIPAddress)
+	d.Set("route_table_id", resp.RouteTableID)
+
+	return nil
+}
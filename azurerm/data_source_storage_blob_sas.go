@@ -0,0 +1,44 @@
+package azurerm
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+)
+
+// storageBlobSASPermissions are the permissions supported by a Service SAS for a Blob, in the order they appear in the SAS
+var storageBlobSASPermissions = []string{"read", "add", "create", "write", "delete"}
+
+// This is a SERVICE SAS for a Blob: https://docs.microsoft.com/en-us/rest/api/storageservices/constructing-a-service-sas
+func dataSourceArmStorageBlobSharedAccessSignature() *schema.Resource {
+	s := storageServiceSASSchema(storageBlobSASPermissions)
+
+	s["container_name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validateArmStorageContainerName,
+	}
+	s["blob_name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validate.NoEmptyStrings,
+	}
+
+	return &schema.Resource{
+		Read:   dataSourceArmStorageBlobSasRead,
+		Schema: s,
+	}
+}
+
+func dataSourceArmStorageBlobSasRead(d *schema.ResourceData, meta interface{}) error {
+	containerName := d.Get("container_name").(string)
+	blobName := d.Get("blob_name").(string)
+
+	sas, err := expandStorageServiceSAS(d, "blob", fmt.Sprintf("%s/%s", containerName, blobName), "b", storageBlobSASPermissions)
+	if err != nil {
+		return err
+	}
+
+	return flattenStorageServiceSAS(d, meta, sas)
+}
@@ -0,0 +1,141 @@
+package azurerm
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceArmStorageBlobSas_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_storage_blob_sas.test"
+	rInt := acctest.RandInt()
+	rString := acctest.RandString(4)
+	location := testLocation()
+	utcNow := time.Now().UTC()
+	startDate := utcNow.Format(time.RFC3339)
+	endDate := utcNow.Add(time.Hour * 24).Format(time.RFC3339)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAzureRMStorageBlobSas_basic(rInt, rString, location, startDate, endDate),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "https_only", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "start", startDate),
+					resource.TestCheckResourceAttr(dataSourceName, "expiry", endDate),
+					resource.TestCheckResourceAttrSet(dataSourceName, "sas"),
+					resource.TestMatchResourceAttr(dataSourceName, "url", regexp.MustCompile(`^https://acctestsads[a-z0-9]+\.blob\.[^/]+/sas/example\.txt\?`)),
+				),
+			},
+			{
+				Config:      testAccDataSourceAzureRMStorageBlobSas_noExpiry(rInt, rString, location),
+				ExpectError: regexp.MustCompile("`expiry` must be specified"),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMStorageBlobSas_basic(rInt int, rString string, location string, startDate string, endDate string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsads%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "sas"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  storage_account_name  = "${azurerm_storage_account.test.name}"
+  container_access_type = "private"
+}
+
+resource "azurerm_storage_blob" "test" {
+  name                   = "example.txt"
+  resource_group_name    = "${azurerm_resource_group.test.name}"
+  storage_account_name   = "${azurerm_storage_account.test.name}"
+  storage_container_name = "${azurerm_storage_container.test.name}"
+  type                   = "block"
+  source_content         = "Hello World"
+}
+
+data "azurerm_storage_blob_sas" "test" {
+  connection_string = "${azurerm_storage_account.test.primary_connection_string}"
+  container_name    = "${azurerm_storage_container.test.name}"
+  blob_name         = "${azurerm_storage_blob.test.name}"
+  https_only        = true
+  start             = "%s"
+  expiry            = "%s"
+
+  cache_control = "no-cache"
+
+  permissions {
+    read   = true
+    add    = false
+    create = false
+    write  = false
+    delete = false
+  }
+}
+`, rInt, location, rString, startDate, endDate)
+}
+
+func testAccDataSourceAzureRMStorageBlobSas_noExpiry(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsads%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "sas"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  storage_account_name  = "${azurerm_storage_account.test.name}"
+  container_access_type = "private"
+}
+
+resource "azurerm_storage_blob" "test" {
+  name                   = "example.txt"
+  resource_group_name    = "${azurerm_resource_group.test.name}"
+  storage_account_name   = "${azurerm_storage_account.test.name}"
+  storage_container_name = "${azurerm_storage_container.test.name}"
+  type                   = "block"
+  source_content         = "Hello World"
+}
+
+data "azurerm_storage_blob_sas" "test" {
+  connection_string = "${azurerm_storage_account.test.primary_connection_string}"
+  container_name    = "${azurerm_storage_container.test.name}"
+  blob_name         = "${azurerm_storage_blob.test.name}"
+
+  permissions {
+    read   = true
+    add    = false
+    create = false
+    write  = false
+    delete = false
+  }
+}
+`, rInt, location, rString)
+}
@@ -0,0 +1,35 @@
+package azurerm
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// storageContainerSASPermissions are the permissions supported by a Service SAS for a Container, in the order they appear in the SAS
+var storageContainerSASPermissions = []string{"read", "add", "create", "write", "delete", "list"}
+
+// This is a SERVICE SAS for a Container: https://docs.microsoft.com/en-us/rest/api/storageservices/constructing-a-service-sas
+func dataSourceArmStorageContainerSharedAccessSignature() *schema.Resource {
+	s := storageServiceSASSchema(storageContainerSASPermissions)
+
+	s["container_name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validateArmStorageContainerName,
+	}
+
+	return &schema.Resource{
+		Read:   dataSourceArmStorageContainerSasRead,
+		Schema: s,
+	}
+}
+
+func dataSourceArmStorageContainerSasRead(d *schema.ResourceData, meta interface{}) error {
+	containerName := d.Get("container_name").(string)
+
+	sas, err := expandStorageServiceSAS(d, "blob", containerName, "c", storageContainerSASPermissions)
+	if err != nil {
+		return err
+	}
+
+	return flattenStorageServiceSAS(d, meta, sas)
+}
@@ -0,0 +1,35 @@
+package azurerm
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// storageShareSASPermissions are the permissions supported by a Service SAS for a File Share, in the order they appear in the SAS
+var storageShareSASPermissions = []string{"read", "create", "write", "delete", "list"}
+
+// This is a SERVICE SAS for a File Share: https://docs.microsoft.com/en-us/rest/api/storageservices/constructing-a-service-sas
+func dataSourceArmStorageShareSharedAccessSignature() *schema.Resource {
+	s := storageServiceSASSchema(storageShareSASPermissions)
+
+	s["share_name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validateArmStorageShareName,
+	}
+
+	return &schema.Resource{
+		Read:   dataSourceArmStorageShareSasRead,
+		Schema: s,
+	}
+}
+
+func dataSourceArmStorageShareSasRead(d *schema.ResourceData, meta interface{}) error {
+	shareName := d.Get("share_name").(string)
+
+	sas, err := expandStorageServiceSAS(d, "file", shareName, "s", storageShareSASPermissions)
+	if err != nil {
+		return err
+	}
+
+	return flattenStorageServiceSAS(d, meta, sas)
+}
@@ -0,0 +1,90 @@
+package azurerm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceArmVirtualMachineRunCommands() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmVirtualMachineRunCommandsRead,
+
+		Schema: map[string]*schema.Schema{
+			"location": locationSchema(),
+
+			"command": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"label": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"os_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmVirtualMachineRunCommandsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmRunCommandsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	location := azureRMNormalizeLocation(d.Get("location").(string))
+
+	results, err := client.ListComplete(ctx, location)
+	if err != nil {
+		return fmt.Errorf("Error listing Virtual Machine Run Commands (Location %q): %+v", location, err)
+	}
+
+	commands := make([]interface{}, 0)
+	for results.NotDone() {
+		val := results.Value()
+
+		command := map[string]interface{}{
+			"os_type": string(val.OsType),
+		}
+		if v := val.ID; v != nil {
+			command["id"] = *v
+		}
+		if v := val.Label; v != nil {
+			command["label"] = *v
+		}
+		if v := val.Description; v != nil {
+			command["description"] = *v
+		}
+		commands = append(commands, command)
+
+		if err = results.Next(); err != nil {
+			return fmt.Errorf("Error going to next Virtual Machine Run Commands value (Location %q): %+v", location, err)
+		}
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	d.Set("location", location)
+	if err := d.Set("command", commands); err != nil {
+		return fmt.Errorf("Error setting `command`: %+v", err)
+	}
+
+	return nil
+}
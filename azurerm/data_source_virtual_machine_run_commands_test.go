@@ -0,0 +1,36 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureRMVirtualMachineRunCommands_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_virtual_machine_run_commands.test"
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAzureRMVirtualMachineRunCommands_basic(location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "command.#"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "command.0.id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "command.0.os_type"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMVirtualMachineRunCommands_basic(location string) string {
+	return fmt.Sprintf(`
+data "azurerm_virtual_machine_run_commands" "test" {
+  location = "%s"
+}
+`, location)
+}
@@ -0,0 +1,212 @@
+package azurerm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+)
+
+func dataSourceArmVirtualMachineScaleSetInstances() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmVirtualMachineScaleSetInstancesRead,
+
+		Schema: map[string]*schema.Schema{
+			"virtual_machine_scale_set_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"instance": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"instance_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"computer_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"private_ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"private_ip_addresses": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"zones": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"latest_model_applied": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"power_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmVirtualMachineScaleSetInstancesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmScaleSetVMsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	scaleSetName := d.Get("virtual_machine_scale_set_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	privateIPAddresses, err := retrieveVirtualMachineScaleSetPrivateIPAddresses(meta, resourceGroup, scaleSetName)
+	if err != nil {
+		return err
+	}
+
+	results, err := client.ListComplete(ctx, resourceGroup, scaleSetName, "", "", "instanceView")
+	if err != nil {
+		return fmt.Errorf("Error listing instances of Virtual Machine Scale Set %q (Resource Group %q): %+v", scaleSetName, resourceGroup, err)
+	}
+
+	instances := make([]interface{}, 0)
+	for results.NotDone() {
+		val := results.Value()
+
+		instance := map[string]interface{}{
+			"private_ip_address":   "",
+			"private_ip_addresses": make([]interface{}, 0),
+			"zones":                make([]interface{}, 0),
+		}
+
+		if v := val.ID; v != nil {
+			instance["id"] = *v
+
+			if ips, ok := privateIPAddresses[strings.ToLower(*v)]; ok && len(ips) > 0 {
+				instance["private_ip_address"] = ips[0]
+				instance["private_ip_addresses"] = ips
+			}
+		}
+		if v := val.InstanceID; v != nil {
+			instance["instance_id"] = *v
+		}
+		if v := val.Name; v != nil {
+			instance["name"] = *v
+		}
+		if v := val.Zones; v != nil {
+			zones := make([]interface{}, 0)
+			for _, zone := range *v {
+				zones = append(zones, zone)
+			}
+			instance["zones"] = zones
+		}
+
+		if props := val.VirtualMachineScaleSetVMProperties; props != nil {
+			if v := props.LatestModelApplied; v != nil {
+				instance["latest_model_applied"] = *v
+			}
+
+			if profile := props.OsProfile; profile != nil && profile.ComputerName != nil {
+				instance["computer_name"] = *profile.ComputerName
+			}
+
+			if instanceView := props.InstanceView; instanceView != nil && instanceView.Statuses != nil {
+				for _, status := range *instanceView.Statuses {
+					if status.Code == nil {
+						continue
+					}
+
+					if powerState := normalizeAzureRmVirtualMachinePowerState(*status.Code); powerState != "" {
+						instance["power_state"] = powerState
+						break
+					}
+				}
+			}
+		}
+
+		instances = append(instances, instance)
+
+		if err = results.Next(); err != nil {
+			return fmt.Errorf("Error going to next instance of Virtual Machine Scale Set %q (Resource Group %q): %+v", scaleSetName, resourceGroup, err)
+		}
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("instance", instances); err != nil {
+		return fmt.Errorf("Error setting `instance`: %+v", err)
+	}
+
+	return nil
+}
+
+// retrieveVirtualMachineScaleSetPrivateIPAddresses returns the private IP Addresses of the Network Interfaces
+// within the Virtual Machine Scale Set, keyed by the (lower-cased) ID of the instance they're attached to.
+func retrieveVirtualMachineScaleSetPrivateIPAddresses(meta interface{}, resourceGroup string, scaleSetName string) (map[string][]interface{}, error) {
+	client := meta.(*ArmClient).ifaceClient
+	ctx := meta.(*ArmClient).StopContext
+
+	results, err := client.ListVirtualMachineScaleSetNetworkInterfacesComplete(ctx, resourceGroup, scaleSetName)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing Network Interfaces of Virtual Machine Scale Set %q (Resource Group %q): %+v", scaleSetName, resourceGroup, err)
+	}
+
+	addresses := make(map[string][]interface{})
+	for results.NotDone() {
+		nic := results.Value()
+
+		if props := nic.InterfacePropertiesFormat; props != nil && props.VirtualMachine != nil && props.VirtualMachine.ID != nil {
+			instanceId := strings.ToLower(*props.VirtualMachine.ID)
+
+			if configs := props.IPConfigurations; configs != nil {
+				for _, config := range *configs {
+					configProps := config.InterfaceIPConfigurationPropertiesFormat
+					if configProps == nil || configProps.PrivateIPAddress == nil {
+						continue
+					}
+
+					// the primary IP Address of the primary Network Interface is listed first
+					if nic.Primary != nil && *nic.Primary && configProps.Primary != nil && *configProps.Primary {
+						addresses[instanceId] = append([]interface{}{*configProps.PrivateIPAddress}, addresses[instanceId]...)
+					} else {
+						addresses[instanceId] = append(addresses[instanceId], *configProps.PrivateIPAddress)
+					}
+				}
+			}
+		}
+
+		if err = results.Next(); err != nil {
+			return nil, fmt.Errorf("Error going to next Network Interface of Virtual Machine Scale Set %q (Resource Group %q): %+v", scaleSetName, resourceGroup, err)
+		}
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,45 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureRMVirtualMachineScaleSetInstances_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_virtual_machine_scale_set_instances.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAzureRMVirtualMachineScaleSetInstances_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "instance.#", "2"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "instance.0.instance_id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "instance.0.computer_name"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "instance.0.private_ip_address"),
+					resource.TestCheckResourceAttr(dataSourceName, "instance.0.latest_model_applied", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "instance.0.power_state", "running"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMVirtualMachineScaleSetInstances_basic(rInt int, location string) string {
+	template := testAccAzureRMVirtualMachineScaleSet_basicLinux_managedDisk(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_virtual_machine_scale_set_instances" "test" {
+  virtual_machine_scale_set_name = "${azurerm_virtual_machine_scale_set.test.name}"
+  resource_group_name            = "${azurerm_resource_group.test.name}"
+}
+`, template)
+}
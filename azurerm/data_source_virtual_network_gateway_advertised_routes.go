@@ -0,0 +1,64 @@
+package azurerm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+)
+
+func dataSourceArmVirtualNetworkGatewayAdvertisedRoutes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmVirtualNetworkGatewayAdvertisedRoutesRead,
+
+		Schema: map[string]*schema.Schema{
+			"virtual_network_gateway_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"peer": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.IPv4Address,
+			},
+
+			"route": virtualNetworkGatewayRoutesSchema(),
+		},
+	}
+}
+
+func dataSourceArmVirtualNetworkGatewayAdvertisedRoutesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vnetGatewayClient
+	ctx := meta.(*ArmClient).StopContext
+
+	gatewayName := d.Get("virtual_network_gateway_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	peer := d.Get("peer").(string)
+
+	future, err := client.GetAdvertisedRoutes(ctx, resourceGroup, gatewayName, peer)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Advertised Routes to Peer %q for Virtual Network Gateway %q (Resource Group %q): %+v", peer, gatewayName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for Advertised Routes to Peer %q for Virtual Network Gateway %q (Resource Group %q): %+v", peer, gatewayName, resourceGroup, err)
+	}
+
+	resp, err := future.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Advertised Routes result to Peer %q for Virtual Network Gateway %q (Resource Group %q): %+v", peer, gatewayName, resourceGroup, err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("route", flattenArmVirtualNetworkGatewayRoutes(resp.Value)); err != nil {
+		return fmt.Errorf("Error setting `route`: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureRMVirtualNetworkGatewayAdvertisedRoutes_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_virtual_network_gateway_advertised_routes.test"
+	ri := acctest.RandInt()
+	config := testAccDataSourceAzureRMVirtualNetworkGatewayAdvertisedRoutes_basic(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualNetworkGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "peer", "10.0.1.254"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "route.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMVirtualNetworkGatewayAdvertisedRoutes_basic(rInt int, location string) string {
+	config := testAccAzureRMVirtualNetworkGateway_enableBgp(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_virtual_network_gateway_advertised_routes" "test" {
+  virtual_network_gateway_name = "${azurerm_virtual_network_gateway.test.name}"
+  resource_group_name          = "${azurerm_virtual_network_gateway.test.resource_group_name}"
+  peer                         = "10.0.1.254"
+}
+`, config)
+}
@@ -0,0 +1,156 @@
+package azurerm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+)
+
+func dataSourceArmVirtualNetworkGatewayBgpPeerStatus() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmVirtualNetworkGatewayBgpPeerStatusRead,
+
+		Schema: map[string]*schema.Schema{
+			"virtual_network_gateway_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"peer": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.IPv4Address,
+			},
+
+			"bgp_peer": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"local_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"neighbor": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"asn": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"connected_duration": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"routes_received": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"messages_sent": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"messages_received": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmVirtualNetworkGatewayBgpPeerStatusRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vnetGatewayClient
+	ctx := meta.(*ArmClient).StopContext
+
+	gatewayName := d.Get("virtual_network_gateway_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	peer := d.Get("peer").(string)
+
+	future, err := client.GetBgpPeerStatus(ctx, resourceGroup, gatewayName, peer)
+	if err != nil {
+		return fmt.Errorf("Error retrieving BGP Peer Status for Virtual Network Gateway %q (Resource Group %q): %+v", gatewayName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for BGP Peer Status for Virtual Network Gateway %q (Resource Group %q): %+v", gatewayName, resourceGroup, err)
+	}
+
+	resp, err := future.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving BGP Peer Status result for Virtual Network Gateway %q (Resource Group %q): %+v", gatewayName, resourceGroup, err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("bgp_peer", flattenArmVirtualNetworkGatewayBgpPeerStatus(resp.Value)); err != nil {
+		return fmt.Errorf("Error setting `bgp_peer`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenArmVirtualNetworkGatewayBgpPeerStatus(input *[]network.BgpPeerStatus) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		result := map[string]interface{}{
+			"state": string(item.State),
+		}
+
+		if v := item.LocalAddress; v != nil {
+			result["local_address"] = *v
+		}
+
+		if v := item.Neighbor; v != nil {
+			result["neighbor"] = *v
+		}
+
+		if v := item.Asn; v != nil {
+			result["asn"] = int(*v)
+		}
+
+		if v := item.ConnectedDuration; v != nil {
+			result["connected_duration"] = *v
+		}
+
+		if v := item.RoutesReceived; v != nil {
+			result["routes_received"] = int(*v)
+		}
+
+		if v := item.MessagesSent; v != nil {
+			result["messages_sent"] = int(*v)
+		}
+
+		if v := item.MessagesReceived; v != nil {
+			result["messages_received"] = int(*v)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
@@ -0,0 +1,146 @@
+package azurerm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+)
+
+func dataSourceArmVirtualNetworkGatewayLearnedRoutes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmVirtualNetworkGatewayLearnedRoutesRead,
+
+		Schema: map[string]*schema.Schema{
+			"virtual_network_gateway_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"route": virtualNetworkGatewayRoutesSchema(),
+		},
+	}
+}
+
+func dataSourceArmVirtualNetworkGatewayLearnedRoutesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vnetGatewayClient
+	ctx := meta.(*ArmClient).StopContext
+
+	gatewayName := d.Get("virtual_network_gateway_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	future, err := client.GetLearnedRoutes(ctx, resourceGroup, gatewayName)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Learned Routes for Virtual Network Gateway %q (Resource Group %q): %+v", gatewayName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for Learned Routes for Virtual Network Gateway %q (Resource Group %q): %+v", gatewayName, resourceGroup, err)
+	}
+
+	resp, err := future.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Learned Routes result for Virtual Network Gateway %q (Resource Group %q): %+v", gatewayName, resourceGroup, err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	if err := d.Set("route", flattenArmVirtualNetworkGatewayRoutes(resp.Value)); err != nil {
+		return fmt.Errorf("Error setting `route`: %+v", err)
+	}
+
+	return nil
+}
+
+func virtualNetworkGatewayRoutesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"local_address": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"network": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"next_hop": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"source_peer": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"origin": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"as_path": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"weight": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func flattenArmVirtualNetworkGatewayRoutes(input *[]network.GatewayRoute) []interface{} {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range *input {
+		result := make(map[string]interface{})
+
+		if v := item.LocalAddress; v != nil {
+			result["local_address"] = *v
+		}
+
+		if v := item.NetworkProperty; v != nil {
+			result["network"] = *v
+		}
+
+		if v := item.NextHop; v != nil {
+			result["next_hop"] = *v
+		}
+
+		if v := item.SourcePeer; v != nil {
+			result["source_peer"] = *v
+		}
+
+		if v := item.Origin; v != nil {
+			result["origin"] = *v
+		}
+
+		if v := item.AsPath; v != nil {
+			result["as_path"] = *v
+		}
+
+		if v := item.Weight; v != nil {
+			result["weight"] = int(*v)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
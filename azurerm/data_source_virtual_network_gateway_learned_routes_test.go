@@ -0,0 +1,41 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureRMVirtualNetworkGatewayLearnedRoutes_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_virtual_network_gateway_learned_routes.test"
+	ri := acctest.RandInt()
+	config := testAccDataSourceAzureRMVirtualNetworkGatewayLearnedRoutes_basic(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualNetworkGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "route.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMVirtualNetworkGatewayLearnedRoutes_basic(rInt int, location string) string {
+	config := testAccAzureRMVirtualNetworkGateway_enableBgp(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_virtual_network_gateway_learned_routes" "test" {
+  virtual_network_gateway_name = "${azurerm_virtual_network_gateway.test.name}"
+  resource_group_name          = "${azurerm_virtual_network_gateway.test.resource_group_name}"
+}
+`, config)
+}
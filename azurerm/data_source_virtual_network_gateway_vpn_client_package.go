@@ -0,0 +1,129 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmVirtualNetworkGatewayVpnClientPackage() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmVirtualNetworkGatewayVpnClientPackageRead,
+
+		Schema: map[string]*schema.Schema{
+			"virtual_network_gateway_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameForDataSourceSchema(),
+
+			"processor_architecture": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(network.Amd64),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(network.Amd64),
+					string(network.X86),
+				}, false),
+			},
+
+			"authentication_method": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(network.EAPTLS),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(network.EAPTLS),
+					string(network.EAPMSCHAPv2),
+				}, false),
+			},
+
+			"radius_server_auth_certificate": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"client_root_certificates": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+			},
+
+			"url": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceArmVirtualNetworkGatewayVpnClientPackageRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vnetGatewayClient
+	ctx := meta.(*ArmClient).StopContext
+
+	gatewayName := d.Get("virtual_network_gateway_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	parameters := network.VpnClientParameters{
+		ProcessorArchitecture: network.ProcessorArchitecture(d.Get("processor_architecture").(string)),
+		AuthenticationMethod:  network.AuthenticationMethod(d.Get("authentication_method").(string)),
+	}
+
+	if v := d.Get("radius_server_auth_certificate").(string); v != "" {
+		parameters.RadiusServerAuthCertificate = utils.String(v)
+	}
+
+	if v := d.Get("client_root_certificates").([]interface{}); len(v) > 0 {
+		certificates := make([]string, 0)
+		for _, certificate := range v {
+			certificates = append(certificates, certificate.(string))
+		}
+		parameters.ClientRootCertificates = &certificates
+	}
+
+	future, err := client.Generatevpnclientpackage(ctx, resourceGroup, gatewayName, parameters)
+	if err != nil {
+		return fmt.Errorf("Error generating VPN Client Package for Virtual Network Gateway %q (Resource Group %q): %+v", gatewayName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for generation of VPN Client Package for Virtual Network Gateway %q (Resource Group %q): %+v", gatewayName, resourceGroup, err)
+	}
+
+	// the API returns the URL as a bare JSON string, which the SDK's `Result` method fails to unmarshal
+	// into the `String` model - so we parse the final response ourselves
+	resp, err := future.GetResult(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving VPN Client Package for Virtual Network Gateway %q (Resource Group %q): %+v", gatewayName, resourceGroup, err)
+	}
+
+	var url string
+	err = autorest.Respond(
+		resp,
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&url),
+		autorest.ByClosing())
+	if err != nil {
+		return fmt.Errorf("Error parsing VPN Client Package URL for Virtual Network Gateway %q (Resource Group %q): %+v", gatewayName, resourceGroup, err)
+	}
+
+	d.SetId(time.Now().UTC().String())
+
+	d.Set("url", url)
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureRMVirtualNetworkGatewayVpnClientPackage_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_virtual_network_gateway_vpn_client_package.test"
+	ri := acctest.RandInt()
+	config := testAccDataSourceAzureRMVirtualNetworkGatewayVpnClientPackage_basic(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualNetworkGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "processor_architecture", "Amd64"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "url"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMVirtualNetworkGatewayVpnClientPackage_basic(rInt int, location string) string {
+	config := testAccAzureRMVirtualNetworkGateway_vpnClientConfig(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_virtual_network_gateway_vpn_client_package" "test" {
+  virtual_network_gateway_name = "${azurerm_virtual_network_gateway.test.name}"
+  resource_group_name          = "${azurerm_virtual_network_gateway.test.resource_group_name}"
+  authentication_method        = "EAPMSCHAPv2"
+}
+`, config)
+}
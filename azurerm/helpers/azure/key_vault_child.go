@@ -17,6 +17,16 @@ type KeyVaultChildID struct {
 
 func ParseKeyVaultChildID(id string) (*KeyVaultChildID, error) {
 	// example: https://tharvey-keyvault.vault.azure.net/type/bird/fdf067c93bbb4b22bff4d8b7a9a56217
+	return parseKeyVaultChildID(id, false)
+}
+
+// ParseKeyVaultChildIDWithOptionalVersion parses the ID of a Key Vault Child which may omit the Version
+// (e.g. `https://tharvey-keyvault.vault.azure.net/keys/bird`) - in which case the Version is empty
+func ParseKeyVaultChildIDWithOptionalVersion(id string) (*KeyVaultChildID, error) {
+	return parseKeyVaultChildID(id, true)
+}
+
+func parseKeyVaultChildID(id string, versionOptional bool) (*KeyVaultChildID, error) {
 	idURL, err := url.ParseRequestURI(id)
 	if err != nil {
 		return nil, fmt.Errorf("Cannot parse Azure KeyVault Child Id: %s", err)
@@ -29,10 +39,21 @@ func ParseKeyVaultChildID(id string) (*KeyVaultChildID, error) {
 
 	components := strings.Split(path, "/")
 
+	if versionOptional && len(components) == 2 {
+		components = append(components, "")
+	}
+
 	if len(components) != 3 {
+		if versionOptional {
+			return nil, fmt.Errorf("Azure KeyVault Child Id should have 2 or 3 segments, got %d: '%s'", len(components), path)
+		}
 		return nil, fmt.Errorf("Azure KeyVault Child Id should have 3 segments, got %d: '%s'", len(components), path)
 	}
 
+	if components[1] == "" {
+		return nil, fmt.Errorf("Azure KeyVault Child Id should contain a name: '%s'", path)
+	}
+
 	childId := KeyVaultChildID{
 		KeyVaultBaseUrl: fmt.Sprintf("%s://%s/", idURL.Scheme, idURL.Host),
 		Name:            components[1],
@@ -72,3 +93,23 @@ func ValidateKeyVaultChildId(i interface{}, k string) (warnings []string, errors
 
 	return warnings, errors
 }
+
+// ValidateKeyVaultChildIdWithOptionalVersion validates the ID of a Key Vault Child where the Version is optional
+func ValidateKeyVaultChildIdWithOptionalVersion(i interface{}, k string) (warnings []string, errors []error) {
+	if warnings, errors = validate.NoEmptyStrings(i, k); len(errors) > 0 {
+		return warnings, errors
+	}
+
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("Expected %s to be a string!", k))
+		return warnings, errors
+	}
+
+	if _, err := ParseKeyVaultChildIDWithOptionalVersion(v); err != nil {
+		errors = append(errors, fmt.Errorf("Error parsing Key Vault Child ID: %s", err))
+		return warnings, errors
+	}
+
+	return warnings, errors
+}
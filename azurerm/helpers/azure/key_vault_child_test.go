@@ -183,3 +183,78 @@ func TestAccAzureRMKeyVaultChild_validateName(t *testing.T) {
 		}
 	}
 }
+
+func TestAccAzureRMKeyVaultChild_parseIDWithOptionalVersion(t *testing.T) {
+	cases := []struct {
+		Input       string
+		Expected    KeyVaultChildID
+		ExpectError bool
+	}{
+		{
+			Input:       "",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-keyvault.vault.azure.net/keys",
+			ExpectError: true,
+		},
+		{
+			Input:       "https://my-keyvault.vault.azure.net/keys/castle",
+			ExpectError: false,
+			Expected: KeyVaultChildID{
+				Name:            "castle",
+				KeyVaultBaseUrl: "https://my-keyvault.vault.azure.net/",
+				Version:         "",
+			},
+		},
+		{
+			Input:       "https://my-keyvault.vault.azure.net/keys/castle/",
+			ExpectError: false,
+			Expected: KeyVaultChildID{
+				Name:            "castle",
+				KeyVaultBaseUrl: "https://my-keyvault.vault.azure.net/",
+				Version:         "",
+			},
+		},
+		{
+			Input:       "https://my-keyvault.vault.azure.net/keys/castle/1492",
+			ExpectError: false,
+			Expected: KeyVaultChildID{
+				Name:            "castle",
+				KeyVaultBaseUrl: "https://my-keyvault.vault.azure.net/",
+				Version:         "1492",
+			},
+		},
+		{
+			Input:       "https://my-keyvault.vault.azure.net/keys/castle/1492/XXX",
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		keyId, err := ParseKeyVaultChildIDWithOptionalVersion(tc.Input)
+		if err != nil {
+			if !tc.ExpectError {
+				t.Fatalf("Got error for ID '%s': %+v", tc.Input, err)
+			}
+
+			continue
+		}
+
+		if tc.ExpectError {
+			t.Fatalf("Expected an error for ID '%s' but didn't get one", tc.Input)
+		}
+
+		if tc.Expected.KeyVaultBaseUrl != keyId.KeyVaultBaseUrl {
+			t.Fatalf("Expected 'KeyVaultBaseUrl' to be '%s', got '%s' for ID '%s'", tc.Expected.KeyVaultBaseUrl, keyId.KeyVaultBaseUrl, tc.Input)
+		}
+
+		if tc.Expected.Name != keyId.Name {
+			t.Fatalf("Expected 'Name' to be '%s', got '%s' for ID '%s'", tc.Expected.Name, keyId.Name, tc.Input)
+		}
+
+		if tc.Expected.Version != keyId.Version {
+			t.Fatalf("Expected 'Version' to be '%s', got '%s' for ID '%s'", tc.Expected.Version, keyId.Version, tc.Input)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// DefaultExecAPIVersion is the API Version used for exec-plugin credentials when none is specified
+	DefaultExecAPIVersion = "client.authentication.k8s.io/v1beta1"
+
+	azureAuthProviderName = "azure"
+)
+
+// KubeConfigEntry defines the credentials for a single cluster which should be rendered into a kubeconfig
+type KubeConfigEntry struct {
+	// RawConfig is the kubeconfig returned from the API for this cluster
+	RawConfig string
+
+	// ContextName optionally overrides the name of both the Context and the Cluster
+	ContextName string
+
+	// UserName optionally overrides the name of the User
+	UserName string
+
+	// Namespace optionally sets the default Namespace for the Context
+	Namespace string
+
+	// ExecPlugin optionally replaces an Azure Active Directory `auth-provider` with an exec-plugin
+	ExecPlugin *ExecPlugin
+}
+
+// ExecPlugin defines an exec-plugin (such as `kubelogin`) used to retrieve a token for the user
+type ExecPlugin struct {
+	APIVersion string
+	Command    string
+
+	// Args are passed to the Command - when empty `get-token` arguments are generated from the
+	// Azure Active Directory configuration of the user
+	Args []string
+	Env  map[string]string
+}
+
+type renderedUserItem struct {
+	Name string       `yaml:"name"`
+	User renderedUser `yaml:"user"`
+}
+
+type renderedUser struct {
+	ClientCertificateData string                `yaml:"client-certificate-data,omitempty"`
+	ClientKeyData         string                `yaml:"client-key-data,omitempty"`
+	Token                 string                `yaml:"token,omitempty"`
+	AuthProvider          *renderedAuthProvider `yaml:"auth-provider,omitempty"`
+	Exec                  *execConfig           `yaml:"exec,omitempty"`
+}
+
+// renderedAuthProvider retains all of the configuration for the auth-provider, rather than only the fields we parse
+type renderedAuthProvider struct {
+	Name   string            `yaml:"name"`
+	Config map[string]string `yaml:"config,omitempty"`
+}
+
+type execConfig struct {
+	APIVersion string           `yaml:"apiVersion"`
+	Command    string           `yaml:"command"`
+	Args       []string         `yaml:"args,omitempty"`
+	Env        []execEnvVarItem `yaml:"env,omitempty"`
+}
+
+type execEnvVarItem struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+type renderedKubeConfig struct {
+	KubeConfigBase `yaml:",inline"`
+	Users          []renderedUserItem `yaml:"users"`
+}
+
+// RenderKubeConfig merges the credentials for one or more clusters into a single kubeconfig, renaming the
+// contexts and users as requested. The current context is set to `currentContext` if specified, otherwise
+// it's set to the context of the first entry. The names of the contexts are returned in the order of the entries.
+func RenderKubeConfig(entries []KubeConfigEntry, currentContext string) (string, []string, error) {
+	if len(entries) == 0 {
+		return "", nil, fmt.Errorf("At least one cluster must be specified")
+	}
+
+	output := renderedKubeConfig{
+		KubeConfigBase: KubeConfigBase{
+			APIVersion: "v1",
+			Kind:       "Config",
+			Clusters:   make([]clusterItem, 0),
+			Contexts:   make([]contextItem, 0),
+		},
+		Users: make([]renderedUserItem, 0),
+	}
+
+	for i, entry := range entries {
+		var input renderedKubeConfig
+		if err := yaml.Unmarshal([]byte(entry.RawConfig), &input); err != nil {
+			return "", nil, fmt.Errorf("Failed to unmarshal YAML config for cluster %d with error %+v", i, err)
+		}
+		if len(input.Clusters) == 0 || len(input.Users) == 0 {
+			return "", nil, fmt.Errorf("Config for cluster %d contains no valid clusters or users", i)
+		}
+
+		clusterEntry := input.Clusters[0]
+		userEntry := input.Users[0]
+
+		contextName := clusterEntry.Name
+		if len(input.Contexts) > 0 {
+			contextName = input.Contexts[0].Name
+		}
+		if entry.ContextName != "" {
+			contextName = entry.ContextName
+			clusterEntry.Name = entry.ContextName
+		}
+		if entry.UserName != "" {
+			userEntry.Name = entry.UserName
+		}
+
+		if entry.ExecPlugin != nil && userEntry.User.AuthProvider != nil && userEntry.User.AuthProvider.Name == azureAuthProviderName {
+			userEntry.User.Exec = expandExecConfig(*entry.ExecPlugin, userEntry.User.AuthProvider.Config)
+			userEntry.User.AuthProvider = nil
+		}
+
+		output.Clusters = append(output.Clusters, clusterEntry)
+		output.Users = append(output.Users, userEntry)
+		output.Contexts = append(output.Contexts, contextItem{
+			Name: contextName,
+			Context: context{
+				Cluster:   clusterEntry.Name,
+				User:      userEntry.Name,
+				Namespace: entry.Namespace,
+			},
+		})
+	}
+
+	output.CurrentContext = output.Contexts[0].Name
+	if currentContext != "" {
+		output.CurrentContext = currentContext
+	}
+
+	bytes, err := yaml.Marshal(output)
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed to marshal YAML config with error %+v", err)
+	}
+
+	rendered := string(bytes)
+	if err := ValidateKubeConfig(rendered); err != nil {
+		return "", nil, err
+	}
+
+	contexts := make([]string, 0)
+	for _, v := range output.Contexts {
+		contexts = append(contexts, v.Name)
+	}
+
+	return rendered, contexts, nil
+}
+
+// ValidateKubeConfig ensures the kubeconfig is valid YAML, that the names of the clusters, contexts and users
+// are unique, that every context references a cluster and user which exist and that each user has credentials.
+func ValidateKubeConfig(config string) error {
+	if config == "" {
+		return fmt.Errorf("Cannot validate empty config")
+	}
+
+	var kubeConfig renderedKubeConfig
+	if err := yaml.Unmarshal([]byte(config), &kubeConfig); err != nil {
+		return fmt.Errorf("Failed to unmarshal YAML config with error %+v", err)
+	}
+	if len(kubeConfig.Clusters) == 0 || len(kubeConfig.Users) == 0 || len(kubeConfig.Contexts) == 0 {
+		return fmt.Errorf("Config contains no valid clusters, contexts or users")
+	}
+
+	clusters := make(map[string]bool)
+	for _, v := range kubeConfig.Clusters {
+		if clusters[v.Name] {
+			return fmt.Errorf("Config contains multiple clusters named %q", v.Name)
+		}
+		if v.Cluster.Server == "" {
+			return fmt.Errorf("Config has invalid or non existent server for cluster %q", v.Name)
+		}
+		clusters[v.Name] = true
+	}
+
+	users := make(map[string]bool)
+	for _, v := range kubeConfig.Users {
+		if users[v.Name] {
+			return fmt.Errorf("Config contains multiple users named %q", v.Name)
+		}
+
+		u := v.User
+		hasCertificate := u.ClientCertificateData != "" && u.ClientKeyData != ""
+		if u.Token == "" && !hasCertificate && u.AuthProvider == nil && u.Exec == nil {
+			return fmt.Errorf("Config requires either token, certificate, auth-provider or exec auth for user %q", v.Name)
+		}
+		if u.Exec != nil && u.Exec.Command == "" {
+			return fmt.Errorf("Config requires a command for the exec-plugin of user %q", v.Name)
+		}
+		users[v.Name] = true
+	}
+
+	contexts := make(map[string]bool)
+	for _, v := range kubeConfig.Contexts {
+		if contexts[v.Name] {
+			return fmt.Errorf("Config contains multiple contexts named %q", v.Name)
+		}
+		if !clusters[v.Context.Cluster] {
+			return fmt.Errorf("Context %q references cluster %q which doesn't exist", v.Name, v.Context.Cluster)
+		}
+		if !users[v.Context.User] {
+			return fmt.Errorf("Context %q references user %q which doesn't exist", v.Name, v.Context.User)
+		}
+		contexts[v.Name] = true
+	}
+
+	if kubeConfig.CurrentContext != "" && !contexts[kubeConfig.CurrentContext] {
+		return fmt.Errorf("Current context %q doesn't exist", kubeConfig.CurrentContext)
+	}
+
+	return nil
+}
+
+func expandExecConfig(plugin ExecPlugin, aad map[string]string) *execConfig {
+	apiVersion := plugin.APIVersion
+	if apiVersion == "" {
+		apiVersion = DefaultExecAPIVersion
+	}
+
+	args := plugin.Args
+	if len(args) == 0 {
+		args = []string{
+			"get-token",
+			"--server-id", aad["apiserver-id"],
+			"--client-id", aad["client-id"],
+			"--tenant-id", aad["tenant-id"],
+		}
+	}
+
+	env := make([]execEnvVarItem, 0)
+	for _, k := range sortedKeys(plugin.Env) {
+		env = append(env, execEnvVarItem{
+			Name:  k,
+			Value: plugin.Env[k],
+		})
+	}
+
+	return &execConfig{
+		APIVersion: apiVersion,
+		Command:    plugin.Command,
+		Args:       args,
+		Env:        env,
+	}
+}
+
+func sortedKeys(input map[string]string) []string {
+	keys := make([]string, 0, len(input))
+	for k := range input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
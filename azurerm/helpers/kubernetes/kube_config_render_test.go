@@ -0,0 +1,324 @@
+package kubernetes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestRenderKubeConfig(t *testing.T) {
+	testCases := []struct {
+		name           string
+		entries        []KubeConfigEntry
+		currentContext string
+		expectError    bool
+		checkFunc      func(t *testing.T, config renderedKubeConfig)
+	}{
+		{
+			name:        "no clusters",
+			entries:     []KubeConfigEntry{},
+			expectError: true,
+		},
+		{
+			name: "invalid config",
+			entries: []KubeConfigEntry{
+				{RawConfig: LoadConfig("no_user.yml")},
+			},
+			expectError: true,
+		},
+		{
+			name: "single cluster",
+			entries: []KubeConfigEntry{
+				{RawConfig: LoadConfig("aks_admin_cert.yml")},
+			},
+			checkFunc: func(t *testing.T, config renderedKubeConfig) {
+				expectContexts(t, config, []contextItem{
+					{
+						Name: "certcluster-admin",
+						Context: context{
+							Cluster: "certcluster",
+							User:    "clusterAdmin_certgroup_certcluster",
+						},
+					},
+				})
+				if config.CurrentContext != "certcluster-admin" {
+					t.Fatalf("expected the current context to be %q but got %q", "certcluster-admin", config.CurrentContext)
+				}
+				u := config.Users[0].User
+				if u.ClientCertificateData != "test-client-certificate-data" || u.ClientKeyData != "test-client-key-data" || u.Token != "test-token" {
+					t.Fatalf("expected the user credentials to be retained but got %+v", u)
+				}
+			},
+		},
+		{
+			name: "multiple clusters renamed",
+			entries: []KubeConfigEntry{
+				{
+					RawConfig:   LoadConfig("aks_admin_cert.yml"),
+					ContextName: "production",
+					UserName:    "production-admin",
+					Namespace:   "kube-system",
+				},
+				{
+					RawConfig:   LoadConfig("aks_user_aad.yml"),
+					ContextName: "staging",
+					UserName:    "staging-user",
+				},
+			},
+			currentContext: "staging",
+			checkFunc: func(t *testing.T, config renderedKubeConfig) {
+				expectContexts(t, config, []contextItem{
+					{
+						Name: "production",
+						Context: context{
+							Cluster:   "production",
+							User:      "production-admin",
+							Namespace: "kube-system",
+						},
+					},
+					{
+						Name: "staging",
+						Context: context{
+							Cluster: "staging",
+							User:    "staging-user",
+						},
+					},
+				})
+				if config.CurrentContext != "staging" {
+					t.Fatalf("expected the current context to be %q but got %q", "staging", config.CurrentContext)
+				}
+				if config.Clusters[1].Cluster.Server != "https://aadcluster-12345678.hcp.westeurope.azmk8s.io:443" {
+					t.Fatalf("expected the server of the second cluster to be retained but got %q", config.Clusters[1].Cluster.Server)
+				}
+				provider := config.Users[1].User.AuthProvider
+				if provider == nil || provider.Name != "azure" || provider.Config["environment"] != "AzurePublicCloud" {
+					t.Fatalf("expected the auth-provider to be retained but got %+v", provider)
+				}
+			},
+		},
+		{
+			name: "duplicate cluster names",
+			entries: []KubeConfigEntry{
+				{RawConfig: LoadConfig("aks_user_aad.yml")},
+				{RawConfig: LoadConfig("aks_user_aad.yml")},
+			},
+			expectError: true,
+		},
+		{
+			name: "duplicate cluster names renamed",
+			entries: []KubeConfigEntry{
+				{RawConfig: LoadConfig("aks_user_aad.yml")},
+				{
+					RawConfig:   LoadConfig("aks_user_aad.yml"),
+					ContextName: "aadcluster-2",
+					UserName:    "aadcluster-2-user",
+				},
+			},
+			checkFunc: func(t *testing.T, config renderedKubeConfig) {
+				if len(config.Clusters) != 2 || len(config.Users) != 2 || len(config.Contexts) != 2 {
+					t.Fatalf("expected 2 clusters, users and contexts but got %d, %d and %d", len(config.Clusters), len(config.Users), len(config.Contexts))
+				}
+			},
+		},
+		{
+			name: "missing current context",
+			entries: []KubeConfigEntry{
+				{RawConfig: LoadConfig("aks_admin_cert.yml")},
+			},
+			currentContext: "does-not-exist",
+			expectError:    true,
+		},
+		{
+			name: "exec plugin with generated arguments",
+			entries: []KubeConfigEntry{
+				{
+					RawConfig: LoadConfig("aks_user_aad.yml"),
+					ExecPlugin: &ExecPlugin{
+						Command: "kubelogin",
+						Env: map[string]string{
+							"B_VARIABLE": "b",
+							"A_VARIABLE": "a",
+						},
+					},
+				},
+			},
+			checkFunc: func(t *testing.T, config renderedKubeConfig) {
+				u := config.Users[0].User
+				if u.AuthProvider != nil {
+					t.Fatalf("expected the auth-provider to be replaced but got %+v", u.AuthProvider)
+				}
+				expected := &execConfig{
+					APIVersion: DefaultExecAPIVersion,
+					Command:    "kubelogin",
+					Args: []string{
+						"get-token",
+						"--server-id", "test-server-app-id",
+						"--client-id", "test-client-app-id",
+						"--tenant-id", "test-tenant-id",
+					},
+					Env: []execEnvVarItem{
+						{Name: "A_VARIABLE", Value: "a"},
+						{Name: "B_VARIABLE", Value: "b"},
+					},
+				}
+				if !reflect.DeepEqual(expected, u.Exec) {
+					t.Fatalf("expected the exec-plugin to be %+v but got %+v", expected, u.Exec)
+				}
+			},
+		},
+		{
+			name: "exec plugin with custom arguments",
+			entries: []KubeConfigEntry{
+				{
+					RawConfig: LoadConfig("aks_user_aad.yml"),
+					ExecPlugin: &ExecPlugin{
+						APIVersion: "client.authentication.k8s.io/v1alpha1",
+						Command:    "/usr/local/bin/get-token",
+						Args:       []string{"--cluster", "aadcluster"},
+					},
+				},
+			},
+			checkFunc: func(t *testing.T, config renderedKubeConfig) {
+				exec := config.Users[0].User.Exec
+				if exec == nil {
+					t.Fatalf("expected an exec-plugin but didn't get one")
+				}
+				if exec.APIVersion != "client.authentication.k8s.io/v1alpha1" || strings.Join(exec.Args, " ") != "--cluster aadcluster" {
+					t.Fatalf("expected the custom exec-plugin configuration to be used but got %+v", exec)
+				}
+			},
+		},
+		{
+			name: "exec plugin ignored for certificate credentials",
+			entries: []KubeConfigEntry{
+				{
+					RawConfig: LoadConfig("aks_admin_cert.yml"),
+					ExecPlugin: &ExecPlugin{
+						Command: "kubelogin",
+					},
+				},
+			},
+			checkFunc: func(t *testing.T, config renderedKubeConfig) {
+				if exec := config.Users[0].User.Exec; exec != nil {
+					t.Fatalf("expected no exec-plugin but got %+v", exec)
+				}
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		rendered, contexts, err := RenderKubeConfig(test.entries, test.currentContext)
+		if test.expectError {
+			if err == nil {
+				t.Fatalf("Test case %q: expected an error but didn't get one", test.name)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Test case %q: unexpected error: %+v", test.name, err)
+		}
+
+		if err := ValidateKubeConfig(rendered); err != nil {
+			t.Fatalf("Test case %q: rendered config wasn't valid: %+v", test.name, err)
+		}
+
+		var config renderedKubeConfig
+		if err := yaml.Unmarshal([]byte(rendered), &config); err != nil {
+			t.Fatalf("Test case %q: failed to unmarshal rendered config: %+v", test.name, err)
+		}
+
+		if config.APIVersion != "v1" || config.Kind != "Config" {
+			t.Fatalf("Test case %q: expected a v1 Config but got %q / %q", test.name, config.APIVersion, config.Kind)
+		}
+
+		if len(contexts) != len(config.Contexts) {
+			t.Fatalf("Test case %q: expected %d context names but got %d", test.name, len(config.Contexts), len(contexts))
+		}
+		for i, v := range config.Contexts {
+			if contexts[i] != v.Name {
+				t.Fatalf("Test case %q: expected context name %d to be %q but got %q", test.name, i, v.Name, contexts[i])
+			}
+		}
+
+		if test.checkFunc != nil {
+			test.checkFunc(t, config)
+		}
+	}
+}
+
+func TestValidateKubeConfig(t *testing.T) {
+	testCases := []struct {
+		sourceFile  string
+		expectError bool
+	}{
+		{
+			"user_with_cert.yml",
+			false,
+		},
+		{
+			"user_with_cert_token.yml",
+			false,
+		},
+		{
+			"aks_user_aad.yml",
+			false,
+		},
+		{
+			"aks_admin_cert.yml",
+			false,
+		},
+		{
+			// there are no contexts
+			"user_with_token.yml",
+			true,
+		},
+		{
+			"user_with_no_auth.yml",
+			true,
+		},
+		{
+			"user_with_partial_auth.yml",
+			true,
+		},
+		{
+			"no_cluster.yml",
+			true,
+		},
+		{
+			"no_user.yml",
+			true,
+		},
+		{
+			"cluster_with_no_server.yml",
+			true,
+		},
+		{
+			"context_with_unknown_user.yml",
+			true,
+		},
+	}
+
+	for _, test := range testCases {
+		config := LoadConfig(test.sourceFile)
+		if len(config) <= 0 {
+			t.Fatalf("Failed to read config from file %q", test.sourceFile)
+		}
+
+		err := ValidateKubeConfig(config)
+		if test.expectError && err == nil {
+			t.Fatalf("Expected config %q to be invalid but it wasn't", test.sourceFile)
+		}
+		if !test.expectError && err != nil {
+			t.Fatalf("Expected config %q to be valid but got: %+v", test.sourceFile, err)
+		}
+	}
+}
+
+func expectContexts(t *testing.T, config renderedKubeConfig, expected []contextItem) {
+	if !reflect.DeepEqual(expected, config.Contexts) {
+		t.Fatalf("expected the contexts to be %+v but got %+v", expected, config.Contexts)
+	}
+}
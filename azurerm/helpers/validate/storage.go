@@ -0,0 +1,24 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// StorageMetaDataKeys validates that the keys of a MetaData map are valid names for the Storage data plane -
+// which must be valid C# identifiers and lower-case, since they're returned lower-cased by the API
+func StorageMetaDataKeys(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(map[string]interface{})
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be map", k)}
+	}
+
+	var errors []error
+	for key := range v {
+		if !regexp.MustCompile(`^[a-z_][a-z0-9_]*$`).MatchString(key) {
+			errors = append(errors, fmt.Errorf("%q: the key %q must start with a lower-case letter or underscore and only contain lower-case letters, numbers and underscores", k, key))
+		}
+	}
+
+	return nil, errors
+}
@@ -0,0 +1,60 @@
+package validate
+
+import (
+	"testing"
+)
+
+func TestStorageMetaDataKeys(t *testing.T) {
+	cases := []struct {
+		Value    map[string]interface{}
+		TestName string
+		ErrCount int
+	}{
+		{
+			Value:    map[string]interface{}{},
+			TestName: "Empty",
+			ErrCount: 0,
+		},
+		{
+			Value: map[string]interface{}{
+				"hello":       "world",
+				"_private":    "value",
+				"with_digit1": "value",
+			},
+			TestName: "Valid",
+			ErrCount: 0,
+		},
+		{
+			Value: map[string]interface{}{
+				"Hello": "world",
+			},
+			TestName: "Upper-case",
+			ErrCount: 1,
+		},
+		{
+			Value: map[string]interface{}{
+				"1hello": "world",
+			},
+			TestName: "Leading Digit",
+			ErrCount: 1,
+		},
+		{
+			Value: map[string]interface{}{
+				"hello-world": "value",
+				"hello.world": "value",
+			},
+			TestName: "Invalid Characters",
+			ErrCount: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			_, errors := StorageMetaDataKeys(tc.Value, tc.TestName)
+
+			if len(errors) != tc.ErrCount {
+				t.Fatalf("Expected StorageMetaDataKeys to have %d errors for %v, got %d", tc.ErrCount, tc.Value, len(errors))
+			}
+		})
+	}
+}
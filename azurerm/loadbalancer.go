@@ -113,6 +113,20 @@ func findLoadBalancerNatPoolByName(lb *network.LoadBalancer, name string) (*netw
 	return nil, -1, false
 }
 
+func findLoadBalancerOutboundNatRuleByName(lb *network.LoadBalancer, name string) (*network.OutboundNatRule, int, bool) {
+	if lb == nil || lb.LoadBalancerPropertiesFormat == nil || lb.LoadBalancerPropertiesFormat.OutboundNatRules == nil {
+		return nil, -1, false
+	}
+
+	for i, or := range *lb.LoadBalancerPropertiesFormat.OutboundNatRules {
+		if or.Name != nil && *or.Name == name {
+			return &or, i, true
+		}
+	}
+
+	return nil, -1, false
+}
+
 func findLoadBalancerProbeByName(lb *network.LoadBalancer, name string) (*network.Probe, int, bool) {
 	if lb == nil || lb.LoadBalancerPropertiesFormat == nil || lb.LoadBalancerPropertiesFormat.Probes == nil {
 		return nil, -1, false
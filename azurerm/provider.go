@@ -86,61 +86,88 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_SKIP_PROVIDER_REGISTRATION", false),
 			},
+
+			"storage_use_azuread": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_STORAGE_USE_AZUREAD", false),
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"azurerm_azuread_application":                   dataSourceArmAzureADApplication(),
-			"azurerm_azuread_service_principal":             dataSourceArmActiveDirectoryServicePrincipal(),
-			"azurerm_api_management":                        dataSourceApiManagementService(),
-			"azurerm_application_security_group":            dataSourceArmApplicationSecurityGroup(),
-			"azurerm_app_service":                           dataSourceArmAppService(),
-			"azurerm_app_service_plan":                      dataSourceAppServicePlan(),
-			"azurerm_builtin_role_definition":               dataSourceArmBuiltInRoleDefinition(),
-			"azurerm_cdn_profile":                           dataSourceArmCdnProfile(),
-			"azurerm_client_config":                         dataSourceArmClientConfig(),
-			"azurerm_cosmosdb_account":                      dataSourceArmCosmosDBAccount(),
-			"azurerm_container_registry":                    dataSourceArmContainerRegistry(),
-			"azurerm_data_lake_store":                       dataSourceArmDataLakeStoreAccount(),
-			"azurerm_dev_test_lab":                          dataSourceArmDevTestLab(),
-			"azurerm_dns_zone":                              dataSourceArmDnsZone(),
-			"azurerm_eventhub_namespace":                    dataSourceEventHubNamespace(),
-			"azurerm_image":                                 dataSourceArmImage(),
-			"azurerm_key_vault":                             dataSourceArmKeyVault(),
-			"azurerm_key_vault_key":                         dataSourceArmKeyVaultKey(),
-			"azurerm_key_vault_access_policy":               dataSourceArmKeyVaultAccessPolicy(),
-			"azurerm_key_vault_secret":                      dataSourceArmKeyVaultSecret(),
-			"azurerm_kubernetes_cluster":                    dataSourceArmKubernetesCluster(),
-			"azurerm_log_analytics_workspace":               dataSourceLogAnalyticsWorkspace(),
-			"azurerm_logic_app_workflow":                    dataSourceArmLogicAppWorkflow(),
-			"azurerm_managed_disk":                          dataSourceArmManagedDisk(),
-			"azurerm_management_group":                      dataSourceArmManagementGroup(),
-			"azurerm_monitor_action_group":                  dataSourceArmMonitorActionGroup(),
-			"azurerm_monitor_diagnostic_categories":         dataSourceArmMonitorDiagnosticCategories(),
-			"azurerm_monitor_log_profile":                   dataSourceArmMonitorLogProfile(),
-			"azurerm_network_interface":                     dataSourceArmNetworkInterface(),
-			"azurerm_network_security_group":                dataSourceArmNetworkSecurityGroup(),
-			"azurerm_notification_hub":                      dataSourceNotificationHub(),
-			"azurerm_notification_hub_namespace":            dataSourceNotificationHubNamespace(),
-			"azurerm_platform_image":                        dataSourceArmPlatformImage(),
-			"azurerm_public_ip":                             dataSourceArmPublicIP(),
-			"azurerm_public_ips":                            dataSourceArmPublicIPs(),
-			"azurerm_recovery_services_vault":               dataSourceArmRecoveryServicesVault(),
-			"azurerm_resource_group":                        dataSourceArmResourceGroup(),
-			"azurerm_role_definition":                       dataSourceArmRoleDefinition(),
-			"azurerm_route_table":                           dataSourceArmRouteTable(),
-			"azurerm_scheduler_job_collection":              dataSourceArmSchedulerJobCollection(),
-			"azurerm_shared_image":                          dataSourceArmSharedImage(),
-			"azurerm_shared_image_gallery":                  dataSourceArmSharedImageGallery(),
-			"azurerm_shared_image_version":                  dataSourceArmSharedImageVersion(),
-			"azurerm_snapshot":                              dataSourceArmSnapshot(),
-			"azurerm_storage_account":                       dataSourceArmStorageAccount(),
-			"azurerm_storage_account_sas":                   dataSourceArmStorageAccountSharedAccessSignature(),
-			"azurerm_subnet":                                dataSourceArmSubnet(),
-			"azurerm_subscription":                          dataSourceArmSubscription(),
-			"azurerm_subscriptions":                         dataSourceArmSubscriptions(),
-			"azurerm_traffic_manager_geographical_location": dataSourceArmTrafficManagerGeographicalLocation(),
-			"azurerm_virtual_network":                       dataSourceArmVirtualNetwork(),
-			"azurerm_virtual_network_gateway":               dataSourceArmVirtualNetworkGateway(),
+			"azurerm_azuread_application":                        dataSourceArmAzureADApplication(),
+			"azurerm_azuread_service_principal":                  dataSourceArmActiveDirectoryServicePrincipal(),
+			"azurerm_api_management":                             dataSourceApiManagementService(),
+			"azurerm_application_security_group":                 dataSourceArmApplicationSecurityGroup(),
+			"azurerm_app_service":                                dataSourceArmAppService(),
+			"azurerm_app_service_plan":                           dataSourceAppServicePlan(),
+			"azurerm_builtin_role_definition":                    dataSourceArmBuiltInRoleDefinition(),
+			"azurerm_cdn_profile":                                dataSourceArmCdnProfile(),
+			"azurerm_client_config":                              dataSourceArmClientConfig(),
+			"azurerm_cosmosdb_account":                           dataSourceArmCosmosDBAccount(),
+			"azurerm_container_registry":                         dataSourceArmContainerRegistry(),
+			"azurerm_container_registry_replications":            dataSourceArmContainerRegistryReplications(),
+			"azurerm_container_registry_webhook_events":          dataSourceArmContainerRegistryWebhookEvents(),
+			"azurerm_data_lake_store":                            dataSourceArmDataLakeStoreAccount(),
+			"azurerm_dev_test_lab":                               dataSourceArmDevTestLab(),
+			"azurerm_dns_zone":                                   dataSourceArmDnsZone(),
+			"azurerm_eventhub_namespace":                         dataSourceEventHubNamespace(),
+			"azurerm_express_route_circuit_arp_table":            dataSourceArmExpressRouteCircuitArpTable(),
+			"azurerm_express_route_circuit_route_table":          dataSourceArmExpressRouteCircuitRouteTable(),
+			"azurerm_image":                                      dataSourceArmImage(),
+			"azurerm_key_vault":                                  dataSourceArmKeyVault(),
+			"azurerm_key_vault_key":                              dataSourceArmKeyVaultKey(),
+			"azurerm_key_vault_access_policy":                    dataSourceArmKeyVaultAccessPolicy(),
+			"azurerm_key_vault_secret":                           dataSourceArmKeyVaultSecret(),
+			"azurerm_kubernetes_cluster":                         dataSourceArmKubernetesCluster(),
+			"azurerm_kubernetes_cluster_credentials":             dataSourceArmKubernetesClusterCredentials(),
+			"azurerm_kubernetes_cluster_upgrade_versions":        dataSourceArmKubernetesClusterUpgradeVersions(),
+			"azurerm_lb_backend_address_pool":                    dataSourceArmLoadBalancerBackendAddressPool(),
+			"azurerm_log_analytics_workspace":                    dataSourceLogAnalyticsWorkspace(),
+			"azurerm_logic_app_workflow":                         dataSourceArmLogicAppWorkflow(),
+			"azurerm_managed_disk":                               dataSourceArmManagedDisk(),
+			"azurerm_management_group":                           dataSourceArmManagementGroup(),
+			"azurerm_monitor_action_group":                       dataSourceArmMonitorActionGroup(),
+			"azurerm_monitor_diagnostic_categories":              dataSourceArmMonitorDiagnosticCategories(),
+			"azurerm_monitor_log_profile":                        dataSourceArmMonitorLogProfile(),
+			"azurerm_network_interface":                          dataSourceArmNetworkInterface(),
+			"azurerm_network_effective_security_rules":           dataSourceArmNetworkEffectiveSecurityRules(),
+			"azurerm_network_security_group":                     dataSourceArmNetworkSecurityGroup(),
+			"azurerm_network_topology":                           dataSourceArmNetworkTopology(),
+			"azurerm_network_watcher_connectivity":               dataSourceArmNetworkWatcherConnectivity(),
+			"azurerm_network_watcher_ip_flow_verify":             dataSourceArmNetworkWatcherIPFlowVerify(),
+			"azurerm_network_watcher_next_hop":                   dataSourceArmNetworkWatcherNextHop(),
+			"azurerm_notification_hub":                           dataSourceNotificationHub(),
+			"azurerm_notification_hub_namespace":                 dataSourceNotificationHubNamespace(),
+			"azurerm_platform_image":                             dataSourceArmPlatformImage(),
+			"azurerm_public_ip":                                  dataSourceArmPublicIP(),
+			"azurerm_public_ips":                                 dataSourceArmPublicIPs(),
+			"azurerm_recovery_services_vault":                    dataSourceArmRecoveryServicesVault(),
+			"azurerm_resource_group":                             dataSourceArmResourceGroup(),
+			"azurerm_role_definition":                            dataSourceArmRoleDefinition(),
+			"azurerm_route_table":                                dataSourceArmRouteTable(),
+			"azurerm_scheduler_job_collection":                   dataSourceArmSchedulerJobCollection(),
+			"azurerm_shared_image":                               dataSourceArmSharedImage(),
+			"azurerm_shared_image_gallery":                       dataSourceArmSharedImageGallery(),
+			"azurerm_shared_image_version":                       dataSourceArmSharedImageVersion(),
+			"azurerm_snapshot":                                   dataSourceArmSnapshot(),
+			"azurerm_storage_account":                            dataSourceArmStorageAccount(),
+			"azurerm_storage_account_sas":                        dataSourceArmStorageAccountSharedAccessSignature(),
+			"azurerm_storage_blob_sas":                           dataSourceArmStorageBlobSharedAccessSignature(),
+			"azurerm_storage_container_sas":                      dataSourceArmStorageContainerSharedAccessSignature(),
+			"azurerm_storage_share_sas":                          dataSourceArmStorageShareSharedAccessSignature(),
+			"azurerm_subnet":                                     dataSourceArmSubnet(),
+			"azurerm_subscription":                               dataSourceArmSubscription(),
+			"azurerm_subscriptions":                              dataSourceArmSubscriptions(),
+			"azurerm_traffic_manager_geographical_location":      dataSourceArmTrafficManagerGeographicalLocation(),
+			"azurerm_virtual_machine_run_commands":               dataSourceArmVirtualMachineRunCommands(),
+			"azurerm_virtual_machine_scale_set_instances":        dataSourceArmVirtualMachineScaleSetInstances(),
+			"azurerm_virtual_network":                            dataSourceArmVirtualNetwork(),
+			"azurerm_virtual_network_gateway":                    dataSourceArmVirtualNetworkGateway(),
+			"azurerm_virtual_network_gateway_advertised_routes":  dataSourceArmVirtualNetworkGatewayAdvertisedRoutes(),
+			"azurerm_virtual_network_gateway_bgp_peer_status":    dataSourceArmVirtualNetworkGatewayBgpPeerStatus(),
+			"azurerm_virtual_network_gateway_learned_routes":     dataSourceArmVirtualNetworkGatewayLearnedRoutes(),
+			"azurerm_virtual_network_gateway_vpn_client_package": dataSourceArmVirtualNetworkGatewayVpnClientPackage(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -169,8 +196,10 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_cdn_profile":                            resourceArmCdnProfile(),
 			"azurerm_cognitive_account":                      resourceArmCognitiveAccount(),
 			"azurerm_container_registry":                     resourceArmContainerRegistry(),
+			"azurerm_container_registry_webhook":             resourceArmContainerRegistryWebhook(),
 			"azurerm_container_service":                      resourceArmContainerService(),
 			"azurerm_container_group":                        resourceArmContainerGroup(),
+			"azurerm_connection_monitor":                     resourceArmConnectionMonitor(),
 			"azurerm_cosmosdb_account":                       resourceArmCosmosDBAccount(),
 			"azurerm_databricks_workspace":                   resourceArmDatabricksWorkspace(),
 			"azurerm_data_lake_analytics_account":            resourceArmDataLakeAnalyticsAccount(),
@@ -202,7 +231,10 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_eventhub_namespace_authorization_rule":  resourceArmEventHubNamespaceAuthorizationRule(),
 			"azurerm_express_route_circuit":                  resourceArmExpressRouteCircuit(),
 			"azurerm_express_route_circuit_authorization":    resourceArmExpressRouteCircuitAuthorization(),
+			"azurerm_express_route_circuit_connection":       resourceArmExpressRouteCircuitConnection(),
 			"azurerm_express_route_circuit_peering":          resourceArmExpressRouteCircuitPeering(),
+			"azurerm_express_route_cross_connection":         resourceArmExpressRouteCrossConnection(),
+			"azurerm_express_route_cross_connection_peering": resourceArmExpressRouteCrossConnectionPeering(),
 			"azurerm_firewall":                               resourceArmFirewall(),
 			"azurerm_firewall_network_rule_collection":       resourceArmFirewallNetworkRuleCollection(),
 			"azurerm_function_app":                           resourceArmFunctionApp(),
@@ -219,6 +251,7 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_lb_backend_address_pool":                resourceArmLoadBalancerBackendAddressPool(),
 			"azurerm_lb_nat_rule":                            resourceArmLoadBalancerNatRule(),
 			"azurerm_lb_nat_pool":                            resourceArmLoadBalancerNatPool(),
+			"azurerm_lb_outbound_rule":                       resourceArmLoadBalancerOutboundRule(),
 			"azurerm_lb_probe":                               resourceArmLoadBalancerProbe(),
 			"azurerm_lb_rule":                                resourceArmLoadBalancerRule(),
 			"azurerm_local_network_gateway":                  resourceArmLocalNetworkGateway(),
@@ -234,6 +267,7 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_mariadb_database":                       resourceArmMariaDbDatabase(),
 			"azurerm_mariadb_server":                         resourceArmMariaDbServer(),
 			"azurerm_managed_disk":                           resourceArmManagedDisk(),
+			"azurerm_managed_disk_sas_token":                 resourceArmManagedDiskSasToken(),
 			"azurerm_management_lock":                        resourceArmManagementLock(),
 			"azurerm_management_group":                       resourceArmManagementGroup(),
 			"azurerm_metric_alertrule":                       resourceArmMetricAlertRule(),
@@ -254,6 +288,7 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_network_security_group":                                                 resourceArmNetworkSecurityGroup(),
 			"azurerm_network_security_rule":                                                  resourceArmNetworkSecurityRule(),
 			"azurerm_network_watcher":                                                        resourceArmNetworkWatcher(),
+			"azurerm_network_watcher_flow_log":                                               resourceArmNetworkWatcherFlowLog(),
 			"azurerm_notification_hub":                                                       resourceArmNotificationHub(),
 			"azurerm_notification_hub_authorization_rule":                                    resourceArmNotificationHubAuthorizationRule(),
 			"azurerm_notification_hub_namespace":                                             resourceArmNotificationHubNamespace(),
@@ -305,11 +340,18 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_sql_server":                                                             resourceArmSqlServer(),
 			"azurerm_sql_virtual_network_rule":                                               resourceArmSqlVirtualNetworkRule(),
 			"azurerm_storage_account":                                                        resourceArmStorageAccount(),
+			"azurerm_storage_account_customer_managed_key":                                   resourceArmStorageAccountCustomerManagedKey(),
+			"azurerm_storage_account_key_rotation":                                           resourceArmStorageAccountKeyRotation(),
 			"azurerm_storage_blob":                                                           resourceArmStorageBlob(),
+			"azurerm_storage_blob_directory":                                                 resourceArmStorageBlobDirectory(),
 			"azurerm_storage_container":                                                      resourceArmStorageContainer(),
 			"azurerm_storage_share":                                                          resourceArmStorageShare(),
+			"azurerm_storage_share_directory":                                                resourceArmStorageShareDirectory(),
+			"azurerm_storage_share_file":                                                     resourceArmStorageShareFile(),
 			"azurerm_storage_queue":                                                          resourceArmStorageQueue(),
 			"azurerm_storage_table":                                                          resourceArmStorageTable(),
+			"azurerm_storage_table_entities":                                                 resourceArmStorageTableEntities(),
+			"azurerm_storage_table_entity":                                                   resourceArmStorageTableEntity(),
 			"azurerm_subnet":                                                                 resourceArmSubnet(),
 			"azurerm_subnet_network_security_group_association":                              resourceArmSubnetNetworkSecurityGroupAssociation(),
 			"azurerm_subnet_route_table_association":                                         resourceArmSubnetRouteTableAssociation(),
@@ -318,9 +360,12 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_traffic_manager_profile":                                                resourceArmTrafficManagerProfile(),
 			"azurerm_user_assigned_identity":                                                 resourceArmUserAssignedIdentity(),
 			"azurerm_virtual_machine":                                                        resourceArmVirtualMachine(),
+			"azurerm_virtual_machine_capture":                                                resourceArmVirtualMachineCapture(),
 			"azurerm_virtual_machine_data_disk_attachment":                                   resourceArmVirtualMachineDataDiskAttachment(),
 			"azurerm_virtual_machine_extension":                                              resourceArmVirtualMachineExtensions(),
+			"azurerm_virtual_machine_run_command":                                            resourceArmVirtualMachineRunCommand(),
 			"azurerm_virtual_machine_scale_set":                                              resourceArmVirtualMachineScaleSet(),
+			"azurerm_virtual_machine_scale_set_extension":                                    resourceArmVirtualMachineScaleSetExtension(),
 			"azurerm_virtual_network":                                                        resourceArmVirtualNetwork(),
 			"azurerm_virtual_network_gateway":                                                resourceArmVirtualNetworkGateway(),
 			"azurerm_virtual_network_gateway_connection":                                     resourceArmVirtualNetworkGatewayConnection(),
@@ -364,6 +409,7 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 		}
 
 		client.StopContext = p.StopContext()
+		client.storageUseAzureAD = d.Get("storage_use_azuread").(bool)
 
 		// replaces the context between tests
 		p.MetaReset = func() error {
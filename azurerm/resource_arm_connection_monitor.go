@@ -0,0 +1,351 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmConnectionMonitor() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmConnectionMonitorCreateUpdate,
+		Read:   resourceArmConnectionMonitorRead,
+		Update: resourceArmConnectionMonitorCreateUpdate,
+		Delete: resourceArmConnectionMonitorDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"network_watcher_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"location": locationSchema(),
+
+			"auto_start": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+
+			"interval_in_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      60,
+				ValidateFunc: validation.IntAtLeast(30),
+			},
+
+			"source": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"virtual_machine_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      0,
+							ValidateFunc: validate.PortNumberOrZero,
+						},
+					},
+				},
+			},
+
+			"destination": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"virtual_machine_id": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							ForceNew:      true,
+							ValidateFunc:  azure.ValidateResourceID,
+							ConflictsWith: []string{"destination.0.address"},
+						},
+
+						"address": {
+							Type:          schema.TypeString,
+							Optional:      true,
+							ForceNew:      true,
+							ValidateFunc:  validation.NoZeroValues,
+							ConflictsWith: []string{"destination.0.virtual_machine_id"},
+						},
+
+						"port": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validate.PortNumber,
+						},
+					},
+				},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceArmConnectionMonitorCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).connectionMonitorsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	watcherName := d.Get("network_watcher_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	location := azureRMNormalizeLocation(d.Get("location").(string))
+	autoStart := d.Get("auto_start").(bool)
+	intervalInSeconds := d.Get("interval_in_seconds").(int)
+	tags := d.Get("tags").(map[string]interface{})
+
+	source, err := expandArmConnectionMonitorSource(d)
+	if err != nil {
+		return err
+	}
+
+	destination, err := expandArmConnectionMonitorDestination(d)
+	if err != nil {
+		return err
+	}
+
+	properties := network.ConnectionMonitor{
+		Location: utils.String(location),
+		Tags:     expandTags(tags),
+		ConnectionMonitorParameters: &network.ConnectionMonitorParameters{
+			Source:                      source,
+			Destination:                 destination,
+			AutoStart:                   utils.Bool(autoStart),
+			MonitoringIntervalInSeconds: utils.Int32(int32(intervalInSeconds)),
+		},
+	}
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, watcherName, name, properties)
+	if err != nil {
+		return fmt.Errorf("Error creating/updating Connection Monitor %q (Watcher %q / Resource Group %q): %+v", name, watcherName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for completion of Connection Monitor %q (Watcher %q / Resource Group %q): %+v", name, watcherName, resourceGroup, err)
+	}
+
+	resp, err := client.Get(ctx, resourceGroup, watcherName, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Connection Monitor %q (Watcher %q / Resource Group %q): %+v", name, watcherName, resourceGroup, err)
+	}
+
+	if resp.ID == nil {
+		return fmt.Errorf("Cannot read Connection Monitor %q (Watcher %q / Resource Group %q) ID", name, watcherName, resourceGroup)
+	}
+
+	d.SetId(*resp.ID)
+
+	return resourceArmConnectionMonitorRead(d, meta)
+}
+
+func resourceArmConnectionMonitorRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).connectionMonitorsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := id.ResourceGroup
+	watcherName := id.Path["networkWatchers"]
+	name := id.Path["connectionMonitors"]
+
+	resp, err := client.Get(ctx, resourceGroup, watcherName, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Connection Monitor %q (Watcher %q / Resource Group %q) was not found - removing from state", name, watcherName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error reading Connection Monitor %q (Watcher %q / Resource Group %q): %+v", name, watcherName, resourceGroup, err)
+	}
+
+	d.Set("name", name)
+	d.Set("network_watcher_name", watcherName)
+	d.Set("resource_group_name", resourceGroup)
+	if location := resp.Location; location != nil {
+		d.Set("location", azureRMNormalizeLocation(*location))
+	}
+
+	if props := resp.ConnectionMonitorResultProperties; props != nil {
+		d.Set("auto_start", props.AutoStart)
+		if interval := props.MonitoringIntervalInSeconds; interval != nil {
+			d.Set("interval_in_seconds", int(*interval))
+		}
+
+		source := flattenArmConnectionMonitorSource(props.Source)
+		if err := d.Set("source", source); err != nil {
+			return fmt.Errorf("Error setting `source`: %+v", err)
+		}
+
+		destination := flattenArmConnectionMonitorDestination(props.Destination)
+		if err := d.Set("destination", destination); err != nil {
+			return fmt.Errorf("Error setting `destination`: %+v", err)
+		}
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmConnectionMonitorDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).connectionMonitorsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := id.ResourceGroup
+	watcherName := id.Path["networkWatchers"]
+	name := id.Path["connectionMonitors"]
+
+	future, err := client.Delete(ctx, resourceGroup, watcherName, name)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting Connection Monitor %q (Watcher %q / Resource Group %q): %+v", name, watcherName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+
+		return fmt.Errorf("Error waiting for the deletion of Connection Monitor %q (Watcher %q / Resource Group %q): %+v", name, watcherName, resourceGroup, err)
+	}
+
+	return nil
+}
+
+func expandArmConnectionMonitorSource(d *schema.ResourceData) (*network.ConnectionMonitorSource, error) {
+	sources := d.Get("source").([]interface{})
+	if len(sources) == 0 || sources[0] == nil {
+		return nil, fmt.Errorf("Error expanding `source`: not found")
+	}
+
+	source := sources[0].(map[string]interface{})
+
+	monitorSource := network.ConnectionMonitorSource{
+		ResourceID: utils.String(source["virtual_machine_id"].(string)),
+	}
+
+	if v := source["port"].(int); v != 0 {
+		monitorSource.Port = utils.Int32(int32(v))
+	}
+
+	return &monitorSource, nil
+}
+
+func flattenArmConnectionMonitorSource(input *network.ConnectionMonitorSource) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	output := make(map[string]interface{})
+
+	if resourceId := input.ResourceID; resourceId != nil {
+		output["virtual_machine_id"] = *resourceId
+	}
+
+	if port := input.Port; port != nil {
+		output["port"] = int(*port)
+	}
+
+	return []interface{}{output}
+}
+
+func expandArmConnectionMonitorDestination(d *schema.ResourceData) (*network.ConnectionMonitorDestination, error) {
+	destinations := d.Get("destination").([]interface{})
+	if len(destinations) == 0 || destinations[0] == nil {
+		return nil, fmt.Errorf("Error expanding `destination`: not found")
+	}
+
+	destination := destinations[0].(map[string]interface{})
+
+	monitorDestination := network.ConnectionMonitorDestination{
+		Port: utils.Int32(int32(destination["port"].(int))),
+	}
+
+	virtualMachineId := destination["virtual_machine_id"].(string)
+	address := destination["address"].(string)
+
+	if virtualMachineId == "" && address == "" {
+		return nil, fmt.Errorf("Error: either `destination.0.virtual_machine_id` or `destination.0.address` must be specified")
+	}
+
+	if virtualMachineId != "" {
+		monitorDestination.ResourceID = utils.String(virtualMachineId)
+	}
+
+	if address != "" {
+		monitorDestination.Address = utils.String(address)
+	}
+
+	return &monitorDestination, nil
+}
+
+func flattenArmConnectionMonitorDestination(input *network.ConnectionMonitorDestination) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	output := make(map[string]interface{})
+
+	// the API returns the IP Address of the Virtual Machine as the Address when a Resource ID is specified
+	if resourceId := input.ResourceID; resourceId != nil {
+		output["virtual_machine_id"] = *resourceId
+	} else if address := input.Address; address != nil {
+		output["address"] = *address
+	}
+
+	if port := input.Port; port != nil {
+		output["port"] = int(*port)
+	}
+
+	return []interface{}{output}
+}
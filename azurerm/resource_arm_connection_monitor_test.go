@@ -0,0 +1,415 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func testAccAzureRMConnectionMonitor_addressBasic(t *testing.T) {
+	resourceName := "azurerm_connection_monitor.test"
+
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMConnectionMonitorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAzureRMConnectionMonitor_addressBasicConfig(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMConnectionMonitorExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "resource_group_name"),
+					resource.TestCheckResourceAttr(resourceName, "location", azureRMNormalizeLocation(location)),
+					resource.TestCheckResourceAttr(resourceName, "auto_start", "true"),
+					resource.TestCheckResourceAttr(resourceName, "interval_in_seconds", "60"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAzureRMConnectionMonitor_addressComplete(t *testing.T) {
+	resourceName := "azurerm_connection_monitor.test"
+
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMConnectionMonitorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAzureRMConnectionMonitor_addressCompleteConfig(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMConnectionMonitorExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "auto_start", "false"),
+					resource.TestCheckResourceAttr(resourceName, "interval_in_seconds", "30"),
+					resource.TestCheckResourceAttr(resourceName, "source.0.port", "20020"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.env", "test"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAzureRMConnectionMonitor_virtualMachineBasic(t *testing.T) {
+	resourceName := "azurerm_connection_monitor.test"
+
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMConnectionMonitorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAzureRMConnectionMonitor_virtualMachineBasicConfig(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMConnectionMonitorExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "destination.0.virtual_machine_id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAzureRMConnectionMonitor_destinationConflict(t *testing.T) {
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMConnectionMonitorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAzureRMConnectionMonitor_destinationConflictConfig(ri, location),
+				ExpectError: regexp.MustCompile("conflicts with destination.0.address"),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMConnectionMonitorExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("not found: %s", name)
+		}
+
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+		watcherName := rs.Primary.Attributes["network_watcher_name"]
+		connectionMonitorName := rs.Primary.Attributes["name"]
+
+		client := testAccProvider.Meta().(*ArmClient).connectionMonitorsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, watcherName, connectionMonitorName)
+		if err != nil {
+			return fmt.Errorf("Bad: Get on connectionMonitorsClient: %s", err)
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Connection Monitor does not exist: %s", name)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMConnectionMonitorDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).connectionMonitorsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_connection_monitor" {
+			continue
+		}
+
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+		watcherName := rs.Primary.Attributes["network_watcher_name"]
+		connectionMonitorName := rs.Primary.Attributes["name"]
+
+		resp, err := client.Get(ctx, resourceGroup, watcherName, connectionMonitorName)
+
+		if err != nil {
+			return nil
+		}
+
+		if resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("Connection Monitor still exists:%s", *resp.Name)
+		}
+	}
+
+	return nil
+}
+
+func testAzureRMConnectionMonitor_baseConfig(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_network_watcher" "test" {
+  name                = "acctnw-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctvn-%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "internal"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  virtual_network_name = "${azurerm_virtual_network.test.name}"
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurerm_network_interface" "src" {
+  name                = "acctni-src%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  ip_configuration {
+    name                          = "testconfiguration1"
+    subnet_id                     = "${azurerm_subnet.test.id}"
+    private_ip_address_allocation = "dynamic"
+  }
+}
+
+resource "azurerm_virtual_machine" "src" {
+  name                  = "acctvm-src%d"
+  location              = "${azurerm_resource_group.test.location}"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  network_interface_ids = ["${azurerm_network_interface.src.id}"]
+  vm_size               = "Standard_D1_v2"
+
+  storage_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  storage_os_disk {
+    name              = "osdisk-src%d"
+    caching           = "ReadWrite"
+    create_option     = "FromImage"
+    managed_disk_type = "Standard_LRS"
+  }
+
+  os_profile {
+    computer_name  = "hostname%d"
+    admin_username = "testadmin"
+    admin_password = "Password1234!"
+  }
+
+  os_profile_linux_config {
+    disable_password_authentication = false
+  }
+}
+
+resource "azurerm_virtual_machine_extension" "src" {
+  name                       = "network-watcher"
+  location                   = "${azurerm_resource_group.test.location}"
+  resource_group_name        = "${azurerm_resource_group.test.name}"
+  virtual_machine_name       = "${azurerm_virtual_machine.src.name}"
+  publisher                  = "Microsoft.Azure.NetworkWatcher"
+  type                       = "NetworkWatcherAgentLinux"
+  type_handler_version       = "1.4"
+  auto_upgrade_minor_version = true
+}
+`, rInt, location, rInt, rInt, rInt, rInt, rInt, rInt)
+}
+
+func testAzureRMConnectionMonitor_baseWithDestConfig(rInt int, location string) string {
+	config := testAzureRMConnectionMonitor_baseConfig(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_network_interface" "dest" {
+  name                = "acctni-dest%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  ip_configuration {
+    name                          = "testconfiguration1"
+    subnet_id                     = "${azurerm_subnet.test.id}"
+    private_ip_address_allocation = "dynamic"
+  }
+}
+
+resource "azurerm_virtual_machine" "dest" {
+  name                  = "acctvm-dest%d"
+  location              = "${azurerm_resource_group.test.location}"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  network_interface_ids = ["${azurerm_network_interface.dest.id}"]
+  vm_size               = "Standard_D1_v2"
+
+  storage_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  storage_os_disk {
+    name              = "osdisk-dest%d"
+    caching           = "ReadWrite"
+    create_option     = "FromImage"
+    managed_disk_type = "Standard_LRS"
+  }
+
+  os_profile {
+    computer_name  = "hostname%d"
+    admin_username = "testadmin"
+    admin_password = "Password1234!"
+  }
+
+  os_profile_linux_config {
+    disable_password_authentication = false
+  }
+}
+`, config, rInt, rInt, rInt, rInt)
+}
+
+func testAzureRMConnectionMonitor_addressBasicConfig(rInt int, location string) string {
+	config := testAzureRMConnectionMonitor_baseConfig(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_connection_monitor" "test" {
+  name                 = "acctestcm-%d"
+  network_watcher_name = "${azurerm_network_watcher.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  location             = "${azurerm_network_watcher.test.location}"
+
+  source {
+    virtual_machine_id = "${azurerm_virtual_machine.src.id}"
+  }
+
+  destination {
+    address = "terraform.io"
+    port    = 80
+  }
+
+  depends_on = ["azurerm_virtual_machine_extension.src"]
+}
+`, config, rInt)
+}
+
+func testAzureRMConnectionMonitor_addressCompleteConfig(rInt int, location string) string {
+	config := testAzureRMConnectionMonitor_baseConfig(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_connection_monitor" "test" {
+  name                 = "acctestcm-%d"
+  network_watcher_name = "${azurerm_network_watcher.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  location             = "${azurerm_network_watcher.test.location}"
+
+  auto_start          = false
+  interval_in_seconds = 30
+
+  source {
+    virtual_machine_id = "${azurerm_virtual_machine.src.id}"
+    port               = 20020
+  }
+
+  destination {
+    address = "terraform.io"
+    port    = 443
+  }
+
+  tags {
+    env = "test"
+  }
+
+  depends_on = ["azurerm_virtual_machine_extension.src"]
+}
+`, config, rInt)
+}
+
+func testAzureRMConnectionMonitor_virtualMachineBasicConfig(rInt int, location string) string {
+	config := testAzureRMConnectionMonitor_baseWithDestConfig(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_connection_monitor" "test" {
+  name                 = "acctestcm-%d"
+  network_watcher_name = "${azurerm_network_watcher.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  location             = "${azurerm_network_watcher.test.location}"
+
+  source {
+    virtual_machine_id = "${azurerm_virtual_machine.src.id}"
+  }
+
+  destination {
+    virtual_machine_id = "${azurerm_virtual_machine.dest.id}"
+    port               = 80
+  }
+
+  depends_on = ["azurerm_virtual_machine_extension.src"]
+}
+`, config, rInt)
+}
+
+func testAzureRMConnectionMonitor_destinationConflictConfig(rInt int, location string) string {
+	config := testAzureRMConnectionMonitor_baseWithDestConfig(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_connection_monitor" "test" {
+  name                 = "acctestcm-%d"
+  network_watcher_name = "${azurerm_network_watcher.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  location             = "${azurerm_network_watcher.test.location}"
+
+  source {
+    virtual_machine_id = "${azurerm_virtual_machine.src.id}"
+  }
+
+  destination {
+    virtual_machine_id = "${azurerm_virtual_machine.dest.id}"
+    address            = "terraform.io"
+    port               = 80
+  }
+
+  depends_on = ["azurerm_virtual_machine_extension.src"]
+}
+`, config, rInt)
+}
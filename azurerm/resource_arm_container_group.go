@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/containerinstance/mgmt/2018-10-01/containerinstance"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
@@ -40,9 +42,17 @@ func resourceArmContainerGroup() *schema.Resource {
 				DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
 				ValidateFunc: validation.StringInSlice([]string{
 					string(containerinstance.Public),
+					string(containerinstance.Private),
 				}, true),
 			},
 
+			"network_profile_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
 			"os_type": {
 				Type:             schema.TypeString,
 				Required:         true,
@@ -85,6 +95,59 @@ func resourceArmContainerGroup() *schema.Resource {
 				},
 			},
 
+			"diagnostics": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"log_analytics": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"workspace_id": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validate.NoEmptyStrings,
+									},
+
+									"workspace_key": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ForceNew:     true,
+										Sensitive:    true,
+										ValidateFunc: validate.NoEmptyStrings,
+									},
+
+									"log_type": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										Computed:         true,
+										ForceNew:         true,
+										DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(containerinstance.ContainerInsights),
+											string(containerinstance.ContainerInstanceLogs),
+										}, true),
+									},
+
+									"metadata": {
+										Type:     schema.TypeMap,
+										Optional: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
 			"tags": tagsForceNewSchema(),
 
 			"restart_policy": {
@@ -218,24 +281,80 @@ func resourceArmContainerGroup() *schema.Resource {
 
 									"share_name": {
 										Type:     schema.TypeString,
-										Required: true,
+										Optional: true,
 										ForceNew: true,
 									},
 
 									"storage_account_name": {
 										Type:     schema.TypeString,
-										Required: true,
+										Optional: true,
 										ForceNew: true,
 									},
 
 									"storage_account_key": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:      schema.TypeString,
+										Optional:  true,
+										ForceNew:  true,
+										Sensitive: true,
+									},
+
+									"empty_dir": {
+										Type:     schema.TypeBool,
+										Optional: true,
 										ForceNew: true,
 									},
+
+									"git_repo": {
+										Type:     schema.TypeList,
+										Optional: true,
+										ForceNew: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"url": {
+													Type:         schema.TypeString,
+													Required:     true,
+													ForceNew:     true,
+													ValidateFunc: validate.NoEmptyStrings,
+												},
+
+												"directory": {
+													Type:     schema.TypeString,
+													Optional: true,
+													ForceNew: true,
+												},
+
+												"revision": {
+													Type:     schema.TypeString,
+													Optional: true,
+													ForceNew: true,
+												},
+											},
+										},
+									},
+
+									"secret": {
+										Type:      schema.TypeMap,
+										Optional:  true,
+										ForceNew:  true,
+										Sensitive: true,
+									},
 								},
 							},
 						},
+
+						"liveness_probe": containerGroupProbeSchema(),
+
+						"readiness_probe": containerGroupProbeSchema(),
+
+						"restart_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"current_state": containerGroupContainerStateSchema(),
+
+						"previous_state": containerGroupContainerStateSchema(),
 					},
 				},
 			},
@@ -243,6 +362,137 @@ func resourceArmContainerGroup() *schema.Resource {
 	}
 }
 
+func containerGroupProbeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		ForceNew: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"exec": {
+					Type:     schema.TypeList,
+					Optional: true,
+					ForceNew: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validate.NoEmptyStrings,
+					},
+				},
+
+				"http_get": {
+					Type:     schema.TypeList,
+					Optional: true,
+					ForceNew: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"path": {
+								Type:     schema.TypeString,
+								Optional: true,
+								ForceNew: true,
+							},
+
+							"port": {
+								Type:         schema.TypeInt,
+								Required:     true,
+								ForceNew:     true,
+								ValidateFunc: validation.IntBetween(1, 65535),
+							},
+
+							"scheme": {
+								Type:             schema.TypeString,
+								Optional:         true,
+								ForceNew:         true,
+								DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
+								ValidateFunc: validation.StringInSlice([]string{
+									string(containerinstance.HTTP),
+									string(containerinstance.HTTPS),
+								}, true),
+							},
+						},
+					},
+				},
+
+				"initial_delay_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+
+				"period_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+
+				"failure_threshold": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+
+				"success_threshold": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+
+				"timeout_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func containerGroupContainerStateSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"state": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"detail_status": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"exit_code": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+
+				"start_time": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+
+				"finish_time": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
 func resourceArmContainerGroupCreate(d *schema.ResourceData, meta interface{}) error {
 	ctx := meta.(*ArmClient).StopContext
 	containerGroupsClient := meta.(*ArmClient).containerGroupsClient
@@ -255,7 +505,11 @@ func resourceArmContainerGroupCreate(d *schema.ResourceData, meta interface{}) e
 	tags := d.Get("tags").(map[string]interface{})
 	restartPolicy := d.Get("restart_policy").(string)
 
-	containers, containerGroupPorts, containerGroupVolumes := expandContainerGroupContainers(d)
+	containers, containerGroupPorts, containerGroupVolumes, err := expandContainerGroupContainers(d)
+	if err != nil {
+		return err
+	}
+
 	containerGroup := containerinstance.ContainerGroup{
 		Name:     &name,
 		Location: &location,
@@ -270,9 +524,26 @@ func resourceArmContainerGroupCreate(d *schema.ResourceData, meta interface{}) e
 			OsType:                   containerinstance.OperatingSystemTypes(OSType),
 			Volumes:                  containerGroupVolumes,
 			ImageRegistryCredentials: expandContainerImageRegistryCredentials(d),
+			Diagnostics:              expandContainerGroupDiagnostics(d),
 		},
 	}
 
+	networkProfileId := d.Get("network_profile_id").(string)
+	if strings.EqualFold(IPAddressType, string(containerinstance.Private)) {
+		if networkProfileId == "" {
+			return fmt.Errorf("`network_profile_id` must be specified when `ip_address_type` is `Private`")
+		}
+		if d.Get("dns_name_label").(string) != "" {
+			return fmt.Errorf("`dns_name_label` cannot be specified when `ip_address_type` is `Private`")
+		}
+	}
+
+	if networkProfileId != "" {
+		containerGroup.ContainerGroupProperties.NetworkProfile = &containerinstance.ContainerGroupNetworkProfile{
+			ID: utils.String(networkProfileId),
+		}
+	}
+
 	if dnsNameLabel := d.Get("dns_name_label").(string); dnsNameLabel != "" {
 		containerGroup.ContainerGroupProperties.IPAddress.DNSNameLabel = &dnsNameLabel
 	}
@@ -343,6 +614,16 @@ func resourceArmContainerGroupRead(d *schema.ResourceData, meta interface{}) err
 
 		d.Set("restart_policy", string(props.RestartPolicy))
 		d.Set("os_type", string(props.OsType))
+
+		networkProfileId := ""
+		if profile := props.NetworkProfile; profile != nil && profile.ID != nil {
+			networkProfileId = *profile.ID
+		}
+		d.Set("network_profile_id", networkProfileId)
+
+		if err := d.Set("diagnostics", flattenContainerGroupDiagnostics(d, props.Diagnostics)); err != nil {
+			return fmt.Errorf("Error setting `diagnostics`: %+v", err)
+		}
 	}
 	flattenAndSetTags(d, resp.Tags)
 
@@ -371,7 +652,7 @@ func resourceArmContainerGroupDelete(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func expandContainerGroupContainers(d *schema.ResourceData) (*[]containerinstance.Container, *[]containerinstance.Port, *[]containerinstance.Volume) {
+func expandContainerGroupContainers(d *schema.ResourceData) (*[]containerinstance.Container, *[]containerinstance.Port, *[]containerinstance.Volume, error) {
 	containersConfig := d.Get("container").([]interface{})
 	containers := make([]containerinstance.Container, 0)
 	containerGroupPorts := make([]containerinstance.Port, 0)
@@ -459,17 +740,28 @@ func expandContainerGroupContainers(d *schema.ResourceData) (*[]containerinstanc
 		}
 
 		if v, ok := data["volume"]; ok {
-			volumeMounts, containerGroupVolumesPartial := expandContainerVolumes(v)
+			volumeMounts, containerGroupVolumesPartial, err := expandContainerVolumes(v)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("Error expanding `volume` for Container %q: %+v", name, err)
+			}
 			container.VolumeMounts = volumeMounts
 			if containerGroupVolumesPartial != nil {
 				containerGroupVolumes = append(containerGroupVolumes, *containerGroupVolumesPartial...)
 			}
 		}
 
+		if v, ok := data["liveness_probe"]; ok {
+			container.LivenessProbe = expandContainerProbe(v)
+		}
+
+		if v, ok := data["readiness_probe"]; ok {
+			container.ReadinessProbe = expandContainerProbe(v)
+		}
+
 		containers = append(containers, container)
 	}
 
-	return &containers, &containerGroupPorts, &containerGroupVolumes
+	return &containers, &containerGroupPorts, &containerGroupVolumes, nil
 }
 
 func expandContainerEnvironmentVariables(input interface{}, secure bool) *[]containerinstance.EnvironmentVariable {
@@ -523,11 +815,11 @@ func expandContainerImageRegistryCredentials(d *schema.ResourceData) *[]containe
 	return &output
 }
 
-func expandContainerVolumes(input interface{}) (*[]containerinstance.VolumeMount, *[]containerinstance.Volume) {
+func expandContainerVolumes(input interface{}) (*[]containerinstance.VolumeMount, *[]containerinstance.Volume, error) {
 	volumesRaw := input.([]interface{})
 
 	if len(volumesRaw) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	volumeMounts := make([]containerinstance.VolumeMount, 0)
@@ -553,18 +845,142 @@ func expandContainerVolumes(input interface{}) (*[]containerinstance.VolumeMount
 
 		cv := containerinstance.Volume{
 			Name: utils.String(name),
-			AzureFile: &containerinstance.AzureFileVolume{
+		}
+
+		// a volume can only be one of an Azure File Share, an Empty Directory, a Git Repository or a Secret
+		kinds := 0
+
+		if shareName != "" || storageAccountName != "" || storageAccountKey != "" {
+			if shareName == "" || storageAccountName == "" || storageAccountKey == "" {
+				return nil, nil, fmt.Errorf("`share_name`, `storage_account_name` and `storage_account_key` must all be specified for the Azure File volume %q", name)
+			}
+
+			cv.AzureFile = &containerinstance.AzureFileVolume{
 				ShareName:          utils.String(shareName),
 				ReadOnly:           utils.Bool(readOnly),
 				StorageAccountName: utils.String(storageAccountName),
 				StorageAccountKey:  utils.String(storageAccountKey),
-			},
+			}
+			kinds++
+		}
+
+		if volumeConfig["empty_dir"].(bool) {
+			cv.EmptyDir = map[string]string{}
+			kinds++
+		}
+
+		if gitRepos := volumeConfig["git_repo"].([]interface{}); len(gitRepos) > 0 && gitRepos[0] != nil {
+			gitRepo := gitRepos[0].(map[string]interface{})
+			cv.GitRepo = &containerinstance.GitRepoVolume{
+				Repository: utils.String(gitRepo["url"].(string)),
+			}
+			if v := gitRepo["directory"].(string); v != "" {
+				cv.GitRepo.Directory = utils.String(v)
+			}
+			if v := gitRepo["revision"].(string); v != "" {
+				cv.GitRepo.Revision = utils.String(v)
+			}
+			kinds++
+		}
+
+		if secrets := volumeConfig["secret"].(map[string]interface{}); len(secrets) > 0 {
+			cv.Secret = make(map[string]*string)
+			for k, v := range secrets {
+				cv.Secret[k] = utils.String(v.(string))
+			}
+			kinds++
+		}
+
+		if kinds != 1 {
+			return nil, nil, fmt.Errorf("exactly one of an Azure File Share (`share_name`), `empty_dir`, `git_repo` or `secret` must be specified for the volume %q", name)
 		}
 
 		containerGroupVolumes = append(containerGroupVolumes, cv)
 	}
 
-	return &volumeMounts, &containerGroupVolumes
+	return &volumeMounts, &containerGroupVolumes, nil
+}
+
+func expandContainerProbe(input interface{}) *containerinstance.ContainerProbe {
+	probesRaw := input.([]interface{})
+	if len(probesRaw) == 0 || probesRaw[0] == nil {
+		return nil
+	}
+
+	probeConfig := probesRaw[0].(map[string]interface{})
+	probe := containerinstance.ContainerProbe{}
+
+	if v := probeConfig["initial_delay_seconds"].(int); v > 0 {
+		probe.InitialDelaySeconds = utils.Int32(int32(v))
+	}
+	if v := probeConfig["period_seconds"].(int); v > 0 {
+		probe.PeriodSeconds = utils.Int32(int32(v))
+	}
+	if v := probeConfig["failure_threshold"].(int); v > 0 {
+		probe.FailureThreshold = utils.Int32(int32(v))
+	}
+	if v := probeConfig["success_threshold"].(int); v > 0 {
+		probe.SuccessThreshold = utils.Int32(int32(v))
+	}
+	if v := probeConfig["timeout_seconds"].(int); v > 0 {
+		probe.TimeoutSeconds = utils.Int32(int32(v))
+	}
+
+	if commandsRaw := probeConfig["exec"].([]interface{}); len(commandsRaw) > 0 {
+		commands := make([]string, 0)
+		for _, command := range commandsRaw {
+			commands = append(commands, command.(string))
+		}
+
+		probe.Exec = &containerinstance.ContainerExec{
+			Command: &commands,
+		}
+	}
+
+	if httpGets := probeConfig["http_get"].([]interface{}); len(httpGets) > 0 && httpGets[0] != nil {
+		httpGet := httpGets[0].(map[string]interface{})
+		probe.HTTPGet = &containerinstance.ContainerHTTPGet{
+			Port:   utils.Int32(int32(httpGet["port"].(int))),
+			Scheme: containerinstance.Scheme(strings.ToLower(httpGet["scheme"].(string))),
+		}
+		if v := httpGet["path"].(string); v != "" {
+			probe.HTTPGet.Path = utils.String(v)
+		}
+	}
+
+	return &probe
+}
+
+func expandContainerGroupDiagnostics(d *schema.ResourceData) *containerinstance.ContainerGroupDiagnostics {
+	diagnosticsRaw := d.Get("diagnostics").([]interface{})
+	if len(diagnosticsRaw) == 0 || diagnosticsRaw[0] == nil {
+		return nil
+	}
+
+	diagnosticsConfig := diagnosticsRaw[0].(map[string]interface{})
+	logAnalyticsRaw := diagnosticsConfig["log_analytics"].([]interface{})
+	if len(logAnalyticsRaw) == 0 || logAnalyticsRaw[0] == nil {
+		return nil
+	}
+
+	logAnalyticsConfig := logAnalyticsRaw[0].(map[string]interface{})
+	logAnalytics := containerinstance.LogAnalytics{
+		WorkspaceID:  utils.String(logAnalyticsConfig["workspace_id"].(string)),
+		WorkspaceKey: utils.String(logAnalyticsConfig["workspace_key"].(string)),
+		LogType:      containerinstance.LogAnalyticsLogType(logAnalyticsConfig["log_type"].(string)),
+	}
+
+	if metadataRaw := logAnalyticsConfig["metadata"].(map[string]interface{}); len(metadataRaw) > 0 {
+		metadata := make(map[string]*string)
+		for k, v := range metadataRaw {
+			metadata[k] = utils.String(v.(string))
+		}
+		logAnalytics.Metadata = metadata
+	}
+
+	return &containerinstance.ContainerGroupDiagnostics{
+		LogAnalytics: &logAnalytics,
+	}
 }
 
 func flattenContainerImageRegistryCredentials(d *schema.ResourceData, input *[]containerinstance.ImageRegistryCredential) []interface{} {
@@ -671,6 +1087,23 @@ func flattenContainerGroupContainers(d *schema.ResourceData, containers *[]conta
 		}
 		containerConfig["commands"] = commands
 
+		containerConfig["liveness_probe"] = flattenContainerProbe(container.LivenessProbe)
+		containerConfig["readiness_probe"] = flattenContainerProbe(container.ReadinessProbe)
+
+		restartCount := 0
+		currentState := make([]interface{}, 0)
+		previousState := make([]interface{}, 0)
+		if instanceView := container.InstanceView; instanceView != nil {
+			if v := instanceView.RestartCount; v != nil {
+				restartCount = int(*v)
+			}
+			currentState = flattenContainerState(instanceView.CurrentState)
+			previousState = flattenContainerState(instanceView.PreviousState)
+		}
+		containerConfig["restart_count"] = restartCount
+		containerConfig["current_state"] = currentState
+		containerConfig["previous_state"] = previousState
+
 		if containerGroupVolumes != nil && container.VolumeMounts != nil {
 			// Also pass in the container volume config from schema
 			var containerVolumesConfig *[]interface{}
@@ -762,6 +1195,22 @@ func flattenContainerVolumes(volumeMounts *[]containerinstance.VolumeMount, cont
 						}
 						// skip storage_account_key, is always nil
 					}
+
+					volumeConfig["empty_dir"] = cgv.EmptyDir != nil
+
+					if gitRepo := cgv.GitRepo; gitRepo != nil {
+						repo := make(map[string]interface{})
+						if gitRepo.Repository != nil {
+							repo["url"] = *gitRepo.Repository
+						}
+						if gitRepo.Directory != nil {
+							repo["directory"] = *gitRepo.Directory
+						}
+						if gitRepo.Revision != nil {
+							repo["revision"] = *gitRepo.Revision
+						}
+						volumeConfig["git_repo"] = []interface{}{repo}
+					}
 				}
 			}
 		}
@@ -775,6 +1224,9 @@ func flattenContainerVolumes(volumeMounts *[]containerinstance.VolumeMount, cont
 				if vm.Name != nil && *vm.Name == rawName {
 					storageAccountKey := cv["storage_account_key"].(string)
 					volumeConfig["storage_account_key"] = storageAccountKey
+
+					// the contents of secret volumes aren't returned from the API
+					volumeConfig["secret"] = cv["secret"]
 				}
 			}
 		}
@@ -784,3 +1236,112 @@ func flattenContainerVolumes(volumeMounts *[]containerinstance.VolumeMount, cont
 
 	return volumeConfigs
 }
+
+func flattenContainerProbe(input *containerinstance.ContainerProbe) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	output := make(map[string]interface{})
+
+	if v := input.InitialDelaySeconds; v != nil {
+		output["initial_delay_seconds"] = int(*v)
+	}
+	if v := input.PeriodSeconds; v != nil {
+		output["period_seconds"] = int(*v)
+	}
+	if v := input.FailureThreshold; v != nil {
+		output["failure_threshold"] = int(*v)
+	}
+	if v := input.SuccessThreshold; v != nil {
+		output["success_threshold"] = int(*v)
+	}
+	if v := input.TimeoutSeconds; v != nil {
+		output["timeout_seconds"] = int(*v)
+	}
+
+	commands := make([]interface{}, 0)
+	if exec := input.Exec; exec != nil && exec.Command != nil {
+		for _, command := range *exec.Command {
+			commands = append(commands, command)
+		}
+	}
+	output["exec"] = commands
+
+	httpGets := make([]interface{}, 0)
+	if httpGet := input.HTTPGet; httpGet != nil {
+		get := map[string]interface{}{
+			"scheme": string(httpGet.Scheme),
+		}
+		if httpGet.Path != nil {
+			get["path"] = *httpGet.Path
+		}
+		if httpGet.Port != nil {
+			get["port"] = int(*httpGet.Port)
+		}
+		httpGets = append(httpGets, get)
+	}
+	output["http_get"] = httpGets
+
+	return []interface{}{output}
+}
+
+func flattenContainerState(input *containerinstance.ContainerState) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	output := make(map[string]interface{})
+
+	if v := input.State; v != nil {
+		output["state"] = *v
+	}
+	if v := input.DetailStatus; v != nil {
+		output["detail_status"] = *v
+	}
+	if v := input.ExitCode; v != nil {
+		output["exit_code"] = int(*v)
+	}
+	if v := input.StartTime; v != nil {
+		output["start_time"] = v.Format(time.RFC3339)
+	}
+	if v := input.FinishTime; v != nil {
+		output["finish_time"] = v.Format(time.RFC3339)
+	}
+
+	return []interface{}{output}
+}
+
+func flattenContainerGroupDiagnostics(d *schema.ResourceData, input *containerinstance.ContainerGroupDiagnostics) []interface{} {
+	if input == nil || input.LogAnalytics == nil {
+		return []interface{}{}
+	}
+
+	logAnalytics := input.LogAnalytics
+	output := map[string]interface{}{
+		"log_type": string(logAnalytics.LogType),
+	}
+
+	if v := logAnalytics.WorkspaceID; v != nil {
+		output["workspace_id"] = *v
+	}
+
+	// the Workspace Key isn't returned from the API, so we pull it from the config
+	if v, ok := d.GetOk("diagnostics.0.log_analytics.0.workspace_key"); ok {
+		output["workspace_key"] = v.(string)
+	}
+
+	metadata := make(map[string]interface{})
+	for k, v := range logAnalytics.Metadata {
+		if v != nil {
+			metadata[k] = *v
+		}
+	}
+	output["metadata"] = metadata
+
+	return []interface{}{
+		map[string]interface{}{
+			"log_analytics": []interface{}{output},
+		},
+	}
+}
@@ -272,6 +272,128 @@ func TestAccAzureRMContainerGroup_windowsComplete(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMContainerGroup_probes(t *testing.T) {
+	resourceName := "azurerm_container_group.test"
+	ri := acctest.RandInt()
+
+	config := testAccAzureRMContainerGroup_probes(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "container.0.liveness_probe.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "container.0.liveness_probe.0.http_get.0.port", "80"),
+					resource.TestCheckResourceAttr(resourceName, "container.0.liveness_probe.0.failure_threshold", "3"),
+					resource.TestCheckResourceAttr(resourceName, "container.0.readiness_probe.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "container.0.readiness_probe.0.exec.#", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "container.0.restart_count"),
+					resource.TestCheckResourceAttr(resourceName, "container.0.current_state.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMContainerGroup_volumes(t *testing.T) {
+	resourceName := "azurerm_container_group.test"
+	ri := acctest.RandInt()
+
+	config := testAccAzureRMContainerGroup_volumes(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "container.0.volume.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "container.0.volume.0.empty_dir", "true"),
+					resource.TestCheckResourceAttr(resourceName, "container.0.volume.1.git_repo.0.url", "https://github.com/Azure-Samples/aci-helloworld"),
+					resource.TestCheckResourceAttr(resourceName, "container.0.volume.2.secret.%", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"container.0.volume.2.secret",
+				},
+			},
+		},
+	})
+}
+
+func TestAccAzureRMContainerGroup_logAnalytics(t *testing.T) {
+	resourceName := "azurerm_container_group.test"
+	ri := acctest.RandInt()
+
+	config := testAccAzureRMContainerGroup_logAnalytics(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "diagnostics.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "diagnostics.0.log_analytics.0.log_type", "ContainerInsights"),
+					resource.TestCheckResourceAttr(resourceName, "diagnostics.0.log_analytics.0.metadata.%", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"diagnostics.0.log_analytics.0.workspace_key",
+				},
+			},
+		},
+	})
+}
+
+func TestAccAzureRMContainerGroup_virtualNetwork(t *testing.T) {
+	resourceName := "azurerm_container_group.test"
+	ri := acctest.RandInt()
+
+	config := testAccAzureRMContainerGroup_virtualNetwork(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerGroupExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "ip_address_type", "Private"),
+					resource.TestCheckResourceAttrSet(resourceName, "network_profile_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "ip_address"),
+				),
+			},
+		},
+	})
+}
+
 func testAccAzureRMContainerGroup_linuxBasic(ri int, location string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
@@ -571,6 +693,254 @@ resource "azurerm_container_group" "test" {
 `, ri, location, ri, ri, ri, ri)
 }
 
+func testAccAzureRMContainerGroup_probes(ri int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_container_group" "test" {
+  name                = "acctestcontainergroup-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  ip_address_type     = "public"
+  os_type             = "Linux"
+
+  container {
+    name   = "hw"
+    image  = "microsoft/aci-helloworld:latest"
+    cpu    = "0.5"
+    memory = "0.5"
+    port   = "80"
+
+    liveness_probe {
+      http_get {
+        path   = "/"
+        port   = 80
+        scheme = "Http"
+      }
+
+      initial_delay_seconds = 10
+      period_seconds        = 5
+      failure_threshold     = 3
+      success_threshold     = 1
+      timeout_seconds       = 2
+    }
+
+    readiness_probe {
+      exec = ["cat", "/usr/src/app/index.js"]
+
+      initial_delay_seconds = 5
+      period_seconds        = 5
+    }
+  }
+}
+`, ri, location, ri)
+}
+
+func testAccAzureRMContainerGroup_volumes(ri int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_container_group" "test" {
+  name                = "acctestcontainergroup-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  ip_address_type     = "public"
+  os_type             = "Linux"
+
+  container {
+    name   = "hw"
+    image  = "microsoft/aci-helloworld:latest"
+    cpu    = "0.5"
+    memory = "0.5"
+    port   = "80"
+
+    volume {
+      name       = "scratch"
+      mount_path = "/aci/scratch"
+      empty_dir  = true
+    }
+
+    volume {
+      name       = "repo"
+      mount_path = "/aci/repo"
+
+      git_repo {
+        url       = "https://github.com/Azure-Samples/aci-helloworld"
+        directory = "."
+      }
+    }
+
+    volume {
+      name       = "secrets"
+      mount_path = "/aci/secrets"
+      read_only  = true
+
+      secret {
+        "token" = "${base64encode("hello world")}"
+      }
+    }
+  }
+}
+`, ri, location, ri)
+}
+
+func testAccAzureRMContainerGroup_logAnalytics(ri int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_log_analytics_workspace" "test" {
+  name                = "acctestLAW-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  sku                 = "PerGB2018"
+}
+
+resource "azurerm_container_group" "test" {
+  name                = "acctestcontainergroup-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  ip_address_type     = "public"
+  os_type             = "Linux"
+
+  container {
+    name   = "hw"
+    image  = "microsoft/aci-helloworld:latest"
+    cpu    = "0.5"
+    memory = "0.5"
+    port   = "80"
+  }
+
+  diagnostics {
+    log_analytics {
+      workspace_id  = "${azurerm_log_analytics_workspace.test.workspace_id}"
+      workspace_key = "${azurerm_log_analytics_workspace.test.primary_shared_key}"
+      log_type      = "ContainerInsights"
+
+      metadata {
+        "node-name" = "acctestContainerGroup"
+      }
+    }
+  }
+}
+`, ri, location, ri, ri)
+}
+
+func testAccAzureRMContainerGroup_virtualNetwork(ri int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestvirtnet%[1]d"
+  address_space       = ["10.1.0.0/16"]
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+# Subnet Delegations and Network Profiles aren't available in the vendored Network API, so these are provisioned via a Template
+resource "azurerm_template_deployment" "test" {
+  name                = "acctesttemplate-%[1]d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  deployment_mode     = "Incremental"
+
+  template_body = <<DEPLOY
+{
+  "$schema": "https://schema.management.azure.com/schemas/2015-01-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "parameters": {
+    "virtualNetworkName": { "type": "string" }
+  },
+  "variables": {
+    "subnetId": "[resourceId('Microsoft.Network/virtualNetworks/subnets', parameters('virtualNetworkName'), 'acctestsubnet')]"
+  },
+  "resources": [
+    {
+      "type": "Microsoft.Network/virtualNetworks/subnets",
+      "apiVersion": "2018-08-01",
+      "name": "[concat(parameters('virtualNetworkName'), '/acctestsubnet')]",
+      "properties": {
+        "addressPrefix": "10.1.0.0/24",
+        "delegations": [
+          {
+            "name": "delegation",
+            "properties": {
+              "serviceName": "Microsoft.ContainerInstance/containerGroups"
+            }
+          }
+        ]
+      }
+    },
+    {
+      "type": "Microsoft.Network/networkProfiles",
+      "apiVersion": "2018-08-01",
+      "name": "acctestnetprofile-%[1]d",
+      "location": "[resourceGroup().location]",
+      "dependsOn": [
+        "[variables('subnetId')]"
+      ],
+      "properties": {
+        "containerNetworkInterfaceConfigurations": [
+          {
+            "name": "acctesteth-%[1]d",
+            "properties": {
+              "ipConfigurations": [
+                {
+                  "name": "acctestipconfig-%[1]d",
+                  "properties": {
+                    "subnet": { "id": "[variables('subnetId')]" }
+                  }
+                }
+              ]
+            }
+          }
+        ]
+      }
+    }
+  ],
+  "outputs": {
+    "networkProfileId": {
+      "type": "string",
+      "value": "[resourceId('Microsoft.Network/networkProfiles', 'acctestnetprofile-%[1]d')]"
+    }
+  }
+}
+DEPLOY
+
+  parameters {
+    "virtualNetworkName" = "${azurerm_virtual_network.test.name}"
+  }
+}
+
+resource "azurerm_container_group" "test" {
+  name                = "acctestcontainergroup-%[1]d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  ip_address_type     = "Private"
+  network_profile_id  = "${lookup(azurerm_template_deployment.test.outputs, "networkProfileId")}"
+  os_type             = "Linux"
+
+  container {
+    name   = "hw"
+    image  = "microsoft/aci-helloworld:latest"
+    cpu    = "0.5"
+    memory = "0.5"
+    port   = "80"
+  }
+}
+`, ri, location)
+}
+
 func testCheckAzureRMContainerGroupExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// Ensure we have enough information in state to look up in API
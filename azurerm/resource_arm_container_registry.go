@@ -58,6 +58,11 @@ func resourceArmContainerRegistry() *schema.Resource {
 				Default:  false,
 			},
 
+			"admin_password_rotation_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"georeplication_locations": {
 				Type:     schema.TypeSet,
 				MinItems: 1,
@@ -270,6 +275,16 @@ func resourceArmContainerRegistryUpdate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
+	// the admin password can only be regenerated whilst the admin user is enabled
+	if d.HasChange("admin_password_rotation_trigger") && adminUserEnabled {
+		regenerateParameters := containerregistry.RegenerateCredentialParameters{
+			Name: containerregistry.Password,
+		}
+		if _, err = client.RegenerateCredential(ctx, resourceGroup, name, regenerateParameters); err != nil {
+			return fmt.Errorf("Error regenerating Admin Password for Container Registry %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	}
+
 	read, err := client.Get(ctx, resourceGroup, name)
 	if err != nil {
 		return fmt.Errorf("Error retrieving Container Registry %q (Resource Group %q): %+v", name, resourceGroup, err)
@@ -401,9 +416,13 @@ func resourceArmContainerRegistryRead(d *schema.ResourceData, meta interface{})
 		}
 
 		d.Set("admin_username", credsResp.Username)
-		for _, v := range *credsResp.Passwords {
-			d.Set("admin_password", v.Value)
-			break
+		if passwords := credsResp.Passwords; passwords != nil {
+			// `admin_password_rotation_trigger` regenerates `password`, so prefer it over `password2`
+			for i, v := range *passwords {
+				if i == 0 || v.Name == containerregistry.Password {
+					d.Set("admin_password", v.Value)
+				}
+			}
 		}
 	} else {
 		d.Set("admin_username", "")
@@ -333,6 +333,36 @@ func TestAccAzureRMContainerRegistry_geoReplication(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMContainerRegistry_adminPasswordRotation(t *testing.T) {
+	resourceName := "azurerm_container_registry.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+	var adminPassword string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMContainerRegistry_adminPasswordRotation(ri, location, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "admin_password"),
+					testCheckAzureRMContainerRegistryAdminPassword(resourceName, &adminPassword, false),
+				),
+			},
+			{
+				Config: testAccAzureRMContainerRegistry_adminPasswordRotation(ri, location, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryExists(resourceName),
+					testCheckAzureRMContainerRegistryAdminPassword(resourceName, &adminPassword, true),
+				),
+			},
+		},
+	})
+}
+
 func testCheckAzureRMContainerRegistryDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*ArmClient).containerRegistryClient
 	ctx := testAccProvider.Meta().(*ArmClient).StopContext
@@ -444,6 +474,41 @@ resource "azurerm_container_registry" "test" {
 `, rInt, location, rInt, sku)
 }
 
+func testCheckAzureRMContainerRegistryAdminPassword(name string, password *string, expectChanged bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		current := rs.Primary.Attributes["admin_password"]
+		if expectChanged && current == *password {
+			return fmt.Errorf("Bad: expected the Admin Password for Container Registry %q to have been rotated", rs.Primary.Attributes["name"])
+		}
+
+		*password = current
+		return nil
+	}
+}
+
+func testAccAzureRMContainerRegistry_adminPasswordRotation(rInt int, location string, trigger string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRg-%d"
+  location = "%s"
+}
+
+resource "azurerm_container_registry" "test" {
+  name                            = "testacccr%d"
+  resource_group_name             = "${azurerm_resource_group.test.name}"
+  location                        = "${azurerm_resource_group.test.location}"
+  sku                             = "Basic"
+  admin_enabled                   = true
+  admin_password_rotation_trigger = "%s"
+}
+`, rInt, location, rInt, trigger)
+}
+
 func testAccAzureRMContainerRegistry_basicUnmanaged(rInt int, rStr string, location string, sku string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
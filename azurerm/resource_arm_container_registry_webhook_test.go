@@ -0,0 +1,227 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestAccAzureRMContainerRegistryWebhookServiceUri_validation(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "invalid",
+			ErrCount: 1,
+		},
+		{
+			Value:    "ftp://example.com",
+			ErrCount: 1,
+		},
+		{
+			Value:    "https://example.com/hook with spaces",
+			ErrCount: 1,
+		},
+		{
+			Value:    "http://example.com",
+			ErrCount: 0,
+		},
+		{
+			Value:    "https://mywebhookreceiver.example/mytag?key=value",
+			ErrCount: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateAzureRMContainerRegistryWebhookServiceUri(tc.Value, "service_uri")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %q to trigger %d validation errors but got %d", tc.Value, tc.ErrCount, len(errors))
+		}
+	}
+}
+
+func TestAccAzureRMContainerRegistryWebhook_basic(t *testing.T) {
+	resourceName := "azurerm_container_registry_webhook.test"
+	ri := acctest.RandInt()
+	config := testAccAzureRMContainerRegistryWebhook_basic(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryWebhookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryWebhookExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "actions.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "status", "enabled"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMContainerRegistryWebhook_update(t *testing.T) {
+	resourceName := "azurerm_container_registry_webhook.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMContainerRegistryWebhookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMContainerRegistryWebhook_basic(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryWebhookExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "actions.#", "1"),
+				),
+			},
+			{
+				Config: testAccAzureRMContainerRegistryWebhook_complete(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMContainerRegistryWebhookExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "actions.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "scope", "mytag:*"),
+					resource.TestCheckResourceAttr(resourceName, "status", "disabled"),
+					resource.TestCheckResourceAttr(resourceName, "custom_headers.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckAzureRMContainerRegistryWebhookDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).containerRegistryWebhooksClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_container_registry_webhook" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+		registryName := rs.Primary.Attributes["registry_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		resp, err := client.Get(ctx, resourceGroup, registryName, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(resp.Response) {
+				return err
+			}
+
+			continue
+		}
+
+		return fmt.Errorf("Webhook %q (Container Registry %q / Resource Group %q) still exists", name, registryName, resourceGroup)
+	}
+
+	return nil
+}
+
+func testCheckAzureRMContainerRegistryWebhookExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		registryName := rs.Primary.Attributes["registry_name"]
+		resourceGroup, hasResourceGroup := rs.Primary.Attributes["resource_group_name"]
+		if !hasResourceGroup {
+			return fmt.Errorf("Bad: no resource group found in state for Webhook: %s", name)
+		}
+
+		client := testAccProvider.Meta().(*ArmClient).containerRegistryWebhooksClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, registryName, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: Webhook %q (Container Registry %q / Resource Group %q) does not exist", name, registryName, resourceGroup)
+			}
+
+			return fmt.Errorf("Bad: Get on containerRegistryWebhooksClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testAccAzureRMContainerRegistryWebhook_template(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRg-%d"
+  location = "%s"
+}
+
+resource "azurerm_container_registry" "test" {
+  name                = "testacccr%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+  sku                 = "Standard"
+}
+`, rInt, location, rInt)
+}
+
+func testAccAzureRMContainerRegistryWebhook_basic(rInt int, location string) string {
+	template := testAccAzureRMContainerRegistryWebhook_template(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_webhook" "test" {
+  name                = "testaccwebhook%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  registry_name       = "${azurerm_container_registry.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+  service_uri         = "https://mywebhookreceiver.example/mytag"
+  actions             = ["push"]
+}
+`, template, rInt)
+}
+
+func testAccAzureRMContainerRegistryWebhook_complete(rInt int, location string) string {
+	template := testAccAzureRMContainerRegistryWebhook_template(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_webhook" "test" {
+  name                = "testaccwebhook%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  registry_name       = "${azurerm_container_registry.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+  service_uri         = "https://mywebhookreceiver.example/updated"
+  actions             = ["push", "delete"]
+  scope               = "mytag:*"
+  status              = "disabled"
+
+  custom_headers {
+    "Content-Type" = "application/json"
+  }
+
+  tags {
+    environment = "Production"
+  }
+}
+`, template, rInt)
+}
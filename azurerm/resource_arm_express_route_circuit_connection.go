@@ -0,0 +1,221 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmExpressRouteCircuitConnection() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmExpressRouteCircuitConnectionCreate,
+		Read:   resourceArmExpressRouteCircuitConnectionRead,
+		Delete: resourceArmExpressRouteCircuitConnectionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"express_route_circuit_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			// Global Reach is only supported on the Private Peering
+			"peering_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(network.AzurePrivatePeering),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(network.AzurePrivatePeering),
+				}, false),
+			},
+
+			"peer_peering_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"address_prefix": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.CIDRNetwork(29, 29),
+			},
+
+			"authorization_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"circuit_connection_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceArmExpressRouteCircuitConnectionCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).expressRouteConnectionsClient
+	peeringsClient := meta.(*ArmClient).expressRoutePeeringsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	log.Printf("[INFO] preparing arguments for Express Route Circuit Connection creation.")
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	circuitName := d.Get("express_route_circuit_name").(string)
+	peeringName := d.Get("peering_name").(string)
+
+	peering, err := peeringsClient.Get(ctx, resourceGroup, circuitName, peeringName)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Express Route Circuit Peering %q (Circuit %q / Resource Group %q): %+v", peeringName, circuitName, resourceGroup, err)
+	}
+	if peering.ID == nil {
+		return fmt.Errorf("Error retrieving Express Route Circuit Peering %q (Circuit %q / Resource Group %q): ID was nil", peeringName, circuitName, resourceGroup)
+	}
+
+	properties := network.ExpressRouteCircuitConnectionPropertiesFormat{
+		ExpressRouteCircuitPeering: &network.SubResource{
+			ID: peering.ID,
+		},
+		PeerExpressRouteCircuitPeering: &network.SubResource{
+			ID: utils.String(d.Get("peer_peering_id").(string)),
+		},
+		AddressPrefix: utils.String(d.Get("address_prefix").(string)),
+	}
+
+	if v := d.Get("authorization_key").(string); v != "" {
+		properties.AuthorizationKey = utils.String(v)
+	}
+
+	parameters := network.ExpressRouteCircuitConnection{
+		Name: utils.String(name),
+		ExpressRouteCircuitConnectionPropertiesFormat: &properties,
+	}
+
+	azureRMLockByName(circuitName, expressRouteCircuitResourceName)
+	defer azureRMUnlockByName(circuitName, expressRouteCircuitResourceName)
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, circuitName, peeringName, name, parameters)
+	if err != nil {
+		return fmt.Errorf("Error creating Express Route Circuit Connection %q (Circuit %q / Resource Group %q): %+v", name, circuitName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for creation of Express Route Circuit Connection %q (Circuit %q / Resource Group %q): %+v", name, circuitName, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, circuitName, peeringName, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Express Route Circuit Connection %q (Circuit %q / Resource Group %q): %+v", name, circuitName, resourceGroup, err)
+	}
+
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read ID of Express Route Circuit Connection %q (Circuit %q / Resource Group %q)", name, circuitName, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmExpressRouteCircuitConnectionRead(d, meta)
+}
+
+func resourceArmExpressRouteCircuitConnectionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).expressRouteConnectionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	circuitName := id.Path["expressRouteCircuits"]
+	peeringName := id.Path["peerings"]
+	name := id.Path["connections"]
+
+	resp, err := client.Get(ctx, resourceGroup, circuitName, peeringName, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Express Route Circuit Connection %q was not found (Circuit %q / Resource Group %q) - removing from state", name, circuitName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error making Read request on Express Route Circuit Connection %q (Circuit %q / Resource Group %q): %+v", name, circuitName, resourceGroup, err)
+	}
+
+	d.Set("name", name)
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("express_route_circuit_name", circuitName)
+	d.Set("peering_name", peeringName)
+
+	if props := resp.ExpressRouteCircuitConnectionPropertiesFormat; props != nil {
+		if peer := props.PeerExpressRouteCircuitPeering; peer != nil {
+			d.Set("peer_peering_id", peer.ID)
+		}
+		d.Set("address_prefix", props.AddressPrefix)
+		d.Set("circuit_connection_status", string(props.CircuitConnectionStatus))
+
+		// the API doesn't return the Authorization Key, so we keep the value from the config
+	}
+
+	return nil
+}
+
+func resourceArmExpressRouteCircuitConnectionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).expressRouteConnectionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	circuitName := id.Path["expressRouteCircuits"]
+	peeringName := id.Path["peerings"]
+	name := id.Path["connections"]
+
+	azureRMLockByName(circuitName, expressRouteCircuitResourceName)
+	defer azureRMUnlockByName(circuitName, expressRouteCircuitResourceName)
+
+	future, err := client.Delete(ctx, resourceGroup, circuitName, peeringName, name)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+		return fmt.Errorf("Error issuing delete request for Express Route Circuit Connection %q (Circuit %q / Resource Group %q): %+v", name, circuitName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+		return fmt.Errorf("Error waiting for Express Route Circuit Connection %q (Circuit %q / Resource Group %q) to be deleted: %+v", name, circuitName, resourceGroup, err)
+	}
+
+	return nil
+}
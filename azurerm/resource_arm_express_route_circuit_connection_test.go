@@ -0,0 +1,175 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func testAccAzureRMExpressRouteCircuitConnection_basic(t *testing.T) {
+	resourceName := "azurerm_express_route_circuit_connection.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMExpressRouteCircuitConnectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMExpressRouteCircuitConnection_basicConfig(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMExpressRouteCircuitConnectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "peering_name", "AzurePrivatePeering"),
+					resource.TestCheckResourceAttr(resourceName, "address_prefix", "192.169.8.0/29"),
+					resource.TestCheckResourceAttrSet(resourceName, "circuit_connection_status"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"authorization_key"},
+			},
+		},
+	})
+}
+
+func testCheckAzureRMExpressRouteCircuitConnectionExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		connectionName := rs.Primary.Attributes["name"]
+		circuitName := rs.Primary.Attributes["express_route_circuit_name"]
+		peeringName := rs.Primary.Attributes["peering_name"]
+		resourceGroup, hasResourceGroup := rs.Primary.Attributes["resource_group_name"]
+		if !hasResourceGroup {
+			return fmt.Errorf("Bad: no resource group found in state for Express Route Circuit Connection: %s", connectionName)
+		}
+
+		client := testAccProvider.Meta().(*ArmClient).expressRouteConnectionsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, circuitName, peeringName, connectionName)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: Express Route Circuit Connection %q (Circuit %q / Resource Group %q) does not exist", connectionName, circuitName, resourceGroup)
+			}
+
+			return fmt.Errorf("Bad: Get on expressRouteConnectionsClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMExpressRouteCircuitConnectionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).expressRouteConnectionsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_express_route_circuit_connection" {
+			continue
+		}
+
+		connectionName := rs.Primary.Attributes["name"]
+		circuitName := rs.Primary.Attributes["express_route_circuit_name"]
+		peeringName := rs.Primary.Attributes["peering_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		resp, err := client.Get(ctx, resourceGroup, circuitName, peeringName, connectionName)
+
+		if err != nil {
+			return nil
+		}
+
+		if resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("Express Route Circuit Connection still exists:\n%#v", resp)
+		}
+	}
+
+	return nil
+}
+
+func testAccAzureRMExpressRouteCircuitConnection_basicConfig(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_express_route_circuit" "test" {
+  name                  = "acctest-erc-%d"
+  location              = "${azurerm_resource_group.test.location}"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  service_provider_name = "Equinix"
+  peering_location      = "Silicon Valley"
+  bandwidth_in_mbps     = 50
+
+  sku {
+    tier   = "Premium"
+    family = "MeteredData"
+  }
+}
+
+resource "azurerm_express_route_circuit_peering" "test" {
+  peering_type                  = "AzurePrivatePeering"
+  express_route_circuit_name    = "${azurerm_express_route_circuit.test.name}"
+  resource_group_name           = "${azurerm_resource_group.test.name}"
+  shared_key                    = "ABCdefGHIJklm@nOPqrsTU!!"
+  peer_asn                      = 100
+  primary_peer_address_prefix   = "192.168.1.0/30"
+  secondary_peer_address_prefix = "192.168.2.0/30"
+  vlan_id                       = 100
+}
+
+resource "azurerm_express_route_circuit" "peer" {
+  name                  = "acctest-erc-peer-%d"
+  location              = "${azurerm_resource_group.test.location}"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  service_provider_name = "Equinix"
+  peering_location      = "Washington DC"
+  bandwidth_in_mbps     = 50
+
+  sku {
+    tier   = "Premium"
+    family = "MeteredData"
+  }
+}
+
+resource "azurerm_express_route_circuit_peering" "peer" {
+  peering_type                  = "AzurePrivatePeering"
+  express_route_circuit_name    = "${azurerm_express_route_circuit.peer.name}"
+  resource_group_name           = "${azurerm_resource_group.test.name}"
+  shared_key                    = "ABCdefGHIJklm@nOPqrsTU!!"
+  peer_asn                      = 100
+  primary_peer_address_prefix   = "192.168.3.0/30"
+  secondary_peer_address_prefix = "192.168.4.0/30"
+  vlan_id                       = 200
+}
+
+resource "azurerm_express_route_circuit_authorization" "peer" {
+  name                       = "acctestauth%d"
+  express_route_circuit_name = "${azurerm_express_route_circuit.peer.name}"
+  resource_group_name        = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_express_route_circuit_connection" "test" {
+  name                       = "acctest-ercc-%d"
+  resource_group_name        = "${azurerm_resource_group.test.name}"
+  express_route_circuit_name = "${azurerm_express_route_circuit.test.name}"
+  peering_name               = "${azurerm_express_route_circuit_peering.test.peering_type}"
+  peer_peering_id            = "${azurerm_express_route_circuit_peering.peer.id}"
+  address_prefix             = "192.169.8.0/29"
+  authorization_key          = "${azurerm_express_route_circuit_authorization.peer.authorization_key}"
+}
+`, rInt, location, rInt, rInt, rInt, rInt)
+}
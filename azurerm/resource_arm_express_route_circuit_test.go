@@ -35,6 +35,13 @@ func TestAccAzureRMExpressRouteCircuit(t *testing.T) {
 			"basic":    testAccAzureRMExpressRouteCircuitAuthorization_basic,
 			"multiple": testAccAzureRMExpressRouteCircuitAuthorization_multiple,
 		},
+		"connection": {
+			"basic": testAccAzureRMExpressRouteCircuitConnection_basic,
+		},
+		"DataSource": {
+			"arpTable":   testAccDataSourceAzureRMExpressRouteCircuitArpTable_basic,
+			"routeTable": testAccDataSourceAzureRMExpressRouteCircuitRouteTable_basic,
+		},
 	}
 
 	for group, m := range testCases {
@@ -0,0 +1,196 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+var expressRouteCrossConnectionResourceName = "azurerm_express_route_cross_connection"
+
+// Express Route Cross Connections are created by Azure when a customer provisions an Express Route Circuit
+// against a connectivity provider - as such this resource manages the provider-side of an existing
+// Cross Connection, rather than creating or deleting it.
+func resourceArmExpressRouteCrossConnection() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmExpressRouteCrossConnectionCreateUpdate,
+		Read:   resourceArmExpressRouteCrossConnectionRead,
+		Update: resourceArmExpressRouteCrossConnectionCreateUpdate,
+		Delete: resourceArmExpressRouteCrossConnectionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"service_provider_provisioning_state": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(network.NotProvisioned),
+					string(network.Provisioning),
+					string(network.Provisioned),
+					string(network.Deprovisioning),
+				}, false),
+			},
+
+			"service_provider_notes": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"location": locationForDataSourceSchema(),
+
+			"express_route_circuit_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"peering_location": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"bandwidth_in_mbps": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"primary_azure_port": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"secondary_azure_port": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"s_tag": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceArmExpressRouteCrossConnectionCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).expressRouteCrossConnectionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	log.Printf("[INFO] preparing arguments for Express Route Cross Connection create/update.")
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	azureRMLockByName(name, expressRouteCrossConnectionResourceName)
+	defer azureRMUnlockByName(name, expressRouteCrossConnectionResourceName)
+
+	// the Cross Connection has to exist already, since it's created alongside the Express Route Circuit
+	existing, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(existing.Response) {
+			return fmt.Errorf("Express Route Cross Connection %q (Resource Group %q) was not found - it's created by Azure when an Express Route Circuit is provisioned against this Service Provider", name, resourceGroup)
+		}
+		return fmt.Errorf("Error retrieving Express Route Cross Connection %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if existing.ExpressRouteCrossConnectionProperties == nil {
+		existing.ExpressRouteCrossConnectionProperties = &network.ExpressRouteCrossConnectionProperties{}
+	}
+
+	props := existing.ExpressRouteCrossConnectionProperties
+	props.ServiceProviderProvisioningState = network.ServiceProviderProvisioningState(d.Get("service_provider_provisioning_state").(string))
+	props.ServiceProviderNotes = utils.String(d.Get("service_provider_notes").(string))
+
+	// the peerings are managed via the `azurerm_express_route_cross_connection_peering` resource
+	props.Peerings = nil
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, name, existing)
+	if err != nil {
+		return fmt.Errorf("Error updating Express Route Cross Connection %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for update of Express Route Cross Connection %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Express Route Cross Connection %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read ID of Express Route Cross Connection %q (Resource Group %q)", name, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmExpressRouteCrossConnectionRead(d, meta)
+}
+
+func resourceArmExpressRouteCrossConnectionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).expressRouteCrossConnectionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["expressRouteCrossConnections"]
+
+	resp, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Express Route Cross Connection %q was not found (Resource Group %q) - removing from state", name, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error making Read request on Express Route Cross Connection %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	d.Set("name", name)
+	d.Set("resource_group_name", resourceGroup)
+	if location := resp.Location; location != nil {
+		d.Set("location", azureRMNormalizeLocation(*location))
+	}
+
+	if props := resp.ExpressRouteCrossConnectionProperties; props != nil {
+		d.Set("service_provider_provisioning_state", string(props.ServiceProviderProvisioningState))
+		d.Set("service_provider_notes", props.ServiceProviderNotes)
+		d.Set("peering_location", props.PeeringLocation)
+		d.Set("bandwidth_in_mbps", props.BandwidthInMbps)
+		d.Set("primary_azure_port", props.PrimaryAzurePort)
+		d.Set("secondary_azure_port", props.SecondaryAzurePort)
+		d.Set("s_tag", props.STag)
+
+		circuitId := ""
+		if circuit := props.ExpressRouteCircuit; circuit != nil && circuit.ID != nil {
+			circuitId = *circuit.ID
+		}
+		d.Set("express_route_circuit_id", circuitId)
+	}
+
+	return nil
+}
+
+func resourceArmExpressRouteCrossConnectionDelete(d *schema.ResourceData, meta interface{}) error {
+	// the Cross Connection's lifecycle is tied to the Express Route Circuit, which the customer owns
+	// and there's no API to delete it - so all we can do is remove it from the state
+	log.Printf("[DEBUG] Express Route Cross Connections can't be deleted - removing %q from the state only", d.Id())
+	return nil
+}
@@ -0,0 +1,260 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmExpressRouteCrossConnectionPeering() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmExpressRouteCrossConnectionPeeringCreateUpdate,
+		Read:   resourceArmExpressRouteCrossConnectionPeeringRead,
+		Update: resourceArmExpressRouteCrossConnectionPeeringCreateUpdate,
+		Delete: resourceArmExpressRouteCrossConnectionPeeringDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"peering_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(network.AzurePrivatePeering),
+					string(network.AzurePublicPeering),
+					string(network.MicrosoftPeering),
+				}, false),
+			},
+
+			"express_route_cross_connection_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"primary_peer_address_prefix": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"secondary_peer_address_prefix": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"vlan_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"shared_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringLenBetween(1, 25),
+			},
+
+			"peer_asn": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"microsoft_peering_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"advertised_public_prefixes": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"azure_asn": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"primary_azure_port": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"secondary_azure_port": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceArmExpressRouteCrossConnectionPeeringCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).expressRouteCrossConnectionPeeringsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	log.Printf("[INFO] preparing arguments for Express Route Cross Connection Peering create/update.")
+
+	peeringType := d.Get("peering_type").(string)
+	crossConnectionName := d.Get("express_route_cross_connection_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	state := network.ExpressRoutePeeringStateDisabled
+	if d.Get("enabled").(bool) {
+		state = network.ExpressRoutePeeringStateEnabled
+	}
+
+	properties := network.ExpressRouteCrossConnectionPeeringProperties{
+		PeeringType:                network.ExpressRoutePeeringType(peeringType),
+		State:                      state,
+		PrimaryPeerAddressPrefix:   utils.String(d.Get("primary_peer_address_prefix").(string)),
+		SecondaryPeerAddressPrefix: utils.String(d.Get("secondary_peer_address_prefix").(string)),
+		VlanID:                     utils.Int32(int32(d.Get("vlan_id").(int))),
+	}
+
+	if v := d.Get("shared_key").(string); v != "" {
+		properties.SharedKey = utils.String(v)
+	}
+
+	if v := d.Get("peer_asn").(int); v != 0 {
+		properties.PeerASN = utils.Int64(int64(v))
+	}
+
+	if strings.EqualFold(peeringType, string(network.MicrosoftPeering)) {
+		peerings := d.Get("microsoft_peering_config").([]interface{})
+		if len(peerings) == 0 {
+			return fmt.Errorf("`microsoft_peering_config` must be specified when `peering_type` is set to `MicrosoftPeering`")
+		}
+
+		properties.MicrosoftPeeringConfig = expandExpressRouteCircuitPeeringMicrosoftConfig(peerings)
+	}
+
+	parameters := network.ExpressRouteCrossConnectionPeering{
+		ExpressRouteCrossConnectionPeeringProperties: &properties,
+	}
+
+	azureRMLockByName(crossConnectionName, expressRouteCrossConnectionResourceName)
+	defer azureRMUnlockByName(crossConnectionName, expressRouteCrossConnectionResourceName)
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, crossConnectionName, peeringType, parameters)
+	if err != nil {
+		return fmt.Errorf("Error creating/updating Express Route Cross Connection Peering %q (Cross Connection %q / Resource Group %q): %+v", peeringType, crossConnectionName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for Express Route Cross Connection Peering %q (Cross Connection %q / Resource Group %q): %+v", peeringType, crossConnectionName, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, crossConnectionName, peeringType)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Express Route Cross Connection Peering %q (Cross Connection %q / Resource Group %q): %+v", peeringType, crossConnectionName, resourceGroup, err)
+	}
+
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read ID of Express Route Cross Connection Peering %q (Cross Connection %q / Resource Group %q)", peeringType, crossConnectionName, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmExpressRouteCrossConnectionPeeringRead(d, meta)
+}
+
+func resourceArmExpressRouteCrossConnectionPeeringRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).expressRouteCrossConnectionPeeringsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	crossConnectionName := id.Path["expressRouteCrossConnections"]
+	peeringType := id.Path["peerings"]
+
+	resp, err := client.Get(ctx, resourceGroup, crossConnectionName, peeringType)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Express Route Cross Connection Peering %q was not found (Cross Connection %q / Resource Group %q) - removing from state", peeringType, crossConnectionName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error making Read request on Express Route Cross Connection Peering %q (Cross Connection %q / Resource Group %q): %+v", peeringType, crossConnectionName, resourceGroup, err)
+	}
+
+	d.Set("peering_type", peeringType)
+	d.Set("express_route_cross_connection_name", crossConnectionName)
+	d.Set("resource_group_name", resourceGroup)
+
+	if props := resp.ExpressRouteCrossConnectionPeeringProperties; props != nil {
+		d.Set("enabled", props.State == network.ExpressRoutePeeringStateEnabled)
+		d.Set("azure_asn", props.AzureASN)
+		d.Set("peer_asn", props.PeerASN)
+		d.Set("primary_azure_port", props.PrimaryAzurePort)
+		d.Set("secondary_azure_port", props.SecondaryAzurePort)
+		d.Set("primary_peer_address_prefix", props.PrimaryPeerAddressPrefix)
+		d.Set("secondary_peer_address_prefix", props.SecondaryPeerAddressPrefix)
+		d.Set("vlan_id", props.VlanID)
+
+		config := flattenExpressRouteCircuitPeeringMicrosoftConfig(props.MicrosoftPeeringConfig)
+		if err := d.Set("microsoft_peering_config", config); err != nil {
+			return fmt.Errorf("Error setting `microsoft_peering_config`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceArmExpressRouteCrossConnectionPeeringDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).expressRouteCrossConnectionPeeringsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	crossConnectionName := id.Path["expressRouteCrossConnections"]
+	peeringType := id.Path["peerings"]
+
+	azureRMLockByName(crossConnectionName, expressRouteCrossConnectionResourceName)
+	defer azureRMUnlockByName(crossConnectionName, expressRouteCrossConnectionResourceName)
+
+	future, err := client.Delete(ctx, resourceGroup, crossConnectionName, peeringType)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+		return fmt.Errorf("Error issuing delete request for Express Route Cross Connection Peering %q (Cross Connection %q / Resource Group %q): %+v", peeringType, crossConnectionName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+		return fmt.Errorf("Error waiting for Express Route Cross Connection Peering %q (Cross Connection %q / Resource Group %q) to be deleted: %+v", peeringType, crossConnectionName, resourceGroup, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestAccAzureRMExpressRouteCrossConnectionPeering_azurePrivatePeering(t *testing.T) {
+	name, resourceGroup := testAccAzureRMExpressRouteCrossConnection_environment(t)
+	resourceName := "azurerm_express_route_cross_connection_peering.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMExpressRouteCrossConnectionPeeringDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMExpressRouteCrossConnectionPeering_privatePeering(name, resourceGroup, true),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMExpressRouteCrossConnectionPeeringExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "peering_type", "AzurePrivatePeering"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+				),
+			},
+			{
+				Config: testAccAzureRMExpressRouteCrossConnectionPeering_privatePeering(name, resourceGroup, false),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMExpressRouteCrossConnectionPeeringExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"shared_key"},
+			},
+		},
+	})
+}
+
+func testCheckAzureRMExpressRouteCrossConnectionPeeringExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		peeringType := rs.Primary.Attributes["peering_type"]
+		crossConnectionName := rs.Primary.Attributes["express_route_cross_connection_name"]
+		resourceGroup, hasResourceGroup := rs.Primary.Attributes["resource_group_name"]
+		if !hasResourceGroup {
+			return fmt.Errorf("Bad: no resource group found in state for Express Route Cross Connection Peering: %s", peeringType)
+		}
+
+		client := testAccProvider.Meta().(*ArmClient).expressRouteCrossConnectionPeeringsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, crossConnectionName, peeringType)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: Express Route Cross Connection Peering %q (Cross Connection %q / Resource Group %q) does not exist", peeringType, crossConnectionName, resourceGroup)
+			}
+
+			return fmt.Errorf("Bad: Get on expressRouteCrossConnectionPeeringsClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMExpressRouteCrossConnectionPeeringDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).expressRouteCrossConnectionPeeringsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_express_route_cross_connection_peering" {
+			continue
+		}
+
+		peeringType := rs.Primary.Attributes["peering_type"]
+		crossConnectionName := rs.Primary.Attributes["express_route_cross_connection_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		resp, err := client.Get(ctx, resourceGroup, crossConnectionName, peeringType)
+
+		if err != nil {
+			return nil
+		}
+
+		if resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("Express Route Cross Connection Peering still exists:\n%#v", resp)
+		}
+	}
+
+	return nil
+}
+
+func testAccAzureRMExpressRouteCrossConnectionPeering_privatePeering(name, resourceGroup string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "azurerm_express_route_cross_connection_peering" "test" {
+  peering_type                        = "AzurePrivatePeering"
+  express_route_cross_connection_name = "%s"
+  resource_group_name                 = "%s"
+  shared_key                          = "ABCdefGHIJklm@nOPqrsTU!!"
+  peer_asn                            = 100
+  primary_peer_address_prefix         = "192.168.1.0/30"
+  secondary_peer_address_prefix       = "192.168.2.0/30"
+  vlan_id                             = 100
+  enabled                             = %t
+}
+`, name, resourceGroup, enabled)
+}
@@ -0,0 +1,104 @@
+package azurerm
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// NOTE: Express Route Cross Connections only exist within a Connectivity Provider's subscription
+// once a customer has provisioned an Express Route Circuit against it - as such these tests
+// require an existing Cross Connection to be specified.
+func testAccAzureRMExpressRouteCrossConnection_environment(t *testing.T) (string, string) {
+	nameEnvVariable := "ARM_TEST_EXPRESS_ROUTE_CROSS_CONNECTION_NAME"
+	name := os.Getenv(nameEnvVariable)
+	if name == "" {
+		t.Skipf("Skipping as %q is not specified", nameEnvVariable)
+	}
+
+	resourceGroupEnvVariable := "ARM_TEST_EXPRESS_ROUTE_CROSS_CONNECTION_RESOURCE_GROUP"
+	resourceGroup := os.Getenv(resourceGroupEnvVariable)
+	if resourceGroup == "" {
+		t.Skipf("Skipping as %q is not specified", resourceGroupEnvVariable)
+	}
+
+	return name, resourceGroup
+}
+
+func TestAccAzureRMExpressRouteCrossConnection_basic(t *testing.T) {
+	name, resourceGroup := testAccAzureRMExpressRouteCrossConnection_environment(t)
+	resourceName := "azurerm_express_route_cross_connection.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMExpressRouteCrossConnection_basic(name, resourceGroup, "Provisioning", "Provisioning in progress"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMExpressRouteCrossConnectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "service_provider_provisioning_state", "Provisioning"),
+					resource.TestCheckResourceAttr(resourceName, "service_provider_notes", "Provisioning in progress"),
+					resource.TestCheckResourceAttrSet(resourceName, "express_route_circuit_id"),
+				),
+			},
+			{
+				Config: testAccAzureRMExpressRouteCrossConnection_basic(name, resourceGroup, "Provisioned", "Provisioned"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMExpressRouteCrossConnectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "service_provider_provisioning_state", "Provisioned"),
+					resource.TestCheckResourceAttr(resourceName, "service_provider_notes", "Provisioned"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckAzureRMExpressRouteCrossConnectionExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		crossConnectionName := rs.Primary.Attributes["name"]
+		resourceGroup, hasResourceGroup := rs.Primary.Attributes["resource_group_name"]
+		if !hasResourceGroup {
+			return fmt.Errorf("Bad: no resource group found in state for Express Route Cross Connection: %s", crossConnectionName)
+		}
+
+		client := testAccProvider.Meta().(*ArmClient).expressRouteCrossConnectionsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, crossConnectionName)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: Express Route Cross Connection %q (Resource Group %q) does not exist", crossConnectionName, resourceGroup)
+			}
+
+			return fmt.Errorf("Bad: Get on expressRouteCrossConnectionsClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testAccAzureRMExpressRouteCrossConnection_basic(name, resourceGroup, state, notes string) string {
+	return fmt.Sprintf(`
+resource "azurerm_express_route_cross_connection" "test" {
+  name                                = "%s"
+  resource_group_name                 = "%s"
+  service_provider_provisioning_state = "%s"
+  service_provider_notes              = "%s"
+}
+`, name, resourceGroup, state, notes)
+}
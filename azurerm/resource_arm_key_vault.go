@@ -39,6 +39,27 @@ func resourceArmKeyVault() *schema.Resource {
 		MigrateState:  resourceAzureRMKeyVaultMigrateState,
 		SchemaVersion: 1,
 
+		CustomizeDiff: func(d *schema.ResourceDiff, v interface{}) error {
+			enableSoftDelete := d.Get("enable_soft_delete").(bool)
+			enablePurgeProtection := d.Get("enable_purge_protection").(bool)
+
+			// once enabled, Soft Delete and Purge Protection can't be disabled - and the API doesn't accept `false`
+			if d.Id() != "" {
+				if old, _ := d.GetChange("enable_soft_delete"); old.(bool) && !enableSoftDelete {
+					return fmt.Errorf("`enable_soft_delete` cannot be disabled once enabled")
+				}
+				if old, _ := d.GetChange("enable_purge_protection"); old.(bool) && !enablePurgeProtection {
+					return fmt.Errorf("`enable_purge_protection` cannot be disabled once enabled")
+				}
+			}
+
+			if enablePurgeProtection && !enableSoftDelete {
+				return fmt.Errorf("`enable_soft_delete` must be enabled to enable `enable_purge_protection`")
+			}
+
+			return nil
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -123,6 +144,16 @@ func resourceArmKeyVault() *schema.Resource {
 				Optional: true,
 			},
 
+			"enable_soft_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"enable_purge_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
 			"network_acls": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -178,6 +209,8 @@ func resourceArmKeyVaultCreateUpdate(d *schema.ResourceData, meta interface{}) e
 	enabledForDeployment := d.Get("enabled_for_deployment").(bool)
 	enabledForDiskEncryption := d.Get("enabled_for_disk_encryption").(bool)
 	enabledForTemplateDeployment := d.Get("enabled_for_template_deployment").(bool)
+	enableSoftDelete := d.Get("enable_soft_delete").(bool)
+	enablePurgeProtection := d.Get("enable_purge_protection").(bool)
 	tags := d.Get("tags").(map[string]interface{})
 
 	networkAclsRaw := d.Get("network_acls").([]interface{})
@@ -203,6 +236,13 @@ func resourceArmKeyVaultCreateUpdate(d *schema.ResourceData, meta interface{}) e
 		Tags: expandTags(tags),
 	}
 
+	if enableSoftDelete {
+		parameters.Properties.EnableSoftDelete = utils.Bool(true)
+	}
+	if enablePurgeProtection {
+		parameters.Properties.EnablePurgeProtection = utils.Bool(true)
+	}
+
 	// Locking this resource so we don't make modifications to it at the same time if there is a
 	// key vault access policy trying to update it as well
 	azureRMLockByName(name, keyVaultResourceName)
@@ -295,6 +335,8 @@ func resourceArmKeyVaultRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("enabled_for_deployment", props.EnabledForDeployment)
 		d.Set("enabled_for_disk_encryption", props.EnabledForDiskEncryption)
 		d.Set("enabled_for_template_deployment", props.EnabledForTemplateDeployment)
+		d.Set("enable_soft_delete", props.EnableSoftDelete)
+		d.Set("enable_purge_protection", props.EnablePurgeProtection)
 		d.Set("vault_uri", props.VaultURI)
 
 		if err := d.Set("sku", flattenKeyVaultSku(props.Sku)); err != nil {
@@ -2,6 +2,7 @@ package azurerm
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -95,6 +96,37 @@ func TestAccAzureRMKeyVault_basic(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMKeyVault_purgeProtection(t *testing.T) {
+	resourceName := "azurerm_key_vault.test"
+	ri := acctest.RandInt()
+	config := testAccAzureRMKeyVault_purgeProtection(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKeyVaultDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKeyVaultExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "enable_soft_delete", "true"),
+					resource.TestCheckResourceAttr(resourceName, "enable_purge_protection", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      testAccAzureRMKeyVault_purgeProtectionDisabled(ri, testLocation()),
+				ExpectError: regexp.MustCompile("`enable_purge_protection` cannot be disabled once enabled"),
+			},
+		},
+	})
+}
+
 func TestAccAzureRMKeyVault_networkAcls(t *testing.T) {
 	resourceName := "azurerm_key_vault.test"
 	ri := acctest.RandInt()
@@ -360,6 +392,54 @@ resource "azurerm_key_vault" "test" {
 `, rInt, location, rInt)
 }
 
+func testAccAzureRMKeyVault_purgeProtection(rInt int, location string) string {
+	return fmt.Sprintf(`
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_key_vault" "test" {
+  name                    = "vault%d"
+  location                = "${azurerm_resource_group.test.location}"
+  resource_group_name     = "${azurerm_resource_group.test.name}"
+  tenant_id               = "${data.azurerm_client_config.current.tenant_id}"
+  enable_soft_delete      = true
+  enable_purge_protection = true
+
+  sku {
+    name = "premium"
+  }
+}
+`, rInt, location, rInt)
+}
+
+func testAccAzureRMKeyVault_purgeProtectionDisabled(rInt int, location string) string {
+	return fmt.Sprintf(`
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_key_vault" "test" {
+  name                    = "vault%d"
+  location                = "${azurerm_resource_group.test.location}"
+  resource_group_name     = "${azurerm_resource_group.test.name}"
+  tenant_id               = "${data.azurerm_client_config.current.tenant_id}"
+  enable_soft_delete      = true
+  enable_purge_protection = false
+
+  sku {
+    name = "premium"
+  }
+}
+`, rInt, location, rInt)
+}
+
 func testAccAzureRMKeyVault_networkAclsTemplate(rInt int, location string) string {
 	return fmt.Sprintf(`
 data "azurerm_client_config" "current" {}
@@ -26,7 +26,14 @@ func resourceArmKubernetesCluster() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
-		CustomizeDiff: func(diff *schema.ResourceDiff, v interface{}) error {
+		CustomizeDiff: func(diff *schema.ResourceDiff, meta interface{}) error {
+			// the Upgrade Profile is only retrieved when the version of an existing cluster is changed
+			if diff.Id() != "" && diff.HasChange("kubernetes_version") {
+				if err := validateKubernetesClusterVersionUpgrade(diff, meta); err != nil {
+					return err
+				}
+			}
+
 			if v, exists := diff.GetOk("network_profile"); exists {
 				rawProfiles := v.([]interface{})
 				if len(rawProfiles) == 0 {
@@ -172,9 +179,9 @@ func resourceArmKubernetesCluster() *schema.Resource {
 							ForceNew: true,
 						},
 
+						// the secret can be rotated in-place, the Client ID can't be changed
 						"client_secret": {
 							Type:      schema.TypeString,
-							ForceNew:  true,
 							Required:  true,
 							Sensitive: true,
 						},
@@ -604,7 +611,7 @@ func resourceArmKubernetesClusterRead(d *schema.ResourceData, meta interface{})
 			return fmt.Errorf("Error setting `role_based_access_control`: %+v", err)
 		}
 
-		servicePrincipal := flattenAzureRmKubernetesClusterServicePrincipalProfile(props.ServicePrincipalProfile)
+		servicePrincipal := flattenAzureRmKubernetesClusterServicePrincipalProfile(props.ServicePrincipalProfile, d)
 		if err := d.Set("service_principal", servicePrincipal); err != nil {
 			return fmt.Errorf("Error setting `service_principal`: %+v", err)
 		}
@@ -1082,7 +1089,7 @@ func expandAzureRmKubernetesClusterServicePrincipal(d *schema.ResourceData) *con
 	return &principal
 }
 
-func flattenAzureRmKubernetesClusterServicePrincipalProfile(profile *containerservice.ManagedClusterServicePrincipalProfile) *schema.Set {
+func flattenAzureRmKubernetesClusterServicePrincipalProfile(profile *containerservice.ManagedClusterServicePrincipalProfile, d *schema.ResourceData) *schema.Set {
 	if profile == nil {
 		return nil
 	}
@@ -1098,6 +1105,15 @@ func flattenAzureRmKubernetesClusterServicePrincipalProfile(profile *containerse
 	}
 	if secret := profile.Secret; secret != nil {
 		values["client_secret"] = *secret
+	} else if existing, ok := d.GetOk("service_principal"); ok {
+		// since the Secret isn't always returned we're pulling this out of the existing state (which won't work for Imports)
+		existingProfiles := existing.(*schema.Set).List()
+		if len(existingProfiles) > 0 {
+			existingProfile := existingProfiles[0].(map[string]interface{})
+			if v, ok := existingProfile["client_secret"]; ok {
+				values["client_secret"] = v.(string)
+			}
+		}
 	}
 
 	servicePrincipalProfiles.Add(values)
@@ -1116,6 +1132,52 @@ func resourceKubernetesClusterServicePrincipalProfileHash(v interface{}) int {
 	return hashcode.String(buf.String())
 }
 
+// validateKubernetesClusterVersionUpgrade ensures that when the `kubernetes_version` of an existing cluster
+// is changed, the new version is one which the cluster can be upgraded to in-place. This retrieves the
+// cluster's Upgrade Profile, so it should only be called when `kubernetes_version` has changed.
+func validateKubernetesClusterVersionUpgrade(diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("kubernetes_version") {
+		return nil
+	}
+
+	old, new := diff.GetChange("kubernetes_version")
+	oldVersion := old.(string)
+	newVersion := new.(string)
+	if oldVersion == "" || newVersion == "" || oldVersion == newVersion {
+		return nil
+	}
+
+	client := meta.(*ArmClient).kubernetesClustersClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(diff.Id())
+	if err != nil {
+		return err
+	}
+	resGroup := id.ResourceGroup
+	name := id.Path["managedClusters"]
+
+	profile, err := client.GetUpgradeProfile(ctx, resGroup, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Upgrade Profile for Managed Kubernetes Cluster %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	upgrades := make([]string, 0)
+	if props := profile.ManagedClusterUpgradeProfileProperties; props != nil {
+		if controlPlane := props.ControlPlaneProfile; controlPlane != nil && controlPlane.Upgrades != nil {
+			upgrades = *controlPlane.Upgrades
+		}
+	}
+
+	for _, upgrade := range upgrades {
+		if upgrade == newVersion {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Managed Kubernetes Cluster %q (Resource Group %q) cannot be upgraded from %q to %q - available upgrades are: %s", name, resGroup, oldVersion, newVersion, strings.Join(upgrades, ", "))
+}
+
 func validateKubernetesClusterAgentPoolName() schema.SchemaValidateFunc {
 	return validation.StringMatch(
 		regexp.MustCompile("^[a-z]{1}[a-z0-9]{0,11}$"),
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -262,6 +263,7 @@ func TestAccAzureRMKubernetesCluster_upgradeConfig(t *testing.T) {
 	location := testLocation()
 	clientId := os.Getenv("ARM_CLIENT_ID")
 	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	var cluster testAccAzureRMKubernetesClusterInstance
 
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -272,6 +274,7 @@ func TestAccAzureRMKubernetesCluster_upgradeConfig(t *testing.T) {
 				Config: testAccAzureRMKubernetesCluster_upgrade(ri, location, clientId, clientSecret, "1.10.9"),
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMKubernetesClusterExists(resourceName),
+					testCheckAzureRMKubernetesClusterCaptureInstance(resourceName, &cluster),
 					resource.TestCheckResourceAttr(resourceName, "kubernetes_version", "1.10.9"),
 				),
 			},
@@ -279,6 +282,7 @@ func TestAccAzureRMKubernetesCluster_upgradeConfig(t *testing.T) {
 				Config: testAccAzureRMKubernetesCluster_upgrade(ri, location, clientId, clientSecret, "1.11.5"),
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMKubernetesClusterExists(resourceName),
+					testCheckAzureRMKubernetesClusterNotRecreated(resourceName, &cluster),
 					resource.TestCheckResourceAttr(resourceName, "kubernetes_version", "1.11.5"),
 				),
 			},
@@ -286,6 +290,66 @@ func TestAccAzureRMKubernetesCluster_upgradeConfig(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMKubernetesCluster_upgradeConfigInvalidVersion(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+	clientId := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesCluster_upgrade(ri, location, clientId, clientSecret, "1.11.5"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "kubernetes_version", "1.11.5"),
+				),
+			},
+			{
+				// downgrades aren't supported, so this should be caught at plan time rather than replacing the cluster
+				Config:      testAccAzureRMKubernetesCluster_upgrade(ri, location, clientId, clientSecret, "1.10.9"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("cannot be upgraded from \"1.11.5\" to \"1.10.9\""),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMKubernetesCluster_servicePrincipalRotation(t *testing.T) {
+	resourceName := "azurerm_kubernetes_cluster.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+	firstSecret := acctest.RandStringFromCharSet(32, acctest.CharSetAlphaNum)
+	secondSecret := acctest.RandStringFromCharSet(32, acctest.CharSetAlphaNum)
+	var cluster testAccAzureRMKubernetesClusterInstance
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMKubernetesClusterDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMKubernetesCluster_servicePrincipalRotation(ri, location, firstSecret, secondSecret, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					testCheckAzureRMKubernetesClusterCaptureInstance(resourceName, &cluster),
+				),
+			},
+			{
+				Config: testAccAzureRMKubernetesCluster_servicePrincipalRotation(ri, location, firstSecret, secondSecret, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMKubernetesClusterExists(resourceName),
+					testCheckAzureRMKubernetesClusterNotRecreated(resourceName, &cluster),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAzureRMKubernetesCluster_internalNetwork(t *testing.T) {
 	resourceName := "azurerm_kubernetes_cluster.test"
 	ri := acctest.RandInt()
@@ -483,6 +547,45 @@ func testCheckAzureRMKubernetesClusterExists(name string) resource.TestCheckFunc
 	}
 }
 
+// testAccAzureRMKubernetesClusterInstance identifies an instance of a Managed Kubernetes Cluster - since a cluster which
+// is recreated with the same name has the same ID, the FQDN (which contains a random suffix) is also compared
+type testAccAzureRMKubernetesClusterInstance struct {
+	id   string
+	fqdn string
+}
+
+func testCheckAzureRMKubernetesClusterCaptureInstance(name string, instance *testAccAzureRMKubernetesClusterInstance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		instance.id = rs.Primary.ID
+		instance.fqdn = rs.Primary.Attributes["fqdn"]
+		return nil
+	}
+}
+
+func testCheckAzureRMKubernetesClusterNotRecreated(name string, instance *testAccAzureRMKubernetesClusterInstance) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		if rs.Primary.ID != instance.id {
+			return fmt.Errorf("Bad: expected the ID of Managed Kubernetes Cluster %q to be %q but got %q", name, instance.id, rs.Primary.ID)
+		}
+
+		if fqdn := rs.Primary.Attributes["fqdn"]; fqdn != instance.fqdn {
+			return fmt.Errorf("Bad: Managed Kubernetes Cluster %q was recreated (FQDN changed from %q to %q)", name, instance.fqdn, fqdn)
+		}
+
+		return nil
+	}
+}
+
 func testCheckAzureRMKubernetesClusterDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*ArmClient).kubernetesClustersClient
 
@@ -881,6 +984,53 @@ resource "azurerm_kubernetes_cluster" "test" {
 `, rInt, location, rInt, rInt, version, rInt, clientId, clientSecret)
 }
 
+func testAccAzureRMKubernetesCluster_servicePrincipalRotation(rInt int, location, firstSecret, secondSecret, activeSecret string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_azuread_application" "test" {
+  name = "acctestaks%d"
+}
+
+resource "azurerm_azuread_service_principal" "test" {
+  application_id = "${azurerm_azuread_application.test.application_id}"
+}
+
+resource "azurerm_azuread_service_principal_password" "first" {
+  service_principal_id = "${azurerm_azuread_service_principal.test.id}"
+  value                = "%s"
+  end_date             = "2099-01-01T01:02:03Z"
+}
+
+resource "azurerm_azuread_service_principal_password" "second" {
+  service_principal_id = "${azurerm_azuread_service_principal.test.id}"
+  value                = "%s"
+  end_date             = "2099-01-01T01:02:03Z"
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                = "acctestaks%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  dns_prefix          = "acctestaks%d"
+
+  agent_pool_profile {
+    name    = "default"
+    count   = "1"
+    vm_size = "Standard_DS2_v2"
+  }
+
+  service_principal {
+    client_id     = "${azurerm_azuread_application.test.application_id}"
+    client_secret = "${azurerm_azuread_service_principal_password.%s.value}"
+  }
+}
+`, rInt, location, rInt, firstSecret, secondSecret, rInt, rInt, activeSecret)
+}
+
 func testAccAzureRMKubernetesCluster_advancedNetworking(rInt int, clientId string, clientSecret string, location string, networkPlugin string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
@@ -0,0 +1,323 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmLoadBalancerOutboundRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmLoadBalancerOutboundRuleCreateUpdate,
+		Read:   resourceArmLoadBalancerOutboundRuleRead,
+		Update: resourceArmLoadBalancerOutboundRuleCreateUpdate,
+		Delete: resourceArmLoadBalancerOutboundRuleDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: loadBalancerSubResourceStateImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"loadbalancer_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"frontend_ip_configuration": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"backend_address_pool_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"allocated_outbound_ports": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1024,
+				ValidateFunc: validation.IntBetween(0, 64000),
+			},
+		},
+	}
+}
+
+func resourceArmLoadBalancerOutboundRuleCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).loadBalancerClient
+	ctx := meta.(*ArmClient).StopContext
+
+	loadBalancerID := d.Get("loadbalancer_id").(string)
+	armMutexKV.Lock(loadBalancerID)
+	defer armMutexKV.Unlock(loadBalancerID)
+
+	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
+	if err != nil {
+		return fmt.Errorf("Error Getting Load Balancer By ID: %+v", err)
+	}
+	if !exists {
+		d.SetId("")
+		log.Printf("[INFO] Load Balancer %q not found. Removing from state", d.Get("name").(string))
+		return nil
+	}
+
+	newOutboundRule, err := expandAzureRmLoadBalancerOutboundRule(d, loadBalancer)
+	if err != nil {
+		return fmt.Errorf("Error Expanding Load Balancer Outbound Rule: %+v", err)
+	}
+
+	outboundRules := make([]network.OutboundNatRule, 0)
+	if existing := loadBalancer.LoadBalancerPropertiesFormat.OutboundNatRules; existing != nil {
+		outboundRules = *existing
+	}
+
+	existingRule, existingRuleIndex, exists := findLoadBalancerOutboundNatRuleByName(loadBalancer, d.Get("name").(string))
+	if exists {
+		if d.Get("name").(string) == *existingRule.Name {
+			// this rule is being updated/reapplied remove old copy from the slice
+			outboundRules = append(outboundRules[:existingRuleIndex], outboundRules[existingRuleIndex+1:]...)
+		}
+	}
+
+	outboundRules = append(outboundRules, *newOutboundRule)
+	loadBalancer.LoadBalancerPropertiesFormat.OutboundNatRules = &outboundRules
+
+	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(loadBalancerID)
+	if err != nil {
+		return fmt.Errorf("Error Getting Load Balancer Name and Group:: %+v", err)
+	}
+
+	future, err := client.CreateOrUpdate(ctx, resGroup, loadBalancerName, *loadBalancer)
+	if err != nil {
+		return fmt.Errorf("Error Creating/Updating Load Balancer %q (Resource Group %q): %+v", loadBalancerName, resGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for completion of Load Balancer %q (Resource Group %q): %+v", loadBalancerName, resGroup, err)
+	}
+
+	read, err := client.Get(ctx, resGroup, loadBalancerName, "")
+	if err != nil {
+		return fmt.Errorf("Error retrieving Load Balancer %q (Resource Group %q): %+v", loadBalancerName, resGroup, err)
+	}
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read Load Balancer %q (Resource Group %q) ID", loadBalancerName, resGroup)
+	}
+
+	var outboundRuleId string
+	if props := read.LoadBalancerPropertiesFormat; props != nil && props.OutboundNatRules != nil {
+		for _, rule := range *props.OutboundNatRules {
+			if rule.Name != nil && *rule.Name == d.Get("name").(string) && rule.ID != nil {
+				outboundRuleId = *rule.ID
+			}
+		}
+	}
+
+	if outboundRuleId == "" {
+		return fmt.Errorf("Cannot find created Load Balancer Outbound Rule ID %q", outboundRuleId)
+	}
+
+	d.SetId(outboundRuleId)
+
+	log.Printf("[DEBUG] Waiting for Load Balancer (%s) to become available", loadBalancerName)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Accepted", "Updating"},
+		Target:  []string{"Succeeded"},
+		Refresh: loadbalancerStateRefreshFunc(ctx, client, resGroup, loadBalancerName),
+		Timeout: 10 * time.Minute,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for Load Balancer (%q Resource Group %q) to become available: %+v", loadBalancerName, resGroup, err)
+	}
+
+	return resourceArmLoadBalancerOutboundRuleRead(d, meta)
+}
+
+func resourceArmLoadBalancerOutboundRuleRead(d *schema.ResourceData, meta interface{}) error {
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	name := id.Path["outboundNatRules"]
+
+	loadBalancer, exists, err := retrieveLoadBalancerById(d.Get("loadbalancer_id").(string), meta)
+	if err != nil {
+		return fmt.Errorf("Error Getting Load Balancer By ID: %+v", err)
+	}
+	if !exists {
+		d.SetId("")
+		log.Printf("[INFO] Load Balancer %q not found. Removing from state", name)
+		return nil
+	}
+
+	config, _, exists := findLoadBalancerOutboundNatRuleByName(loadBalancer, name)
+	if !exists {
+		d.SetId("")
+		log.Printf("[INFO] Load Balancer Outbound Rule %q not found. Removing from state", name)
+		return nil
+	}
+
+	d.Set("name", config.Name)
+	d.Set("resource_group_name", id.ResourceGroup)
+
+	if properties := config.OutboundNatRulePropertiesFormat; properties != nil {
+		if properties.AllocatedOutboundPorts != nil {
+			d.Set("allocated_outbound_ports", int(*properties.AllocatedOutboundPorts))
+		}
+
+		if properties.BackendAddressPool != nil {
+			d.Set("backend_address_pool_id", properties.BackendAddressPool.ID)
+		}
+
+		frontendIpConfigurations, err := flattenAzureRmLoadBalancerOutboundRuleFrontendIpConfigurations(properties.FrontendIPConfigurations)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("frontend_ip_configuration", frontendIpConfigurations); err != nil {
+			return fmt.Errorf("Error setting `frontend_ip_configuration`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+func resourceArmLoadBalancerOutboundRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).loadBalancerClient
+	ctx := meta.(*ArmClient).StopContext
+
+	loadBalancerID := d.Get("loadbalancer_id").(string)
+	armMutexKV.Lock(loadBalancerID)
+	defer armMutexKV.Unlock(loadBalancerID)
+
+	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
+	if err != nil {
+		return fmt.Errorf("Error Getting Load Balancer By ID: %+v", err)
+	}
+	if !exists {
+		d.SetId("")
+		return nil
+	}
+
+	_, index, exists := findLoadBalancerOutboundNatRuleByName(loadBalancer, d.Get("name").(string))
+	if !exists {
+		return nil
+	}
+
+	oldOutboundRules := *loadBalancer.LoadBalancerPropertiesFormat.OutboundNatRules
+	newOutboundRules := append(oldOutboundRules[:index], oldOutboundRules[index+1:]...)
+	loadBalancer.LoadBalancerPropertiesFormat.OutboundNatRules = &newOutboundRules
+
+	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(loadBalancerID)
+	if err != nil {
+		return fmt.Errorf("Error Getting Load Balancer Name and Group:: %+v", err)
+	}
+
+	future, err := client.CreateOrUpdate(ctx, resGroup, loadBalancerName, *loadBalancer)
+	if err != nil {
+		return fmt.Errorf("Error Creating/Updating Load Balancer %q (Resource Group %q): %+v", loadBalancerName, resGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for completion of Load Balancer %q (Resource Group %q): %+v", loadBalancerName, resGroup, err)
+	}
+
+	read, err := client.Get(ctx, resGroup, loadBalancerName, "")
+	if err != nil {
+		return fmt.Errorf("Error Getting LoadBalancer: %+v", err)
+	}
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read ID of Load Balancer %q (resource group %s)", loadBalancerName, resGroup)
+	}
+
+	return nil
+}
+
+func expandAzureRmLoadBalancerOutboundRule(d *schema.ResourceData, lb *network.LoadBalancer) (*network.OutboundNatRule, error) {
+	properties := network.OutboundNatRulePropertiesFormat{
+		AllocatedOutboundPorts: utils.Int32(int32(d.Get("allocated_outbound_ports").(int))),
+		BackendAddressPool: &network.SubResource{
+			ID: utils.String(d.Get("backend_address_pool_id").(string)),
+		},
+	}
+
+	frontendIpConfigurations := make([]network.SubResource, 0)
+	for _, raw := range d.Get("frontend_ip_configuration").([]interface{}) {
+		v := raw.(map[string]interface{})
+		name := v["name"].(string)
+
+		config, exists := findLoadBalancerFrontEndIpConfigurationByName(lb, name)
+		if !exists {
+			return nil, fmt.Errorf("[ERROR] Cannot find FrontEnd IP Configuration with the name %s", name)
+		}
+
+		frontendIpConfigurations = append(frontendIpConfigurations, network.SubResource{
+			ID: config.ID,
+		})
+	}
+	properties.FrontendIPConfigurations = &frontendIpConfigurations
+
+	return &network.OutboundNatRule{
+		Name:                            utils.String(d.Get("name").(string)),
+		OutboundNatRulePropertiesFormat: &properties,
+	}, nil
+}
+
+func flattenAzureRmLoadBalancerOutboundRuleFrontendIpConfigurations(input *[]network.SubResource) ([]interface{}, error) {
+	results := make([]interface{}, 0)
+	if input == nil {
+		return results, nil
+	}
+
+	for _, config := range *input {
+		if config.ID == nil {
+			continue
+		}
+
+		id, err := parseAzureResourceID(*config.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, map[string]interface{}{
+			"id":   *config.ID,
+			"name": id.Path["frontendIPConfigurations"],
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,184 @@
+package azurerm
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAzureRMLoadBalancerOutboundRule_basic(t *testing.T) {
+	var lb network.LoadBalancer
+	ri := acctest.RandInt()
+	outboundRuleName := fmt.Sprintf("OutboundRule-%d", ri)
+
+	subscriptionID := os.Getenv("ARM_SUBSCRIPTION_ID")
+	outboundRuleId := fmt.Sprintf(
+		"/subscriptions/%s/resourceGroups/acctestRG-%d/providers/Microsoft.Network/loadBalancers/arm-test-loadbalancer-%d/outboundNatRules/%s",
+		subscriptionID, ri, ri, outboundRuleName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLoadBalancerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMLoadBalancerOutboundRule_basic(ri, outboundRuleName, 1024, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLoadBalancerExists("azurerm_lb.test", &lb),
+					testCheckAzureRMLoadBalancerOutboundRuleExists(outboundRuleName, &lb),
+					resource.TestCheckResourceAttr("azurerm_lb_outbound_rule.test", "id", outboundRuleId),
+					resource.TestCheckResourceAttr("azurerm_lb_outbound_rule.test", "allocated_outbound_ports", "1024"),
+					resource.TestCheckResourceAttr("azurerm_lb_outbound_rule.test", "frontend_ip_configuration.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "azurerm_lb_outbound_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMLoadBalancerOutboundRule_update(t *testing.T) {
+	var lb network.LoadBalancer
+	ri := acctest.RandInt()
+	outboundRuleName := fmt.Sprintf("OutboundRule-%d", ri)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLoadBalancerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMLoadBalancerOutboundRule_basic(ri, outboundRuleName, 1024, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLoadBalancerExists("azurerm_lb.test", &lb),
+					testCheckAzureRMLoadBalancerOutboundRuleExists(outboundRuleName, &lb),
+					resource.TestCheckResourceAttr("azurerm_lb_outbound_rule.test", "allocated_outbound_ports", "1024"),
+				),
+			},
+			{
+				Config: testAccAzureRMLoadBalancerOutboundRule_basic(ri, outboundRuleName, 2048, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLoadBalancerExists("azurerm_lb.test", &lb),
+					testCheckAzureRMLoadBalancerOutboundRuleExists(outboundRuleName, &lb),
+					resource.TestCheckResourceAttr("azurerm_lb_outbound_rule.test", "allocated_outbound_ports", "2048"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMLoadBalancerOutboundRule_removal(t *testing.T) {
+	var lb network.LoadBalancer
+	ri := acctest.RandInt()
+	outboundRuleName := fmt.Sprintf("OutboundRule-%d", ri)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMLoadBalancerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMLoadBalancerOutboundRule_basic(ri, outboundRuleName, 1024, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLoadBalancerExists("azurerm_lb.test", &lb),
+					testCheckAzureRMLoadBalancerOutboundRuleExists(outboundRuleName, &lb),
+				),
+			},
+			{
+				Config: testAccAzureRMLoadBalancerOutboundRule_removal(ri, testLocation()),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMLoadBalancerExists("azurerm_lb.test", &lb),
+					testCheckAzureRMLoadBalancerOutboundRuleNotExists(outboundRuleName, &lb),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMLoadBalancerOutboundRuleExists(outboundRuleName string, lb *network.LoadBalancer) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, _, exists := findLoadBalancerOutboundNatRuleByName(lb, outboundRuleName)
+		if !exists {
+			return fmt.Errorf("An Outbound Rule with name %q cannot be found.", outboundRuleName)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMLoadBalancerOutboundRuleNotExists(outboundRuleName string, lb *network.LoadBalancer) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, _, exists := findLoadBalancerOutboundNatRuleByName(lb, outboundRuleName)
+		if exists {
+			return fmt.Errorf("An Outbound Rule with name %q has been found.", outboundRuleName)
+		}
+
+		return nil
+	}
+}
+
+func testAccAzureRMLoadBalancerOutboundRule_template(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_public_ip" "test" {
+  name                         = "test-ip-%d"
+  location                     = "${azurerm_resource_group.test.location}"
+  resource_group_name          = "${azurerm_resource_group.test.name}"
+  public_ip_address_allocation = "static"
+  sku                          = "Standard"
+}
+
+resource "azurerm_lb" "test" {
+  name                = "arm-test-loadbalancer-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  sku                 = "Standard"
+
+  frontend_ip_configuration {
+    name                 = "one-%d"
+    public_ip_address_id = "${azurerm_public_ip.test.id}"
+  }
+}
+
+resource "azurerm_lb_backend_address_pool" "test" {
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  loadbalancer_id     = "${azurerm_lb.test.id}"
+  name                = "be-%d"
+}
+`, rInt, location, rInt, rInt, rInt, rInt)
+}
+
+func testAccAzureRMLoadBalancerOutboundRule_basic(rInt int, outboundRuleName string, allocatedOutboundPorts int, location string) string {
+	template := testAccAzureRMLoadBalancerOutboundRule_template(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_lb_outbound_rule" "test" {
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  loadbalancer_id          = "${azurerm_lb.test.id}"
+  name                     = "%s"
+  backend_address_pool_id  = "${azurerm_lb_backend_address_pool.test.id}"
+  allocated_outbound_ports = %d
+
+  frontend_ip_configuration {
+    name = "one-%d"
+  }
+}
+`, template, outboundRuleName, allocatedOutboundPorts, rInt)
+}
+
+func testAccAzureRMLoadBalancerOutboundRule_removal(rInt int, location string) string {
+	return testAccAzureRMLoadBalancerOutboundRule_template(rInt, location)
+}
@@ -0,0 +1,320 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	az "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+const (
+	// managedDiskSasTokenIDSuffix is appended to the ID of the Managed Disk or Snapshot to form the ID of the SAS Token
+	managedDiskSasTokenIDSuffix = "/sasToken"
+
+	// managedDiskStateAPIVersion is the first API Version of the Compute API which returns the `diskState` of both
+	// Managed Disks and Snapshots - which isn't returned by the API Version in the SDK
+	managedDiskStateAPIVersion = "2019-07-01"
+
+	// managedDiskStateActiveSAS is the `diskState` of a Managed Disk or Snapshot which has an active SAS
+	managedDiskStateActiveSAS = "ActiveSAS"
+)
+
+func resourceArmManagedDiskSasToken() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmManagedDiskSasTokenCreate,
+		Read:   resourceArmManagedDiskSasTokenRead,
+		Delete: resourceArmManagedDiskSasTokenDelete,
+
+		Schema: map[string]*schema.Schema{
+			"managed_disk_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  azure.ValidateResourceID,
+				ConflictsWith: []string{"snapshot_id"},
+			},
+
+			"snapshot_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  azure.ValidateResourceID,
+				ConflictsWith: []string{"managed_disk_id"},
+			},
+
+			"duration_in_seconds": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"sas_url": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceArmManagedDiskSasTokenCreate(d *schema.ResourceData, meta interface{}) error {
+	sourceId := d.Get("managed_disk_id").(string)
+	if v, ok := d.GetOk("snapshot_id"); ok {
+		sourceId = v.(string)
+	}
+	if sourceId == "" {
+		return fmt.Errorf("One of `managed_disk_id` or `snapshot_id` must be specified")
+	}
+
+	duration := d.Get("duration_in_seconds").(int)
+
+	// only a single SAS can be active for a Managed Disk or Snapshot at any one time - and granting access again
+	// would replace the existing SAS, so this is an error rather than silently invalidating it
+	if err := ensureNoActiveSASForManagedDiskOrSnapshot(meta, sourceId); err != nil {
+		return err
+	}
+
+	sasUrl, err := grantAccessToManagedDiskOrSnapshot(meta, sourceId, duration)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(sourceId + managedDiskSasTokenIDSuffix)
+	d.Set("sas_url", sasUrl)
+
+	return resourceArmManagedDiskSasTokenRead(d, meta)
+}
+
+func resourceArmManagedDiskSasTokenRead(d *schema.ResourceData, meta interface{}) error {
+	diskClient := meta.(*ArmClient).diskClient
+	snapshotsClient := meta.(*ArmClient).snapshotsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	// SAS Tokens created prior to the ID containing the suffix used the ID of the Managed Disk or Snapshot
+	sourceId := strings.TrimSuffix(d.Id(), managedDiskSasTokenIDSuffix)
+	d.SetId(sourceId + managedDiskSasTokenIDSuffix)
+
+	id, err := parseAzureResourceID(sourceId)
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+
+	// the SAS can't be retrieved once it's been granted, so all we can check is the source still exists
+	if name, ok := id.Path["snapshots"]; ok {
+		resp, err := snapshotsClient.Get(ctx, resourceGroup, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				log.Printf("[DEBUG] Snapshot %q (Resource Group %q) was not found - removing SAS Token from state", name, resourceGroup)
+				d.SetId("")
+				return nil
+			}
+
+			return fmt.Errorf("Error retrieving Snapshot %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		d.Set("snapshot_id", sourceId)
+		return nil
+	}
+
+	name := id.Path["disks"]
+	resp, err := diskClient.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Managed Disk %q (Resource Group %q) was not found - removing SAS Token from state", name, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Managed Disk %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	d.Set("managed_disk_id", sourceId)
+	return nil
+}
+
+func resourceArmManagedDiskSasTokenDelete(d *schema.ResourceData, meta interface{}) error {
+	// since only a single SAS can be active at any one time, this only revokes the SAS issued by this resource
+	sourceId := strings.TrimSuffix(d.Id(), managedDiskSasTokenIDSuffix)
+	return revokeAccessToManagedDiskOrSnapshot(meta, sourceId)
+}
+
+// grantAccessToManagedDiskOrSnapshot grants time-limited read access to the Managed Disk or Snapshot with the
+// specified ID, returning the SAS URL which can be used to read it.
+func grantAccessToManagedDiskOrSnapshot(meta interface{}, resourceId string, durationInSeconds int) (string, error) {
+	diskClient := meta.(*ArmClient).diskClient
+	snapshotsClient := meta.(*ArmClient).snapshotsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(resourceId)
+	if err != nil {
+		return "", err
+	}
+	resourceGroup := id.ResourceGroup
+
+	input := compute.GrantAccessData{
+		Access:            compute.Read,
+		DurationInSeconds: utils.Int32(int32(durationInSeconds)),
+	}
+
+	var result compute.AccessURI
+	if name, ok := id.Path["snapshots"]; ok {
+		future, err := snapshotsClient.GrantAccess(ctx, resourceGroup, name, input)
+		if err != nil {
+			return "", fmt.Errorf("Error granting access to Snapshot %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, snapshotsClient.Client); err != nil {
+			return "", fmt.Errorf("Error waiting for access to be granted to Snapshot %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		result, err = future.Result(snapshotsClient)
+		if err != nil {
+			return "", fmt.Errorf("Error retrieving SAS for Snapshot %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	} else if name, ok := id.Path["disks"]; ok {
+		future, err := diskClient.GrantAccess(ctx, resourceGroup, name, input)
+		if err != nil {
+			return "", fmt.Errorf("Error granting access to Managed Disk %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, diskClient.Client); err != nil {
+			return "", fmt.Errorf("Error waiting for access to be granted to Managed Disk %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		result, err = future.Result(diskClient)
+		if err != nil {
+			return "", fmt.Errorf("Error retrieving SAS for Managed Disk %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	} else {
+		return "", fmt.Errorf("Error: %q is neither a Managed Disk nor a Snapshot ID", resourceId)
+	}
+
+	if result.AccessSAS == nil {
+		return "", fmt.Errorf("Error: SAS was nil for %q", resourceId)
+	}
+
+	return *result.AccessSAS, nil
+}
+
+// revokeAccessToManagedDiskOrSnapshot revokes any SAS previously granted for the Managed Disk or Snapshot with the specified ID.
+func revokeAccessToManagedDiskOrSnapshot(meta interface{}, resourceId string) error {
+	diskClient := meta.(*ArmClient).diskClient
+	snapshotsClient := meta.(*ArmClient).snapshotsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(resourceId)
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+
+	if name, ok := id.Path["snapshots"]; ok {
+		future, err := snapshotsClient.RevokeAccess(ctx, resourceGroup, name)
+		if err != nil {
+			if response.WasNotFound(future.Response()) {
+				return nil
+			}
+
+			return fmt.Errorf("Error revoking access to Snapshot %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, snapshotsClient.Client); err != nil {
+			return fmt.Errorf("Error waiting for access to be revoked to Snapshot %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		return nil
+	}
+
+	if name, ok := id.Path["disks"]; ok {
+		future, err := diskClient.RevokeAccess(ctx, resourceGroup, name)
+		if err != nil {
+			if response.WasNotFound(future.Response()) {
+				return nil
+			}
+
+			return fmt.Errorf("Error revoking access to Managed Disk %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, diskClient.Client); err != nil {
+			return fmt.Errorf("Error waiting for access to be revoked to Managed Disk %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("Error: %q is neither a Managed Disk nor a Snapshot ID", resourceId)
+}
+
+// ensureNoActiveSASForManagedDiskOrSnapshot returns an error if the Managed Disk or Snapshot with the specified ID has
+// an active SAS, since granting access again would replace it.
+func ensureNoActiveSASForManagedDiskOrSnapshot(meta interface{}, resourceId string) error {
+	state, err := getManagedDiskOrSnapshotDiskState(meta, resourceId)
+	if err != nil {
+		return fmt.Errorf("Error retrieving the Disk State of %q: %+v", resourceId, err)
+	}
+
+	if strings.EqualFold(state, managedDiskStateActiveSAS) {
+		return fmt.Errorf("%q already has an active SAS (for example from an `azurerm_managed_disk_sas_token` resource) - only a single SAS can be active at any one time, so access must be revoked before it can be granted again", resourceId)
+	}
+
+	return nil
+}
+
+type managedDiskOrSnapshotDiskState struct {
+	Properties *struct {
+		DiskState *string `json:"diskState,omitempty"`
+	} `json:"properties,omitempty"`
+}
+
+// getManagedDiskOrSnapshotDiskState retrieves the `diskState` of the Managed Disk or Snapshot with the specified ID,
+// which isn't returned by the API Version used by the SDK - and as such is retrieved using a newer API Version
+func getManagedDiskOrSnapshotDiskState(meta interface{}, resourceId string) (string, error) {
+	client := meta.(*ArmClient).diskClient
+	ctx := meta.(*ArmClient).StopContext
+
+	queryParameters := map[string]interface{}{
+		"api-version": managedDiskStateAPIVersion,
+	}
+
+	req, err := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPath(resourceId),
+		autorest.WithQueryParameters(queryParameters)).Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("Error preparing the request: %+v", err)
+	}
+
+	resp, err := autorest.SendWithSender(client, req, az.DoRetryWithRegistration(client.Client))
+	if err != nil {
+		return "", fmt.Errorf("Error sending the request: %+v", err)
+	}
+
+	var disk managedDiskOrSnapshotDiskState
+	err = autorest.Respond(
+		resp,
+		client.ByInspecting(),
+		az.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&disk),
+		autorest.ByClosing())
+	if err != nil {
+		return "", err
+	}
+
+	if props := disk.Properties; props != nil && props.DiskState != nil {
+		return *props.DiskState, nil
+	}
+
+	return "", nil
+}
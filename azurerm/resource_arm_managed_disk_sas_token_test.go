@@ -0,0 +1,188 @@
+package azurerm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestAccAzureRMManagedDiskSasToken_managedDisk(t *testing.T) {
+	resourceName := "azurerm_managed_disk_sas_token.test"
+	ri := acctest.RandInt()
+	config := testAccAzureRMManagedDiskSasToken_managedDisk(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMManagedDiskSasTokenDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "sas_url"),
+					resource.TestCheckResourceAttrSet(resourceName, "managed_disk_id"),
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile("/disks/acctestmd-[0-9]+/sasToken$")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMManagedDiskSasToken_activeSAS(t *testing.T) {
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMManagedDiskSasTokenDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMManagedDiskSasToken_managedDisk(ri, location),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("azurerm_managed_disk_sas_token.test", "sas_url"),
+				),
+			},
+			{
+				Config:      testAccAzureRMManagedDiskSasToken_activeSAS(ri, location),
+				ExpectError: regexp.MustCompile("already has an active SAS"),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMManagedDiskSasToken_snapshot(t *testing.T) {
+	resourceName := "azurerm_managed_disk_sas_token.test"
+	ri := acctest.RandInt()
+	config := testAccAzureRMManagedDiskSasToken_snapshot(ri, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMManagedDiskSasTokenDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "sas_url"),
+					resource.TestCheckResourceAttrSet(resourceName, "snapshot_id"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMManagedDiskSasTokenDestroy(s *terraform.State) error {
+	diskClient := testAccProvider.Meta().(*ArmClient).diskClient
+	snapshotsClient := testAccProvider.Meta().(*ArmClient).snapshotsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	// a Managed Disk or Snapshot can only be deleted once access has been revoked
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_managed_disk_sas_token" {
+			continue
+		}
+
+		id, err := parseAzureResourceID(strings.TrimSuffix(rs.Primary.ID, managedDiskSasTokenIDSuffix))
+		if err != nil {
+			return err
+		}
+
+		if name, ok := id.Path["snapshots"]; ok {
+			resp, err := snapshotsClient.Get(ctx, id.ResourceGroup, name)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					continue
+				}
+				return err
+			}
+
+			return fmt.Errorf("Snapshot %q (Resource Group %q) still exists", name, id.ResourceGroup)
+		}
+
+		name := id.Path["disks"]
+		resp, err := diskClient.Get(ctx, id.ResourceGroup, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				continue
+			}
+			return err
+		}
+
+		return fmt.Errorf("Managed Disk %q (Resource Group %q) still exists", name, id.ResourceGroup)
+	}
+
+	return nil
+}
+
+func testAccAzureRMManagedDiskSasToken_managedDisk(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_managed_disk" "test" {
+  name                 = "acctestmd-%d"
+  location             = "${azurerm_resource_group.test.location}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "1"
+}
+
+resource "azurerm_managed_disk_sas_token" "test" {
+  managed_disk_id     = "${azurerm_managed_disk.test.id}"
+  duration_in_seconds = 3600
+}
+`, rInt, location, rInt)
+}
+
+func testAccAzureRMManagedDiskSasToken_snapshot(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_managed_disk" "test" {
+  name                 = "acctestmd-%d"
+  location             = "${azurerm_resource_group.test.location}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "1"
+}
+
+resource "azurerm_snapshot" "test" {
+  name                = "acctestss_%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  create_option       = "Copy"
+  source_uri          = "${azurerm_managed_disk.test.id}"
+}
+
+resource "azurerm_managed_disk_sas_token" "test" {
+  snapshot_id         = "${azurerm_snapshot.test.id}"
+  duration_in_seconds = 3600
+}
+`, rInt, location, rInt, rInt)
+}
+
+func testAccAzureRMManagedDiskSasToken_activeSAS(rInt int, location string) string {
+	template := testAccAzureRMManagedDiskSasToken_managedDisk(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_managed_disk_sas_token" "second" {
+  managed_disk_id     = "${azurerm_managed_disk.test.id}"
+  duration_in_seconds = 3600
+}
+`, template)
+}
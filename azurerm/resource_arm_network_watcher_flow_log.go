@@ -0,0 +1,354 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmNetworkWatcherFlowLog() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmNetworkWatcherFlowLogCreateUpdate,
+		Read:   resourceArmNetworkWatcherFlowLogRead,
+		Update: resourceArmNetworkWatcherFlowLogCreateUpdate,
+		Delete: resourceArmNetworkWatcherFlowLogDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"network_watcher_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"network_security_group_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"storage_account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+
+			"retention_policy": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						"days": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 365),
+						},
+					},
+				},
+			},
+
+			"traffic_analytics": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						"workspace_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.UUID,
+						},
+
+						"workspace_region": {
+							Type:             schema.TypeString,
+							Required:         true,
+							StateFunc:        azureRMNormalizeLocation,
+							DiffSuppressFunc: azureRMSuppressLocationDiff,
+						},
+
+						"workspace_resource_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceArmNetworkWatcherFlowLogCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).watcherClient
+	ctx := meta.(*ArmClient).StopContext
+
+	watcherName := d.Get("network_watcher_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	networkSecurityGroupId := d.Get("network_security_group_id").(string)
+	storageAccountId := d.Get("storage_account_id").(string)
+	enabled := d.Get("enabled").(bool)
+
+	parameters := network.FlowLogInformation{
+		TargetResourceID: utils.String(networkSecurityGroupId),
+		FlowLogProperties: &network.FlowLogProperties{
+			StorageID:       utils.String(storageAccountId),
+			Enabled:         utils.Bool(enabled),
+			RetentionPolicy: expandArmNetworkWatcherFlowLogRetentionPolicy(d.Get("retention_policy").([]interface{})),
+		},
+		FlowAnalyticsConfiguration: expandArmNetworkWatcherFlowLogTrafficAnalytics(d.Get("traffic_analytics").([]interface{})),
+	}
+
+	future, err := client.SetFlowLogConfiguration(ctx, resourceGroup, watcherName, parameters)
+	if err != nil {
+		return fmt.Errorf("Error setting Flow Log Configuration for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for Flow Log Configuration for Network Security Group %q (Watcher %q / Resource Group %q) to be set: %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	watcher, err := client.Get(ctx, resourceGroup, watcherName)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Network Watcher %q (Resource Group %q): %+v", watcherName, resourceGroup, err)
+	}
+
+	if watcher.ID == nil {
+		return fmt.Errorf("Cannot read Network Watcher %q (Resource Group %q) ID", watcherName, resourceGroup)
+	}
+
+	d.SetId(fmt.Sprintf("%s|%s", *watcher.ID, networkSecurityGroupId))
+
+	return resourceArmNetworkWatcherFlowLogRead(d, meta)
+}
+
+func resourceArmNetworkWatcherFlowLogRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).watcherClient
+	ctx := meta.(*ArmClient).StopContext
+
+	watcherId, networkSecurityGroupId, err := parseArmNetworkWatcherFlowLogID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := watcherId.ResourceGroup
+	watcherName := watcherId.Path["networkWatchers"]
+
+	parameters := network.FlowLogStatusParameters{
+		TargetResourceID: utils.String(networkSecurityGroupId),
+	}
+
+	future, err := client.GetFlowLogStatus(ctx, resourceGroup, watcherName, parameters)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			log.Printf("[DEBUG] Network Watcher %q (Resource Group %q) was not found - removing Flow Log from state", watcherName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Flow Log Status for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for retrieval of Flow Log Status for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	resp, err := future.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Flow Log Status for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	d.Set("network_watcher_name", watcherName)
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("network_security_group_id", networkSecurityGroupId)
+
+	if props := resp.FlowLogProperties; props != nil {
+		d.Set("storage_account_id", props.StorageID)
+		d.Set("enabled", props.Enabled)
+
+		if err := d.Set("retention_policy", flattenArmNetworkWatcherFlowLogRetentionPolicy(props.RetentionPolicy)); err != nil {
+			return fmt.Errorf("Error setting `retention_policy`: %+v", err)
+		}
+	}
+
+	if err := d.Set("traffic_analytics", flattenArmNetworkWatcherFlowLogTrafficAnalytics(resp.FlowAnalyticsConfiguration)); err != nil {
+		return fmt.Errorf("Error setting `traffic_analytics`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceArmNetworkWatcherFlowLogDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).watcherClient
+	ctx := meta.(*ArmClient).StopContext
+
+	watcherId, networkSecurityGroupId, err := parseArmNetworkWatcherFlowLogID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := watcherId.ResourceGroup
+	watcherName := watcherId.Path["networkWatchers"]
+
+	// Flow Logs can't be removed, only disabled - which requires the Storage Account to be specified
+	// and Traffic Analytics to be disabled at the same time
+	parameters := network.FlowLogInformation{
+		TargetResourceID: utils.String(networkSecurityGroupId),
+		FlowLogProperties: &network.FlowLogProperties{
+			StorageID: utils.String(d.Get("storage_account_id").(string)),
+			Enabled:   utils.Bool(false),
+		},
+		FlowAnalyticsConfiguration: &network.TrafficAnalyticsProperties{
+			NetworkWatcherFlowAnalyticsConfiguration: &network.TrafficAnalyticsConfigurationProperties{
+				Enabled: utils.Bool(false),
+			},
+		},
+	}
+
+	future, err := client.SetFlowLogConfiguration(ctx, resourceGroup, watcherName, parameters)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+
+		return fmt.Errorf("Error disabling Flow Log for Network Security Group %q (Watcher %q / Resource Group %q): %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for Flow Log for Network Security Group %q (Watcher %q / Resource Group %q) to be disabled: %+v", networkSecurityGroupId, watcherName, resourceGroup, err)
+	}
+
+	return nil
+}
+
+// parseArmNetworkWatcherFlowLogID splits the ID of a Flow Log, which is in the format
+// `{networkWatcherId}|{networkSecurityGroupId}` since Flow Logs aren't a resource in their own right
+func parseArmNetworkWatcherFlowLogID(input string) (*ResourceID, string, error) {
+	segments := strings.Split(input, "|")
+	if len(segments) != 2 {
+		return nil, "", fmt.Errorf("Expected the Flow Log ID to be in the format `{networkWatcherId}|{networkSecurityGroupId}` but got %q", input)
+	}
+
+	watcherId, err := parseAzureResourceID(segments[0])
+	if err != nil {
+		return nil, "", fmt.Errorf("Error parsing Network Watcher ID %q: %+v", segments[0], err)
+	}
+
+	if _, err := parseAzureResourceID(segments[1]); err != nil {
+		return nil, "", fmt.Errorf("Error parsing Network Security Group ID %q: %+v", segments[1], err)
+	}
+
+	return watcherId, segments[1], nil
+}
+
+func expandArmNetworkWatcherFlowLogRetentionPolicy(input []interface{}) *network.RetentionPolicyParameters {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+
+	return &network.RetentionPolicyParameters{
+		Enabled: utils.Bool(v["enabled"].(bool)),
+		Days:    utils.Int32(int32(v["days"].(int))),
+	}
+}
+
+func flattenArmNetworkWatcherFlowLogRetentionPolicy(input *network.RetentionPolicyParameters) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	result := make(map[string]interface{})
+
+	if enabled := input.Enabled; enabled != nil {
+		result["enabled"] = *enabled
+	}
+
+	if days := input.Days; days != nil {
+		result["days"] = int(*days)
+	}
+
+	return []interface{}{result}
+}
+
+func expandArmNetworkWatcherFlowLogTrafficAnalytics(input []interface{}) *network.TrafficAnalyticsProperties {
+	if len(input) == 0 || input[0] == nil {
+		return &network.TrafficAnalyticsProperties{
+			NetworkWatcherFlowAnalyticsConfiguration: &network.TrafficAnalyticsConfigurationProperties{
+				Enabled: utils.Bool(false),
+			},
+		}
+	}
+
+	v := input[0].(map[string]interface{})
+
+	return &network.TrafficAnalyticsProperties{
+		NetworkWatcherFlowAnalyticsConfiguration: &network.TrafficAnalyticsConfigurationProperties{
+			Enabled:             utils.Bool(v["enabled"].(bool)),
+			WorkspaceID:         utils.String(v["workspace_id"].(string)),
+			WorkspaceRegion:     utils.String(azureRMNormalizeLocation(v["workspace_region"].(string))),
+			WorkspaceResourceID: utils.String(v["workspace_resource_id"].(string)),
+		},
+	}
+}
+
+func flattenArmNetworkWatcherFlowLogTrafficAnalytics(input *network.TrafficAnalyticsProperties) []interface{} {
+	if input == nil || input.NetworkWatcherFlowAnalyticsConfiguration == nil {
+		return []interface{}{}
+	}
+
+	config := input.NetworkWatcherFlowAnalyticsConfiguration
+
+	// when Traffic Analytics has never been configured the API returns a disabled block with no workspace
+	if config.WorkspaceResourceID == nil || *config.WorkspaceResourceID == "" {
+		return []interface{}{}
+	}
+
+	result := make(map[string]interface{})
+
+	if enabled := config.Enabled; enabled != nil {
+		result["enabled"] = *enabled
+	}
+
+	if workspaceId := config.WorkspaceID; workspaceId != nil {
+		result["workspace_id"] = *workspaceId
+	}
+
+	if region := config.WorkspaceRegion; region != nil {
+		result["workspace_region"] = azureRMNormalizeLocation(*region)
+	}
+
+	result["workspace_resource_id"] = *config.WorkspaceResourceID
+
+	return []interface{}{result}
+}
@@ -0,0 +1,307 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-04-01/network"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestParseArmNetworkWatcherFlowLogID(t *testing.T) {
+	watcherId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Network/networkWatchers/watcher1"
+	nsgId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group2/providers/Microsoft.Network/networkSecurityGroups/nsg1"
+
+	testCases := []struct {
+		Input    string
+		Error    bool
+		Watcher  string
+		Group    string
+		Security string
+	}{
+		{
+			Input: "",
+			Error: true,
+		},
+		{
+			Input: watcherId,
+			Error: true,
+		},
+		{
+			Input: fmt.Sprintf("%s|", watcherId),
+			Error: true,
+		},
+		{
+			Input: fmt.Sprintf("%s|%s|%s", watcherId, nsgId, nsgId),
+			Error: true,
+		},
+		{
+			Input:    fmt.Sprintf("%s|%s", watcherId, nsgId),
+			Watcher:  "watcher1",
+			Group:    "group1",
+			Security: nsgId,
+		},
+	}
+
+	for _, tc := range testCases {
+		id, networkSecurityGroupId, err := parseArmNetworkWatcherFlowLogID(tc.Input)
+		if err != nil {
+			if tc.Error {
+				continue
+			}
+
+			t.Fatalf("Expected no error for %q but got: %+v", tc.Input, err)
+		}
+
+		if tc.Error {
+			t.Fatalf("Expected an error for %q but didn't get one", tc.Input)
+		}
+
+		if actual := id.Path["networkWatchers"]; actual != tc.Watcher {
+			t.Fatalf("Expected the Network Watcher to be %q but got %q", tc.Watcher, actual)
+		}
+
+		if id.ResourceGroup != tc.Group {
+			t.Fatalf("Expected the Resource Group to be %q but got %q", tc.Group, id.ResourceGroup)
+		}
+
+		if networkSecurityGroupId != tc.Security {
+			t.Fatalf("Expected the Network Security Group ID to be %q but got %q", tc.Security, networkSecurityGroupId)
+		}
+	}
+}
+
+func testAccAzureRMNetworkWatcherFlowLog_basic(t *testing.T) {
+	resourceName := "azurerm_network_watcher_flow_log.test"
+
+	ri := acctest.RandInt()
+	rs := acctest.RandString(5)
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMNetworkWatcherFlowLogDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAzureRMNetworkWatcherFlowLog_basicConfig(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMNetworkWatcherFlowLogExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "retention_policy.0.enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "traffic_analytics.#", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAzureRMNetworkWatcherFlowLog_trafficAnalytics(t *testing.T) {
+	resourceName := "azurerm_network_watcher_flow_log.test"
+
+	ri := acctest.RandInt()
+	rs := acctest.RandString(5)
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMNetworkWatcherFlowLogDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAzureRMNetworkWatcherFlowLog_basicConfig(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMNetworkWatcherFlowLogExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "traffic_analytics.#", "0"),
+				),
+			},
+			{
+				Config: testAzureRMNetworkWatcherFlowLog_trafficAnalyticsConfig(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMNetworkWatcherFlowLogExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "retention_policy.0.enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "retention_policy.0.days", "7"),
+					resource.TestCheckResourceAttr(resourceName, "traffic_analytics.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "traffic_analytics.0.enabled", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "traffic_analytics.0.workspace_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "traffic_analytics.0.workspace_resource_id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckAzureRMNetworkWatcherFlowLogExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("not found: %s", name)
+		}
+
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+		watcherName := rs.Primary.Attributes["network_watcher_name"]
+		networkSecurityGroupId := rs.Primary.Attributes["network_security_group_id"]
+
+		client := testAccProvider.Meta().(*ArmClient).watcherClient
+
+		resp, err := testGetAzureRMNetworkWatcherFlowLogStatus(client, resourceGroup, watcherName, networkSecurityGroupId)
+		if err != nil {
+			return fmt.Errorf("Bad: GetFlowLogStatus on watcherClient: %+v", err)
+		}
+
+		if props := resp.FlowLogProperties; props == nil || props.Enabled == nil || !*props.Enabled {
+			return fmt.Errorf("Flow Log for Network Security Group %q is not enabled", networkSecurityGroupId)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMNetworkWatcherFlowLogDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).watcherClient
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_network_watcher_flow_log" {
+			continue
+		}
+
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+		watcherName := rs.Primary.Attributes["network_watcher_name"]
+		networkSecurityGroupId := rs.Primary.Attributes["network_security_group_id"]
+
+		resp, err := testGetAzureRMNetworkWatcherFlowLogStatus(client, resourceGroup, watcherName, networkSecurityGroupId)
+		if err != nil {
+			// the Network Watcher or Network Security Group has been removed
+			return nil
+		}
+
+		if props := resp.FlowLogProperties; props != nil && props.Enabled != nil && *props.Enabled {
+			return fmt.Errorf("Flow Log for Network Security Group %q is still enabled", networkSecurityGroupId)
+		}
+	}
+
+	return nil
+}
+
+func testGetAzureRMNetworkWatcherFlowLogStatus(client network.WatchersClient, resourceGroup, watcherName, networkSecurityGroupId string) (*network.FlowLogInformation, error) {
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	parameters := network.FlowLogStatusParameters{
+		TargetResourceID: utils.String(networkSecurityGroupId),
+	}
+
+	future, err := client.GetFlowLogStatus(ctx, resourceGroup, watcherName, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return nil, err
+	}
+
+	resp, err := future.Result(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func testAzureRMNetworkWatcherFlowLog_base(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_network_security_group" "test" {
+  name                = "acctestnsg-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_network_watcher" "test" {
+  name                = "acctestnw-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_kind             = "StorageV2"
+  account_replication_type = "LRS"
+}
+`, rInt, location, rInt, rInt, rString)
+}
+
+func testAzureRMNetworkWatcherFlowLog_basicConfig(rInt int, rString string, location string) string {
+	config := testAzureRMNetworkWatcherFlowLog_base(rInt, rString, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_network_watcher_flow_log" "test" {
+  network_watcher_name = "${azurerm_network_watcher.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+
+  network_security_group_id = "${azurerm_network_security_group.test.id}"
+  storage_account_id        = "${azurerm_storage_account.test.id}"
+  enabled                   = true
+
+  retention_policy {
+    enabled = false
+    days    = 0
+  }
+}
+`, config)
+}
+
+func testAzureRMNetworkWatcherFlowLog_trafficAnalyticsConfig(rInt int, rString string, location string) string {
+	config := testAzureRMNetworkWatcherFlowLog_base(rInt, rString, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_workspace" "test" {
+  name                = "acctestlaw-%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  sku                 = "PerGB2018"
+}
+
+resource "azurerm_network_watcher_flow_log" "test" {
+  network_watcher_name = "${azurerm_network_watcher.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+
+  network_security_group_id = "${azurerm_network_security_group.test.id}"
+  storage_account_id        = "${azurerm_storage_account.test.id}"
+  enabled                   = true
+
+  retention_policy {
+    enabled = true
+    days    = 7
+  }
+
+  traffic_analytics {
+    enabled               = true
+    workspace_id          = "${azurerm_log_analytics_workspace.test.workspace_id}"
+    workspace_region      = "${azurerm_log_analytics_workspace.test.location}"
+    workspace_resource_id = "${azurerm_log_analytics_workspace.test.id}"
+  }
+}
+`, config, rInt)
+}
@@ -28,6 +28,23 @@ func TestAccAzureRMNetworkWatcher(t *testing.T) {
 			"storageAccountAndLocalDisk": testAccAzureRMPacketCapture_storageAccountAndLocalDisk,
 			"withFilters":                testAccAzureRMPacketCapture_withFilters,
 		},
+		"DataSource": {
+			"connectivity":           testAccDataSourceAzureRMNetworkWatcherConnectivity_basic,
+			"effectiveSecurityRules": testAccDataSourceAzureRMNetworkEffectiveSecurityRules_basic,
+			"ipFlowVerify":           testAccDataSourceAzureRMNetworkWatcherIPFlowVerify_basic,
+			"nextHop":                testAccDataSourceAzureRMNetworkWatcherNextHop_basic,
+			"topology":               testAccDataSourceAzureRMNetworkTopology_basic,
+		},
+		"FlowLog": {
+			"basic":            testAccAzureRMNetworkWatcherFlowLog_basic,
+			"trafficAnalytics": testAccAzureRMNetworkWatcherFlowLog_trafficAnalytics,
+		},
+		"ConnectionMonitor": {
+			"addressBasic":        testAccAzureRMConnectionMonitor_addressBasic,
+			"addressComplete":     testAccAzureRMConnectionMonitor_addressComplete,
+			"virtualMachineBasic": testAccAzureRMConnectionMonitor_virtualMachineBasic,
+			"destinationConflict": testAccAzureRMConnectionMonitor_destinationConflict,
+		},
 	}
 
 	for group, m := range testCases {
@@ -6,8 +6,11 @@ import (
 	"regexp"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
+	"github.com/Azure/azure-sdk-for-go/storage"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
@@ -67,6 +70,38 @@ func resourceArmSnapshot() *schema.Resource {
 				Computed: true,
 			},
 
+			"cross_region_copy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"storage_account_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: azure.ValidateResourceID,
+						},
+
+						"storage_container_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+
+						"sas_duration_in_seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      3600,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+
 			"encryption_settings": encryptionSettingsSchema(),
 
 			"tags": tagsSchema(),
@@ -106,6 +141,41 @@ func resourceArmSnapshotCreateUpdate(d *schema.ResourceData, meta interface{}) e
 		properties.SnapshotProperties.CreationData.StorageAccountID = utils.String(v.(string))
 	}
 
+	if v, ok := d.GetOk("cross_region_copy"); ok {
+		sourceResourceId := d.Get("source_resource_id").(string)
+		if sourceResourceId == "" {
+			return fmt.Errorf("`source_resource_id` must be specified when `cross_region_copy` is used")
+		}
+
+		crossRegionCopy := v.([]interface{})[0].(map[string]interface{})
+		storageAccountId := crossRegionCopy["storage_account_id"].(string)
+
+		blob, err := retrieveSnapshotCrossRegionCopyBlob(meta, storageAccountId, crossRegionCopy["storage_container_name"].(string), name)
+		if err != nil {
+			return err
+		}
+
+		// the source is copied into the Storage Account (which is in the target region) and the Snapshot is then imported from there
+		if d.IsNewResource() {
+			if err := copySnapshotSourceToBlob(meta, sourceResourceId, crossRegionCopy["sas_duration_in_seconds"].(int), blob); err != nil {
+				return err
+			}
+
+			defer func() {
+				log.Printf("[DEBUG] Removing staging blob %q used to copy Snapshot %q (Resource Group %q)", blob.GetURL(), name, resourceGroup)
+				if _, err := blob.DeleteIfExists(&storage.DeleteBlobOptions{}); err != nil {
+					log.Printf("[WARN] Error removing staging blob %q used to copy Snapshot %q (Resource Group %q): %+v", blob.GetURL(), name, resourceGroup, err)
+				}
+			}()
+		}
+
+		properties.SnapshotProperties.CreationData = &compute.CreationData{
+			CreateOption:     compute.Import,
+			SourceURI:        utils.String(blob.GetURL()),
+			StorageAccountID: utils.String(storageAccountId),
+		}
+	}
+
 	diskSizeGB := d.Get("disk_size_gb").(int)
 	if diskSizeGB > 0 {
 		properties.SnapshotProperties.DiskSizeGB = utils.Int32(int32(diskSizeGB))
@@ -167,11 +237,14 @@ func resourceArmSnapshotRead(d *schema.ResourceData, meta interface{}) error {
 
 	if props := resp.SnapshotProperties; props != nil {
 
-		if data := props.CreationData; data != nil {
-			d.Set("create_option", string(data.CreateOption))
+		// when copied across regions the Snapshot is imported from a staging blob, so the Creation Data doesn't match the config
+		if _, ok := d.GetOk("cross_region_copy"); !ok {
+			if data := props.CreationData; data != nil {
+				d.Set("create_option", string(data.CreateOption))
 
-			if accountId := data.StorageAccountID; accountId != nil {
-				d.Set("storage_account_id", accountId)
+				if accountId := data.StorageAccountID; accountId != nil {
+					d.Set("storage_account_id", accountId)
+				}
 			}
 		}
 
@@ -213,6 +286,58 @@ func resourceArmSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// retrieveSnapshotCrossRegionCopyBlob returns a reference to the staging blob used to copy the Snapshot across regions.
+func retrieveSnapshotCrossRegionCopyBlob(meta interface{}, storageAccountId string, containerName string, snapshotName string) (*storage.Blob, error) {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseAzureResourceID(storageAccountId)
+	if err != nil {
+		return nil, err
+	}
+	resourceGroup := id.ResourceGroup
+	accountName := id.Path["storageAccounts"]
+
+	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(ctx, resourceGroup, accountName)
+	if err != nil {
+		return nil, err
+	}
+	if !accountExists {
+		return nil, fmt.Errorf("Storage Account %q (Resource Group %q) was not found", accountName, resourceGroup)
+	}
+
+	container := blobClient.GetContainerReference(containerName)
+	return container.GetBlobReference(fmt.Sprintf("%s.vhd", snapshotName)), nil
+}
+
+// copySnapshotSourceToBlob copies the contents of the source Managed Disk or Snapshot into the specified blob,
+// by granting temporary read access to the source which is revoked once the copy has completed.
+func copySnapshotSourceToBlob(meta interface{}, sourceResourceId string, durationInSeconds int, blob *storage.Blob) error {
+	// the SAS of an existing grant can't be retrieved and revoking access would invalidate it for whoever it was
+	// granted to (such as an `azurerm_managed_disk_sas_token` resource), so the copy is refused instead
+	if err := ensureNoActiveSASForManagedDiskOrSnapshot(meta, sourceResourceId); err != nil {
+		return fmt.Errorf("Unable to copy %q across regions: %+v", sourceResourceId, err)
+	}
+
+	sasUrl, err := grantAccessToManagedDiskOrSnapshot(meta, sourceResourceId, durationInSeconds)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Copying %q to staging blob %q", sourceResourceId, blob.GetURL())
+	copyErr := blob.Copy(sasUrl, &storage.CopyOptions{})
+
+	if err := revokeAccessToManagedDiskOrSnapshot(meta, sourceResourceId); err != nil {
+		return err
+	}
+
+	if copyErr != nil {
+		return fmt.Errorf("Error copying %q to staging blob %q: %+v", sourceResourceId, blob.GetURL(), copyErr)
+	}
+
+	return nil
+}
+
 func validateSnapshotName(v interface{}, _ string) (warnings []string, errors []error) {
 	// a-z, A-Z, 0-9, _ and -. The max name length is 80
 	value := v.(string)
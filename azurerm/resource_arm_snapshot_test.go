@@ -179,6 +179,29 @@ func TestAccAzureRMSnapshot_fromExistingSnapshot(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMSnapshot_crossRegionCopy(t *testing.T) {
+	resourceName := "azurerm_snapshot.second"
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	config := testAccAzureRMSnapshot_crossRegionCopy(ri, rs, testLocation(), testAltLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSnapshotExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "create_option", "Copy"),
+					resource.TestCheckResourceAttr(resourceName, "disk_size_gb", "10"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAzureRMSnapshot_fromUnmanagedDisk(t *testing.T) {
 	resourceName := "azurerm_snapshot.test"
 	ri := acctest.RandInt()
@@ -566,3 +589,62 @@ resource "azurerm_snapshot" "test" {
 }
 `, rInt, location, rInt, rInt, rString, rInt, rInt, rInt)
 }
+
+func testAccAzureRMSnapshot_crossRegionCopy(rInt int, rString string, location string, altLocation string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[3]s"
+}
+
+resource "azurerm_resource_group" "second" {
+  name     = "acctestRG2-%[1]d"
+  location = "%[4]s"
+}
+
+resource "azurerm_managed_disk" "test" {
+  name                 = "acctestmd-%[1]d"
+  location             = "${azurerm_resource_group.test.location}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "10"
+}
+
+resource "azurerm_snapshot" "first" {
+  name                = "acctestss1_%[1]d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  create_option       = "Copy"
+  source_uri          = "${azurerm_managed_disk.test.id}"
+}
+
+resource "azurerm_storage_account" "second" {
+  name                     = "acctestsa%[2]s"
+  resource_group_name      = "${azurerm_resource_group.second.name}"
+  location                 = "${azurerm_resource_group.second.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "second" {
+  name                  = "snapshots"
+  resource_group_name   = "${azurerm_resource_group.second.name}"
+  storage_account_name  = "${azurerm_storage_account.second.name}"
+  container_access_type = "private"
+}
+
+resource "azurerm_snapshot" "second" {
+  name                = "acctestss2_%[1]d"
+  location            = "${azurerm_resource_group.second.location}"
+  resource_group_name = "${azurerm_resource_group.second.name}"
+  create_option       = "Copy"
+  source_resource_id  = "${azurerm_snapshot.first.id}"
+
+  cross_region_copy {
+    storage_account_id     = "${azurerm_storage_account.second.id}"
+    storage_container_name = "${azurerm_storage_container.second.name}"
+  }
+}
+`, rInt, rString, location, altLocation)
+}
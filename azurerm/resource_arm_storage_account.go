@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
@@ -95,10 +96,12 @@ func resourceArmStorageAccount() *schema.Resource {
 				}, true),
 			},
 
+			// this is Computed rather than defaulting to `Microsoft.Storage` since it's set to `Microsoft.Keyvault` by
+			// the `azurerm_storage_account_customer_managed_key` resource - which would otherwise be reverted
 			"account_encryption_source": {
 				Type:     schema.TypeString,
 				Optional: true,
-				Default:  string(storage.MicrosoftStorage),
+				Computed: true,
 				ValidateFunc: validation.StringInSlice([]string{
 					string(storage.MicrosoftKeyvault),
 					string(storage.MicrosoftStorage),
@@ -180,6 +183,32 @@ func resourceArmStorageAccount() *schema.Resource {
 				},
 			},
 
+			"blob_properties": storageServicePropertiesSchema(),
+
+			"queue_properties": storageServicePropertiesSchema(),
+
+			"table_properties": storageServicePropertiesSchema(),
+
+			"static_website": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"index_document": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+						"error_404_document": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+					},
+				},
+			},
+
 			"primary_location": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -226,6 +255,11 @@ func resourceArmStorageAccount() *schema.Resource {
 				Computed: true,
 			},
 
+			"primary_web_endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"primary_access_key": {
 				Type:      schema.TypeString,
 				Sensitive: true,
@@ -338,10 +372,17 @@ func resourceArmStorageAccountCreate(d *schema.ResourceData, meta interface{}) e
 	accountTier := d.Get("account_tier").(string)
 	replicationType := d.Get("account_replication_type").(string)
 	storageType := fmt.Sprintf("%s_%s", accountTier, replicationType)
-	storageAccountEncryptionSource := d.Get("account_encryption_source").(string)
+	storageAccountEncryptionSource := string(storage.MicrosoftStorage)
+	if v, ok := d.GetOk("account_encryption_source"); ok {
+		storageAccountEncryptionSource = v.(string)
+	}
 
 	networkRules := expandStorageAccountNetworkRules(d)
 
+	if err := validateStorageAccountServiceProperties(d); err != nil {
+		return err
+	}
+
 	parameters := storage.AccountCreateParameters{
 		Location: &location,
 		Sku: &storage.Sku{
@@ -415,6 +456,10 @@ func resourceArmStorageAccountCreate(d *schema.ResourceData, meta interface{}) e
 	log.Printf("[INFO] storage account %q ID: %q", storageAccountName, *account.ID)
 	d.SetId(*account.ID)
 
+	if err := resourceArmStorageAccountSetServiceProperties(d, meta, resourceGroupName, storageAccountName); err != nil {
+		return err
+	}
+
 	return resourceArmStorageAccountRead(d, meta)
 }
 
@@ -442,6 +487,10 @@ func resourceArmStorageAccountUpdate(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
+	if err := validateStorageAccountServiceProperties(d); err != nil {
+		return err
+	}
+
 	d.Partial(true)
 
 	if d.HasChange("account_replication_type") {
@@ -490,7 +539,7 @@ func resourceArmStorageAccountUpdate(d *schema.ResourceData, meta interface{}) e
 		d.SetPartial("tags")
 	}
 
-	if d.HasChange("enable_blob_encryption") || d.HasChange("enable_file_encryption") {
+	if d.HasChange("enable_blob_encryption") || d.HasChange("enable_file_encryption") || d.HasChange("account_encryption_source") {
 		encryptionSource := d.Get("account_encryption_source").(string)
 
 		opts := storage.AccountUpdateParameters{
@@ -502,6 +551,18 @@ func resourceArmStorageAccountUpdate(d *schema.ResourceData, meta interface{}) e
 			},
 		}
 
+		// retain the Customer Managed Key (configured via `azurerm_storage_account_customer_managed_key`) if there is one
+		if strings.EqualFold(encryptionSource, string(storage.MicrosoftKeyvault)) {
+			existing, err := client.GetProperties(ctx, resourceGroupName, storageAccountName)
+			if err != nil {
+				return fmt.Errorf("Error retrieving Azure Storage Account %q: %+v", storageAccountName, err)
+			}
+
+			if props := existing.AccountProperties; props != nil && props.Encryption != nil {
+				opts.Encryption.KeyVaultProperties = props.Encryption.KeyVaultProperties
+			}
+		}
+
 		if d.HasChange("enable_blob_encryption") {
 			enableEncryption := d.Get("enable_blob_encryption").(bool)
 			opts.Encryption.Services.Blob = &storage.EncryptionService{
@@ -582,6 +643,10 @@ func resourceArmStorageAccountUpdate(d *schema.ResourceData, meta interface{}) e
 		d.SetPartial("network_rules")
 	}
 
+	if err := resourceArmStorageAccountSetServiceProperties(d, meta, resourceGroupName, storageAccountName); err != nil {
+		return err
+	}
+
 	d.Partial(false)
 	return resourceArmStorageAccountRead(d, meta)
 }
@@ -713,11 +778,187 @@ func resourceArmStorageAccountRead(d *schema.ResourceData, meta interface{}) err
 		return err
 	}
 
+	// the Web Endpoint isn't returned by the API Version in the SDK, so it's only retrieved (using a separate request)
+	// when a Static Website is configured - which is only available for StorageV2 accounts
+	primaryWebEndpoint := ""
+	if resp.Kind == storage.StorageV2 && len(d.Get("static_website").([]interface{})) > 0 {
+		primaryWebEndpoint, err = meta.(*ArmClient).getStorageAccountPrimaryWebEndpoint(ctx, resGroup, name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving the Web Endpoint for Storage Account %q (Resource Group %q): %+v", name, resGroup, err)
+		}
+	}
+	d.Set("primary_web_endpoint", primaryWebEndpoint)
+
+	if err := resourceArmStorageAccountReadServiceProperties(d, meta, resGroup, name); err != nil {
+		return err
+	}
+
 	flattenAndSetTags(d, resp.Tags)
 
 	return nil
 }
 
+// validateStorageAccountServiceProperties validates that the services being configured are available for the `account_kind`
+func validateStorageAccountServiceProperties(d *schema.ResourceData) error {
+	accountKind := d.Get("account_kind").(string)
+
+	if strings.EqualFold(accountKind, string(storage.BlobStorage)) {
+		for _, key := range []string{"queue_properties", "table_properties"} {
+			if v := d.Get(key).([]interface{}); len(v) > 0 {
+				return fmt.Errorf("`%s` can't be specified for Blob Storage accounts, since they don't include the Queue and Table services", key)
+			}
+		}
+	}
+
+	if v := d.Get("static_website").([]interface{}); len(v) > 0 && !strings.EqualFold(accountKind, string(storage.StorageV2)) {
+		return fmt.Errorf("`static_website` can only be specified when `account_kind` is `StorageV2`")
+	}
+
+	return nil
+}
+
+// resourceArmStorageAccountSetServiceProperties updates the properties of the Blob, Queue and Table services and the
+// Static Website using the data plane, where they've changed. Removing a block resets those properties to the defaults
+func resourceArmStorageAccountSetServiceProperties(d *schema.ResourceData, meta interface{}, resourceGroupName, storageAccountName string) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	if !d.HasChange("blob_properties") && !d.HasChange("queue_properties") && !d.HasChange("table_properties") && !d.HasChange("static_website") {
+		return nil
+	}
+
+	// the service properties can only be managed by the account owner, so these use the Access Key rather than Azure AD
+	storageClient, accountExists, err := armClient.getStorageClientForStorageAccount(ctx, resourceGroupName, storageAccountName, false)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q Not Found", storageAccountName)
+	}
+
+	if d.HasChange("blob_properties") {
+		blobClient := storageClient.GetBlobService()
+		properties := expandStorageServiceProperties(d.Get("blob_properties").([]interface{}))
+
+		log.Printf("[INFO] Updating the Blob Service Properties for Storage Account %q (Resource Group %q)", storageAccountName, resourceGroupName)
+		if err := blobClient.SetServiceProperties(properties); err != nil {
+			return fmt.Errorf("Error updating the Blob Service Properties for Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroupName, err)
+		}
+
+		d.SetPartial("blob_properties")
+	}
+
+	if d.HasChange("queue_properties") {
+		queueClient := storageClient.GetQueueService()
+		properties := expandStorageServiceProperties(d.Get("queue_properties").([]interface{}))
+
+		log.Printf("[INFO] Updating the Queue Service Properties for Storage Account %q (Resource Group %q)", storageAccountName, resourceGroupName)
+		if err := queueClient.SetServiceProperties(properties); err != nil {
+			return fmt.Errorf("Error updating the Queue Service Properties for Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroupName, err)
+		}
+
+		d.SetPartial("queue_properties")
+	}
+
+	if d.HasChange("table_properties") {
+		tableClient := storageClient.GetTableService()
+		properties := expandStorageServiceProperties(d.Get("table_properties").([]interface{}))
+
+		log.Printf("[INFO] Updating the Table Service Properties for Storage Account %q (Resource Group %q)", storageAccountName, resourceGroupName)
+		if err := tableClient.SetServiceProperties(properties); err != nil {
+			return fmt.Errorf("Error updating the Table Service Properties for Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroupName, err)
+		}
+
+		d.SetPartial("table_properties")
+	}
+
+	if d.HasChange("static_website") {
+		website := expandStorageStaticWebsite(d.Get("static_website").([]interface{}))
+
+		log.Printf("[INFO] Updating the Static Website for Storage Account %q (Resource Group %q)", storageAccountName, resourceGroupName)
+		if err := armClient.setStorageStaticWebsite(ctx, resourceGroupName, storageAccountName, website); err != nil {
+			return fmt.Errorf("Error updating the Static Website for Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroupName, err)
+		}
+
+		d.SetPartial("static_website")
+	}
+
+	return nil
+}
+
+// resourceArmStorageAccountReadServiceProperties reads the properties of the Blob, Queue and Table services and the
+// Static Website from the data plane. These are only read when they're configured, since the data plane may not be
+// reachable (for example when `network_rules` deny access from where Terraform is running)
+func resourceArmStorageAccountReadServiceProperties(d *schema.ResourceData, meta interface{}, resourceGroupName, storageAccountName string) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	readBlob := len(d.Get("blob_properties").([]interface{})) > 0
+	readQueue := len(d.Get("queue_properties").([]interface{})) > 0
+	readTable := len(d.Get("table_properties").([]interface{})) > 0
+	readWebsite := len(d.Get("static_website").([]interface{})) > 0
+	if !readBlob && !readQueue && !readTable && !readWebsite {
+		return nil
+	}
+
+	storageClient, accountExists, err := armClient.getStorageClientForStorageAccount(ctx, resourceGroupName, storageAccountName, false)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q Not Found", storageAccountName)
+	}
+
+	if readBlob {
+		blobClient := storageClient.GetBlobService()
+		properties, err := blobClient.GetServiceProperties()
+		if err != nil {
+			return fmt.Errorf("Error retrieving the Blob Service Properties for Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroupName, err)
+		}
+
+		if err := d.Set("blob_properties", flattenStorageServiceProperties(properties)); err != nil {
+			return fmt.Errorf("Error setting `blob_properties`: %+v", err)
+		}
+	}
+
+	if readQueue {
+		queueClient := storageClient.GetQueueService()
+		properties, err := queueClient.GetServiceProperties()
+		if err != nil {
+			return fmt.Errorf("Error retrieving the Queue Service Properties for Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroupName, err)
+		}
+
+		if err := d.Set("queue_properties", flattenStorageServiceProperties(properties)); err != nil {
+			return fmt.Errorf("Error setting `queue_properties`: %+v", err)
+		}
+	}
+
+	if readTable {
+		tableClient := storageClient.GetTableService()
+		properties, err := tableClient.GetServiceProperties()
+		if err != nil {
+			return fmt.Errorf("Error retrieving the Table Service Properties for Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroupName, err)
+		}
+
+		if err := d.Set("table_properties", flattenStorageServiceProperties(properties)); err != nil {
+			return fmt.Errorf("Error setting `table_properties`: %+v", err)
+		}
+	}
+
+	if readWebsite {
+		website, err := armClient.getStorageStaticWebsite(ctx, resourceGroupName, storageAccountName)
+		if err != nil {
+			return fmt.Errorf("Error retrieving the Static Website for Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroupName, err)
+		}
+
+		if err := d.Set("static_website", flattenStorageStaticWebsite(website)); err != nil {
+			return fmt.Errorf("Error setting `static_website`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
 func resourceArmStorageAccountDelete(d *schema.ResourceData, meta interface{}) error {
 	ctx := meta.(*ArmClient).StopContext
 	client := meta.(*ArmClient).storageServiceClient
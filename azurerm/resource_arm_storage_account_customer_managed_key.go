@@ -0,0 +1,359 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2018-02-14/keyvault"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-10-01/storage"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmStorageAccountCustomerManagedKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmStorageAccountCustomerManagedKeyCreateUpdate,
+		Read:   resourceArmStorageAccountCustomerManagedKeyRead,
+		Update: resourceArmStorageAccountCustomerManagedKeyCreateUpdate,
+		Delete: resourceArmStorageAccountCustomerManagedKeyDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"storage_account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"key_vault_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"key_vault_key_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: azure.ValidateKeyVaultChildIdWithOptionalVersion,
+			},
+
+			"key_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"key_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"key_vault_uri": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceArmStorageAccountCustomerManagedKeyCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).storageServiceClient
+	vaultsClient := meta.(*ArmClient).keyVaultClient
+	keysClient := meta.(*ArmClient).keyVaultManagementClient
+	ctx := meta.(*ArmClient).StopContext
+
+	storageAccountId, err := parseAzureResourceID(d.Get("storage_account_id").(string))
+	if err != nil {
+		return err
+	}
+	resourceGroup := storageAccountId.ResourceGroup
+	storageAccountName := storageAccountId.Path["storageAccounts"]
+
+	keyId, err := azure.ParseKeyVaultChildIDWithOptionalVersion(d.Get("key_vault_key_id").(string))
+	if err != nil {
+		return err
+	}
+
+	account, err := client.GetProperties(ctx, resourceGroup, storageAccountName)
+	if err != nil {
+		if utils.ResponseWasNotFound(account.Response) {
+			return fmt.Errorf("Error: Storage Account %q was not found in Resource Group %q", storageAccountName, resourceGroup)
+		}
+		return fmt.Errorf("Error retrieving Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroup, err)
+	}
+
+	if account.ID == nil {
+		return fmt.Errorf("Error retrieving Storage Account %q (Resource Group %q): ID was nil", storageAccountName, resourceGroup)
+	}
+
+	identity := account.Identity
+	if identity == nil || identity.Type == nil || !strings.EqualFold(*identity.Type, "SystemAssigned") || identity.PrincipalID == nil {
+		return fmt.Errorf("Error: Storage Account %q (Resource Group %q) must have a `SystemAssigned` `identity` to use a Customer Managed Key", storageAccountName, resourceGroup)
+	}
+
+	keyVaultId, err := parseAzureResourceID(d.Get("key_vault_id").(string))
+	if err != nil {
+		return err
+	}
+	keyVaultResourceGroup := keyVaultId.ResourceGroup
+	keyVaultName := keyVaultId.Path["vaults"]
+
+	vault, err := vaultsClient.Get(ctx, keyVaultResourceGroup, keyVaultName)
+	if err != nil {
+		if utils.ResponseWasNotFound(vault.Response) {
+			return fmt.Errorf("Error: Key Vault %q was not found in Resource Group %q", keyVaultName, keyVaultResourceGroup)
+		}
+		return fmt.Errorf("Error retrieving Key Vault %q (Resource Group %q): %+v", keyVaultName, keyVaultResourceGroup, err)
+	}
+
+	if err := validateStorageAccountCustomerManagedKeyVault(vault, keyId.KeyVaultBaseUrl, *identity.PrincipalID); err != nil {
+		return fmt.Errorf("Error validating Key Vault %q (Resource Group %q) for Storage Account %q: %+v", keyVaultName, keyVaultResourceGroup, storageAccountName, err)
+	}
+
+	// when no version is specified the latest version of the key is used
+	key, err := keysClient.GetKey(ctx, keyId.KeyVaultBaseUrl, keyId.Name, keyId.Version)
+	if err != nil {
+		if utils.ResponseWasNotFound(key.Response) {
+			return fmt.Errorf("Error: Key %q was not found in Key Vault %q", keyId.Name, keyId.KeyVaultBaseUrl)
+		}
+		return fmt.Errorf("Error retrieving Key %q (Key Vault %q): %+v", keyId.Name, keyId.KeyVaultBaseUrl, err)
+	}
+
+	if key.Key == nil || key.Key.Kid == nil {
+		return fmt.Errorf("Error retrieving Key %q (Key Vault %q): `kid` was nil", keyId.Name, keyId.KeyVaultBaseUrl)
+	}
+
+	resolvedKeyId, err := azure.ParseKeyVaultChildID(*key.Key.Kid)
+	if err != nil {
+		return err
+	}
+
+	services := &storage.EncryptionServices{}
+	if props := account.AccountProperties; props != nil && props.Encryption != nil && props.Encryption.Services != nil {
+		services = props.Encryption.Services
+	}
+
+	parameters := storage.AccountUpdateParameters{
+		AccountPropertiesUpdateParameters: &storage.AccountPropertiesUpdateParameters{
+			Encryption: &storage.Encryption{
+				Services:  services,
+				KeySource: storage.MicrosoftKeyvault,
+				KeyVaultProperties: &storage.KeyVaultProperties{
+					KeyName:     utils.String(resolvedKeyId.Name),
+					KeyVersion:  utils.String(resolvedKeyId.Version),
+					KeyVaultURI: utils.String(resolvedKeyId.KeyVaultBaseUrl),
+				},
+			},
+		},
+	}
+
+	log.Printf("[DEBUG] Configuring Key %q (Version %q) as the Customer Managed Key for Storage Account %q (Resource Group %q)", resolvedKeyId.Name, resolvedKeyId.Version, storageAccountName, resourceGroup)
+	if _, err := client.Update(ctx, resourceGroup, storageAccountName, parameters); err != nil {
+		return fmt.Errorf("Error updating Customer Managed Key for Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroup, err)
+	}
+
+	d.SetId(*account.ID)
+
+	return resourceArmStorageAccountCustomerManagedKeyRead(d, meta)
+}
+
+func resourceArmStorageAccountCustomerManagedKeyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).storageServiceClient
+	vaultsClient := meta.(*ArmClient).keyVaultClient
+	keysClient := meta.(*ArmClient).keyVaultManagementClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	storageAccountName := id.Path["storageAccounts"]
+
+	account, err := client.GetProperties(ctx, resourceGroup, storageAccountName)
+	if err != nil {
+		if utils.ResponseWasNotFound(account.Response) {
+			log.Printf("[DEBUG] Storage Account %q was not found in Resource Group %q - removing from state!", storageAccountName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroup, err)
+	}
+
+	var keyVaultProps *storage.KeyVaultProperties
+	if props := account.AccountProperties; props != nil && props.Encryption != nil && strings.EqualFold(string(props.Encryption.KeySource), string(storage.MicrosoftKeyvault)) {
+		keyVaultProps = props.Encryption.KeyVaultProperties
+	}
+	if keyVaultProps == nil || keyVaultProps.KeyName == nil || keyVaultProps.KeyVaultURI == nil {
+		log.Printf("[DEBUG] Storage Account %q (Resource Group %q) isn't using a Customer Managed Key - removing from state!", storageAccountName, resourceGroup)
+		d.SetId("")
+		return nil
+	}
+
+	keyName := *keyVaultProps.KeyName
+	keyVersion := ""
+	if keyVaultProps.KeyVersion != nil {
+		keyVersion = *keyVaultProps.KeyVersion
+	}
+	keyVaultUri := strings.TrimSuffix(*keyVaultProps.KeyVaultURI, "/") + "/"
+
+	// a versionless Key ID tracks the latest version of the key - so if the Storage Account is using an
+	// older version we expose the versioned ID such that the next apply rotates the Storage Account to it
+	keyVaultKeyId := fmt.Sprintf("%skeys/%s/%s", keyVaultUri, keyName, keyVersion)
+	if v, ok := d.GetOk("key_vault_key_id"); ok {
+		existing, err := azure.ParseKeyVaultChildIDWithOptionalVersion(v.(string))
+		if err == nil && existing.Version == "" && strings.EqualFold(existing.Name, keyName) && strings.EqualFold(existing.KeyVaultBaseUrl, keyVaultUri) {
+			latest, err := keysClient.GetKey(ctx, keyVaultUri, keyName, "")
+			if err != nil {
+				return fmt.Errorf("Error retrieving the latest version of Key %q (Key Vault %q): %+v", keyName, keyVaultUri, err)
+			}
+
+			if latest.Key != nil && latest.Key.Kid != nil {
+				latestId, err := azure.ParseKeyVaultChildID(*latest.Key.Kid)
+				if err != nil {
+					return err
+				}
+
+				if strings.EqualFold(latestId.Version, keyVersion) {
+					keyVaultKeyId = v.(string)
+				}
+			}
+		}
+	}
+
+	// the Key Vault's Resource ID isn't returned from the API, so when importing we look it up by name
+	if _, ok := d.GetOk("key_vault_id"); !ok {
+		keyVaultId, err := findKeyVaultIDByBaseUrl(ctx, vaultsClient, keyVaultUri)
+		if err != nil {
+			return err
+		}
+		d.Set("key_vault_id", keyVaultId)
+	}
+
+	d.Set("storage_account_id", account.ID)
+	d.Set("key_vault_key_id", keyVaultKeyId)
+	d.Set("key_name", keyName)
+	d.Set("key_version", keyVersion)
+	d.Set("key_vault_uri", keyVaultUri)
+
+	return nil
+}
+
+func resourceArmStorageAccountCustomerManagedKeyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).storageServiceClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	storageAccountName := id.Path["storageAccounts"]
+
+	account, err := client.GetProperties(ctx, resourceGroup, storageAccountName)
+	if err != nil {
+		if utils.ResponseWasNotFound(account.Response) {
+			return nil
+		}
+		return fmt.Errorf("Error retrieving Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroup, err)
+	}
+
+	services := &storage.EncryptionServices{}
+	if props := account.AccountProperties; props != nil && props.Encryption != nil && props.Encryption.Services != nil {
+		services = props.Encryption.Services
+	}
+
+	// removing the Customer Managed Key reverts the Storage Account to Microsoft Managed Keys
+	parameters := storage.AccountUpdateParameters{
+		AccountPropertiesUpdateParameters: &storage.AccountPropertiesUpdateParameters{
+			Encryption: &storage.Encryption{
+				Services:  services,
+				KeySource: storage.MicrosoftStorage,
+			},
+		},
+	}
+
+	if _, err := client.Update(ctx, resourceGroup, storageAccountName, parameters); err != nil {
+		return fmt.Errorf("Error removing Customer Managed Key from Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroup, err)
+	}
+
+	return nil
+}
+
+func validateStorageAccountCustomerManagedKeyVault(vault keyvault.Vault, keyVaultBaseUrl string, principalId string) error {
+	props := vault.Properties
+	if props == nil {
+		return fmt.Errorf("`properties` was nil")
+	}
+
+	if props.VaultURI != nil && !strings.EqualFold(strings.TrimSuffix(*props.VaultURI, "/"), strings.TrimSuffix(keyVaultBaseUrl, "/")) {
+		return fmt.Errorf("`key_vault_key_id` (%q) doesn't belong to the Key Vault specified in `key_vault_id` (%q)", keyVaultBaseUrl, *props.VaultURI)
+	}
+
+	if props.EnableSoftDelete == nil || !*props.EnableSoftDelete || props.EnablePurgeProtection == nil || !*props.EnablePurgeProtection {
+		return fmt.Errorf("Soft Delete and Purge Protection must be enabled on the Key Vault (`enable_soft_delete` and `enable_purge_protection`) to use it for a Customer Managed Key")
+	}
+
+	policy, err := findKeyVaultAccessPolicy(props.AccessPolicies, principalId, "")
+	if err != nil {
+		return err
+	}
+
+	required := []keyvault.KeyPermissions{keyvault.KeyPermissionsGet, keyvault.KeyPermissionsWrapKey, keyvault.KeyPermissionsUnwrapKey}
+	missing := make([]string, 0)
+	for _, permission := range required {
+		granted := false
+		if policy != nil && policy.Permissions != nil && policy.Permissions.Keys != nil {
+			for _, v := range *policy.Permissions.Keys {
+				if strings.EqualFold(string(v), string(permission)) {
+					granted = true
+					break
+				}
+			}
+		}
+
+		if !granted {
+			missing = append(missing, string(permission))
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("the Storage Account's Managed Identity (Principal ID %q) is missing the Key Permissions [%s] - these can be granted using an `azurerm_key_vault_access_policy` with `object_id` set to the `identity.0.principal_id` of the Storage Account and `key_permissions` containing `get`, `wrapKey` and `unwrapKey`", principalId, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+func findKeyVaultIDByBaseUrl(ctx context.Context, client keyvault.VaultsClient, keyVaultBaseUrl string) (string, error) {
+	// the name of the Key Vault is the first label of the hostname, e.g. `https://example.vault.azure.net/`
+	u, err := url.Parse(keyVaultBaseUrl)
+	if err != nil {
+		return "", fmt.Errorf("Error parsing Key Vault URI %q: %+v", keyVaultBaseUrl, err)
+	}
+	keyVaultName := strings.Split(u.Hostname(), ".")[0]
+
+	results, err := client.ListComplete(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("Error listing Key Vaults: %+v", err)
+	}
+
+	for results.NotDone() {
+		v := results.Value()
+		if v.ID != nil && v.Name != nil && strings.EqualFold(*v.Name, keyVaultName) {
+			return *v.ID, nil
+		}
+
+		if err := results.Next(); err != nil {
+			return "", fmt.Errorf("Error listing Key Vaults: %+v", err)
+		}
+	}
+
+	return "", fmt.Errorf("Unable to find the Key Vault with the URI %q", keyVaultBaseUrl)
+}
@@ -0,0 +1,274 @@
+package azurerm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-10-01/storage"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAzureRMStorageAccountCustomerManagedKey_basic(t *testing.T) {
+	resourceName := "azurerm_storage_account_customer_managed_key.test"
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	config := testAccAzureRMStorageAccountCustomerManagedKey_basic(ri, rs, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountCustomerManagedKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountCustomerManagedKeyExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "key_name", fmt.Sprintf("key-%s", rs)),
+					resource.TestCheckResourceAttrPair(resourceName, "key_version", "azurerm_key_vault_key.test", "version"),
+					resource.TestCheckResourceAttrPair(resourceName, "key_vault_key_id", "azurerm_key_vault_key.test", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageAccountCustomerManagedKey_versionless(t *testing.T) {
+	resourceName := "azurerm_storage_account_customer_managed_key.test"
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	config := testAccAzureRMStorageAccountCustomerManagedKey_versionless(ri, rs, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountCustomerManagedKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountCustomerManagedKeyExists(resourceName),
+					resource.TestMatchResourceAttr(resourceName, "key_vault_key_id", regexp.MustCompile(fmt.Sprintf("/keys/key-%s$", rs))),
+					resource.TestCheckResourceAttrPair(resourceName, "key_version", "azurerm_key_vault_key.test", "version"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageAccountCustomerManagedKey_missingAccessPolicy(t *testing.T) {
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	config := testAccAzureRMStorageAccountCustomerManagedKey_missingAccessPolicy(ri, rs, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountCustomerManagedKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("azurerm_key_vault_access_policy"),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMStorageAccountCustomerManagedKeyExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		id, err := parseAzureResourceID(rs.Primary.Attributes["storage_account_id"])
+		if err != nil {
+			return err
+		}
+		resourceGroup := id.ResourceGroup
+		storageAccountName := id.Path["storageAccounts"]
+
+		client := testAccProvider.Meta().(*ArmClient).storageServiceClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.GetProperties(ctx, resourceGroup, storageAccountName)
+		if err != nil {
+			return fmt.Errorf("Bad: Get on storageServiceClient: %+v", err)
+		}
+
+		props := resp.AccountProperties
+		if props == nil || props.Encryption == nil || !strings.EqualFold(string(props.Encryption.KeySource), string(storage.MicrosoftKeyvault)) || props.Encryption.KeyVaultProperties == nil {
+			return fmt.Errorf("Bad: Storage Account %q (Resource Group %q) isn't using a Customer Managed Key", storageAccountName, resourceGroup)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMStorageAccountCustomerManagedKeyDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).storageServiceClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_storage_account_customer_managed_key" {
+			continue
+		}
+
+		id, err := parseAzureResourceID(rs.Primary.Attributes["storage_account_id"])
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.GetProperties(ctx, id.ResourceGroup, id.Path["storageAccounts"])
+		if err != nil {
+			// the Storage Account has been removed too
+			return nil
+		}
+
+		if props := resp.AccountProperties; props != nil && props.Encryption != nil && strings.EqualFold(string(props.Encryption.KeySource), string(storage.MicrosoftKeyvault)) {
+			return fmt.Errorf("Storage Account %q (Resource Group %q) is still using a Customer Managed Key", id.Path["storageAccounts"], id.ResourceGroup)
+		}
+	}
+
+	return nil
+}
+
+func testAccAzureRMStorageAccountCustomerManagedKey_template(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_key_vault" "test" {
+  name                    = "acctestkv%s"
+  location                = "${azurerm_resource_group.test.location}"
+  resource_group_name     = "${azurerm_resource_group.test.name}"
+  tenant_id               = "${data.azurerm_client_config.current.tenant_id}"
+  enable_soft_delete      = true
+  enable_purge_protection = true
+
+  sku {
+    name = "standard"
+  }
+
+  access_policy {
+    tenant_id = "${data.azurerm_client_config.current.tenant_id}"
+    object_id = "${data.azurerm_client_config.current.service_principal_object_id}"
+
+    key_permissions = [
+      "create",
+      "delete",
+      "get",
+    ]
+
+    secret_permissions = [
+      "delete",
+      "get",
+      "set",
+    ]
+  }
+}
+
+resource "azurerm_key_vault_key" "test" {
+  name      = "key-%s"
+  vault_uri = "${azurerm_key_vault.test.vault_uri}"
+  key_type  = "RSA"
+  key_size  = 2048
+
+  key_opts = [
+    "decrypt",
+    "encrypt",
+    "sign",
+    "unwrapKey",
+    "verify",
+    "wrapKey",
+  ]
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  identity {
+    type = "SystemAssigned"
+  }
+}
+`, rInt, location, rString, rString, rString)
+}
+
+func testAccAzureRMStorageAccountCustomerManagedKey_accessPolicy(rInt int, rString string, location string) string {
+	template := testAccAzureRMStorageAccountCustomerManagedKey_template(rInt, rString, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_access_policy" "storage" {
+  vault_name          = "${azurerm_key_vault.test.name}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  tenant_id           = "${azurerm_storage_account.test.identity.0.tenant_id}"
+  object_id           = "${azurerm_storage_account.test.identity.0.principal_id}"
+
+  key_permissions = [
+    "get",
+    "unwrapKey",
+    "wrapKey",
+  ]
+}
+`, template)
+}
+
+func testAccAzureRMStorageAccountCustomerManagedKey_basic(rInt int, rString string, location string) string {
+	template := testAccAzureRMStorageAccountCustomerManagedKey_accessPolicy(rInt, rString, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_account_customer_managed_key" "test" {
+  storage_account_id = "${azurerm_storage_account.test.id}"
+  key_vault_id       = "${azurerm_key_vault.test.id}"
+  key_vault_key_id   = "${azurerm_key_vault_key.test.id}"
+
+  depends_on = ["azurerm_key_vault_access_policy.storage"]
+}
+`, template)
+}
+
+func testAccAzureRMStorageAccountCustomerManagedKey_versionless(rInt int, rString string, location string) string {
+	template := testAccAzureRMStorageAccountCustomerManagedKey_accessPolicy(rInt, rString, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_account_customer_managed_key" "test" {
+  storage_account_id = "${azurerm_storage_account.test.id}"
+  key_vault_id       = "${azurerm_key_vault.test.id}"
+  key_vault_key_id   = "${azurerm_key_vault.test.vault_uri}keys/${azurerm_key_vault_key.test.name}"
+
+  depends_on = ["azurerm_key_vault_access_policy.storage"]
+}
+`, template)
+}
+
+func testAccAzureRMStorageAccountCustomerManagedKey_missingAccessPolicy(rInt int, rString string, location string) string {
+	template := testAccAzureRMStorageAccountCustomerManagedKey_template(rInt, rString, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_account_customer_managed_key" "test" {
+  storage_account_id = "${azurerm_storage_account.test.id}"
+  key_vault_id       = "${azurerm_key_vault.test.id}"
+  key_vault_key_id   = "${azurerm_key_vault_key.test.id}"
+}
+`, template)
+}
@@ -0,0 +1,132 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-10-01/storage"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmStorageAccountKeyRotation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmStorageAccountKeyRotationCreate,
+		Read:   resourceArmStorageAccountKeyRotationRead,
+		Delete: resourceArmStorageAccountKeyRotationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"storage_account_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageAccountName,
+			},
+
+			"key_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"key1",
+					"key2",
+				}, false),
+			},
+
+			"rotation_triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"key_value": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceArmStorageAccountKeyRotationCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).storageServiceClient
+	ctx := meta.(*ArmClient).StopContext
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	storageAccountName := d.Get("storage_account_name").(string)
+	keyName := d.Get("key_name").(string)
+
+	account, err := client.GetProperties(ctx, resourceGroup, storageAccountName)
+	if err != nil {
+		if utils.ResponseWasNotFound(account.Response) {
+			return fmt.Errorf("Error: Storage Account %q was not found in Resource Group %q", storageAccountName, resourceGroup)
+		}
+		return fmt.Errorf("Error retrieving Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroup, err)
+	}
+
+	if account.ID == nil {
+		return fmt.Errorf("Error retrieving Storage Account %q (Resource Group %q): ID was nil", storageAccountName, resourceGroup)
+	}
+
+	log.Printf("[DEBUG] Regenerating Key %q for Storage Account %q (Resource Group %q)", keyName, storageAccountName, resourceGroup)
+	parameters := storage.AccountRegenerateKeyParameters{
+		KeyName: utils.String(keyName),
+	}
+	if _, err := client.RegenerateKey(ctx, resourceGroup, storageAccountName, parameters); err != nil {
+		return fmt.Errorf("Error regenerating Key %q for Storage Account %q (Resource Group %q): %+v", keyName, storageAccountName, resourceGroup, err)
+	}
+
+	// the cached key may no longer be valid, so ensure it's retrieved again for any data plane operations
+	invalidateKeyForStorageAccount(resourceGroup, storageAccountName)
+
+	d.SetId(fmt.Sprintf("%s/keys/%s", *account.ID, keyName))
+
+	return resourceArmStorageAccountKeyRotationRead(d, meta)
+}
+
+func resourceArmStorageAccountKeyRotationRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).storageServiceClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	storageAccountName := id.Path["storageAccounts"]
+	keyName := id.Path["keys"]
+
+	resp, err := client.ListKeys(ctx, resourceGroup, storageAccountName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Storage Account %q was not found in Resource Group %q - removing from state!", storageAccountName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error listing Keys for Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroup, err)
+	}
+
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("storage_account_name", storageAccountName)
+	d.Set("key_name", keyName)
+
+	if keys := resp.Keys; keys != nil {
+		for _, key := range *keys {
+			if key.KeyName != nil && strings.EqualFold(*key.KeyName, keyName) {
+				d.Set("key_value", key.Value)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceArmStorageAccountKeyRotationDelete(d *schema.ResourceData, meta interface{}) error {
+	// regenerating a key can't be undone, so there's nothing to do here other than remove it from the state
+	log.Printf("[DEBUG] Removing Key Rotation %q from the state - the Key is left unchanged", d.Id())
+	return nil
+}
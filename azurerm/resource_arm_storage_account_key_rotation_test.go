@@ -0,0 +1,102 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAzureRMStorageAccountKeyRotation_basic(t *testing.T) {
+	resourceName := "azurerm_storage_account_key_rotation.test"
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	location := testLocation()
+
+	var container storage.Container
+	var firstKey string
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMStorageAccountKeyRotation_basic(ri, rs, location, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageContainerExists("azurerm_storage_container.test", &container),
+					resource.TestCheckResourceAttrPair(resourceName, "key_value", "azurerm_storage_account.test", "primary_access_key"),
+					testCheckAzureRMStorageAccountKeyRotationValue(resourceName, &firstKey, false),
+				),
+			},
+			{
+				// the container should continue to work with the cached (now invalid) key
+				Config: testAccAzureRMStorageAccountKeyRotation_basic(ri, rs, location, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageContainerExists("azurerm_storage_container.test", &container),
+					resource.TestCheckResourceAttr(resourceName, "rotation_triggers.rotation", "second"),
+					testCheckAzureRMStorageAccountKeyRotationValue(resourceName, &firstKey, true),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMStorageAccountKeyRotationValue(resourceName string, previous *string, expectChanged bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		value := rs.Primary.Attributes["key_value"]
+		if value == "" {
+			return fmt.Errorf("Bad: `key_value` was empty for %s", resourceName)
+		}
+
+		if expectChanged && value == *previous {
+			return fmt.Errorf("Bad: expected the key to have been regenerated for %s but it was unchanged", resourceName)
+		}
+
+		*previous = value
+		return nil
+	}
+}
+
+func testAccAzureRMStorageAccountKeyRotation_basic(rInt int, rString string, location string, trigger string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "vhds"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  storage_account_name  = "${azurerm_storage_account.test.name}"
+  container_access_type = "private"
+}
+
+resource "azurerm_storage_account_key_rotation" "test" {
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_name = "${azurerm_storage_account.test.name}"
+  key_name             = "key1"
+
+  rotation_triggers {
+    rotation = "%s"
+  }
+
+  depends_on = ["azurerm_storage_container.test"]
+}
+`, rInt, location, rString, trigger)
+}
@@ -519,6 +519,74 @@ func TestAccAzureRMStorageAccount_networkRulesDeleted(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMStorageAccount_serviceProperties(t *testing.T) {
+	resourceName := "azurerm_storage_account.testsa"
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMStorageAccount_serviceProperties(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "blob_properties.0.cors_rule.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "blob_properties.0.cors_rule.0.allowed_methods.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "queue_properties.0.logging.0.retention_policy_days", "10"),
+					resource.TestCheckResourceAttr(resourceName, "queue_properties.0.hour_metrics.0.include_apis", "true"),
+					resource.TestCheckResourceAttr(resourceName, "table_properties.0.minute_metrics.0.retention_policy_days", "7"),
+				),
+			},
+			{
+				Config: testAccAzureRMStorageAccount_servicePropertiesUpdated(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "blob_properties.0.cors_rule.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "queue_properties.0.logging.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "queue_properties.0.hour_metrics.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "table_properties.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageAccount_staticWebsite(t *testing.T) {
+	resourceName := "azurerm_storage_account.testsa"
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMStorageAccount_staticWebsite(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "static_website.0.index_document", "index.html"),
+					resource.TestCheckResourceAttr(resourceName, "static_website.0.error_404_document", "404.html"),
+					resource.TestCheckResourceAttrSet(resourceName, "primary_web_endpoint"),
+				),
+			},
+			{
+				Config: testAccAzureRMStorageAccount_storageV2(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "static_website.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "primary_web_endpoint", ""),
+				),
+			},
+		},
+	})
+}
+
 func testCheckAzureRMStorageAccountExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// Ensure we have enough information in state to look up in API
@@ -1021,3 +1089,125 @@ resource "azurerm_storage_account" "testsa" {
 }
 `, rInt, location, rInt, rInt, rString)
 }
+
+func testAccAzureRMStorageAccount_serviceProperties(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+  name     = "acctestAzureRMSA-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+  name                     = "unlikely23exst2acct%s"
+  resource_group_name      = "${azurerm_resource_group.testrg.name}"
+  location                 = "${azurerm_resource_group.testrg.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  blob_properties {
+    cors_rule {
+      allowed_origins    = ["https://example.com"]
+      allowed_methods    = ["GET", "HEAD"]
+      allowed_headers    = ["*"]
+      exposed_headers    = ["x-ms-meta-*"]
+      max_age_in_seconds = 3600
+    }
+  }
+
+  queue_properties {
+    logging {
+      delete                = true
+      read                  = true
+      write                 = true
+      retention_policy_days = 10
+    }
+
+    hour_metrics {
+      enabled               = true
+      include_apis          = true
+      retention_policy_days = 10
+    }
+  }
+
+  table_properties {
+    minute_metrics {
+      enabled               = true
+      retention_policy_days = 7
+    }
+  }
+}
+`, rInt, location, rString)
+}
+
+func testAccAzureRMStorageAccount_servicePropertiesUpdated(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+  name     = "acctestAzureRMSA-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+  name                     = "unlikely23exst2acct%s"
+  resource_group_name      = "${azurerm_resource_group.testrg.name}"
+  location                 = "${azurerm_resource_group.testrg.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  blob_properties {
+    cors_rule {
+      allowed_origins    = ["https://example.com"]
+      allowed_methods    = ["GET", "HEAD"]
+      allowed_headers    = ["*"]
+      exposed_headers    = ["x-ms-meta-*"]
+      max_age_in_seconds = 3600
+    }
+
+    cors_rule {
+      allowed_origins    = ["https://admin.example.com"]
+      allowed_methods    = ["GET", "PUT", "DELETE"]
+      allowed_headers    = ["x-ms-blob-type", "content-type"]
+      exposed_headers    = ["*"]
+      max_age_in_seconds = 60
+    }
+  }
+
+  queue_properties {
+    cors_rule {
+      allowed_origins    = ["*"]
+      allowed_methods    = ["GET"]
+      allowed_headers    = ["*"]
+      exposed_headers    = ["*"]
+      max_age_in_seconds = 60
+    }
+  }
+}
+`, rInt, location, rString)
+}
+
+func testAccAzureRMStorageAccount_staticWebsite(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+  name     = "acctestAzureRMSA-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+  name                = "unlikely23exst2acct%s"
+  resource_group_name = "${azurerm_resource_group.testrg.name}"
+
+  location                 = "${azurerm_resource_group.testrg.location}"
+  account_kind             = "StorageV2"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  static_website {
+    index_document     = "index.html"
+    error_404_document = "404.html"
+  }
+
+  tags {
+    environment = "production"
+  }
+}
+`, rInt, location, rString)
+}
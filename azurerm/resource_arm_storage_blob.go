@@ -2,11 +2,14 @@ package azurerm
 
 import (
 	"bytes"
+	"context"
+	"crypto/md5"
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"net/url"
 	"os"
 	"runtime"
@@ -26,15 +29,16 @@ import (
 
 func resourceArmStorageBlob() *schema.Resource {
 	return &schema.Resource{
-		Create:        resourceArmStorageBlobCreate,
-		Read:          resourceArmStorageBlobRead,
-		Update:        resourceArmStorageBlobUpdate,
-		Delete:        resourceArmStorageBlobDelete,
+		Create:        retryOnStorageAuthenticationFailure(resourceArmStorageBlobCreate),
+		Read:          retryOnStorageAuthenticationFailure(resourceArmStorageBlobRead),
+		Update:        retryOnStorageAuthenticationFailure(resourceArmStorageBlobUpdate),
+		Delete:        retryOnStorageAuthenticationFailure(resourceArmStorageBlobDelete),
 		MigrateState:  resourceStorageBlobMigrateState,
 		SchemaVersion: 1,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceArmStorageBlobCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -61,7 +65,7 @@ func resourceArmStorageBlob() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice([]string{"block", "page"}, true),
+				ValidateFunc: validation.StringInSlice([]string{"append", "block", "page"}, true),
 			},
 
 			"size": {
@@ -83,14 +87,43 @@ func resourceArmStorageBlob() *schema.Resource {
 				Type:          schema.TypeString,
 				Optional:      true,
 				ForceNew:      true,
-				ConflictsWith: []string{"source_uri"},
+				ConflictsWith: []string{"source_content", "source_uri"},
+			},
+
+			"source_content": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"source", "source_uri"},
 			},
 
 			"source_uri": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				ForceNew:      true,
-				ConflictsWith: []string{"source"},
+				ConflictsWith: []string{"source", "source_content"},
+			},
+
+			"content_md5": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"access_tier": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"Archive",
+					"Cool",
+					"Hot",
+				}, true),
+				DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
+			},
+
+			"metadata": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validate.StorageMetaDataKeys,
 			},
 
 			"url": {
@@ -138,11 +171,20 @@ func resourceArmStorageBlobCreate(d *schema.ResourceData, meta interface{}) erro
 	containerName := d.Get("storage_container_name").(string)
 	sourceUri := d.Get("source_uri").(string)
 	contentType := d.Get("content_type").(string)
+	accessTier := d.Get("access_tier").(string)
 
 	log.Printf("[INFO] Creating blob %q in container %q within storage account %q", name, containerName, storageAccountName)
 	container := blobClient.GetContainerReference(containerName)
 	blob := container.GetBlobReference(name)
 
+	source, err := resourceArmStorageBlobOpenSource(d.Get("source").(string), d.Get("source_content").(string))
+	if err != nil {
+		return err
+	}
+	if source != nil {
+		defer utils.IoCloseAndLogError(source, fmt.Sprintf("Error closing source %s for Storage Blob %q after upload", source.name, name))
+	}
+
 	if sourceUri != "" {
 		options := &storage.CopyOptions{}
 		if err := blob.Copy(sourceUri, options); err != nil {
@@ -150,14 +192,28 @@ func resourceArmStorageBlobCreate(d *schema.ResourceData, meta interface{}) erro
 		}
 	} else {
 		switch strings.ToLower(blobType) {
+		case "append":
+			if source != nil {
+				attempts := d.Get("attempts").(int)
+
+				if err := resourceArmStorageBlobAppendUploadFromSource(containerName, name, source, contentType, blobClient, attempts); err != nil {
+					return fmt.Errorf("Error creating storage blob on Azure: %s", err)
+				}
+			} else {
+				options := &storage.PutBlobOptions{}
+
+				blob.Properties.ContentType = contentType
+				if err := blob.PutAppendBlob(options); err != nil {
+					return fmt.Errorf("Error creating storage blob on Azure: %s", err)
+				}
+			}
 		case "block":
 			options := &storage.PutBlobOptions{}
 			if err := blob.CreateBlockBlob(options); err != nil {
 				return fmt.Errorf("Error creating storage blob on Azure: %s", err)
 			}
 
-			source := d.Get("source").(string)
-			if source != "" {
+			if source != nil {
 				parallelism := d.Get("parallelism").(int)
 				attempts := d.Get("attempts").(int)
 
@@ -166,8 +222,7 @@ func resourceArmStorageBlobCreate(d *schema.ResourceData, meta interface{}) erro
 				}
 			}
 		case "page":
-			source := d.Get("source").(string)
-			if source != "" {
+			if source != nil {
 				parallelism := d.Get("parallelism").(int)
 				attempts := d.Get("attempts").(int)
 
@@ -187,29 +242,99 @@ func resourceArmStorageBlobCreate(d *schema.ResourceData, meta interface{}) erro
 		}
 	}
 
+	if source != nil && blobType != "" {
+		if err := resourceArmStorageBlobSetContentMD5(blob, source); err != nil {
+			return err
+		}
+	}
+
+	// a copied Blob retains the MetaData of the source Blob, so this is replaced with the MetaData from the configuration
+	if metaData := expandStorageBlobMetaData(d.Get("metadata").(map[string]interface{})); len(metaData) > 0 || sourceUri != "" {
+		log.Printf("[INFO] Setting the MetaData for blob %q in container %q within storage account %q", name, containerName, storageAccountName)
+		blob.Metadata = metaData
+		if err := blob.SetMetadata(&storage.SetBlobMetadataOptions{}); err != nil {
+			return fmt.Errorf("Error setting MetaData for Storage Blob %q (Container %q / Storage Account %q): %s", name, containerName, storageAccountName, err)
+		}
+	}
+
+	if accessTier != "" {
+		if err := resourceArmStorageBlobSetAccessTier(ctx, armClient, resourceGroupName, storageAccountName, blob, accessTier); err != nil {
+			return err
+		}
+	}
+
 	// gives us https://example.blob.core.windows.net/container/file.vhd
 	id := fmt.Sprintf("https://%s.blob.%s/%s/%s", storageAccountName, env.StorageEndpointSuffix, containerName, name)
 	d.SetId(id)
 	return resourceArmStorageBlobRead(d, meta)
 }
 
-type resourceArmStorageBlobPage struct {
-	offset  int64
-	section *io.SectionReader
+// resourceArmStorageBlobSource is the content being uploaded to a Storage Blob, either from a file or an inline string
+type resourceArmStorageBlobSource struct {
+	name   string
+	file   *os.File
+	reader *io.SectionReader
 }
 
-func resourceArmStorageBlobPageUploadFromSource(container, name, source, contentType string, client *storage.BlobStorageClient, parallelism, attempts int) error {
-	workerCount := parallelism * runtime.NumCPU()
+func resourceArmStorageBlobOpenSource(source, sourceContent string) (*resourceArmStorageBlobSource, error) {
+	if sourceContent != "" {
+		return &resourceArmStorageBlobSource{
+			name:   "`source_content`",
+			reader: io.NewSectionReader(strings.NewReader(sourceContent), 0, int64(len(sourceContent))),
+		}, nil
+	}
+
+	if source == "" {
+		return nil, nil
+	}
 
 	file, err := os.Open(source)
 	if err != nil {
-		return fmt.Errorf("Error opening source file for upload %q: %s", source, err)
+		return nil, fmt.Errorf("Error opening source file for upload %q: %s", source, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		utils.IoCloseAndLogError(file, fmt.Sprintf("Error closing source file %q", source))
+		return nil, fmt.Errorf("Could not stat file %q: %s", source, err)
+	}
+
+	return &resourceArmStorageBlobSource{
+		name:   fmt.Sprintf("file %q", source),
+		file:   file,
+		reader: io.NewSectionReader(file, 0, info.Size()),
+	}, nil
+}
+
+func (s *resourceArmStorageBlobSource) Close() error {
+	if s.file == nil {
+		return nil
+	}
+
+	return s.file.Close()
+}
+
+// contentMD5 returns the base64 encoded MD5 hash of the source, in the same format as the `Content-MD5` of a Storage Blob
+func (s *resourceArmStorageBlobSource) contentMD5() (string, error) {
+	hash := md5.New()
+	if _, err := io.Copy(hash, io.NewSectionReader(s.reader, 0, s.reader.Size())); err != nil {
+		return "", fmt.Errorf("Error computing the MD5 hash of source %s: %s", s.name, err)
 	}
-	defer utils.IoCloseAndLogError(file, fmt.Sprintf("Error closing Storage Blob `%s` file `%s` after upload", name, source))
 
-	blobSize, pageList, err := resourceArmStorageBlobPageSplit(file)
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
+}
+
+type resourceArmStorageBlobPage struct {
+	offset  int64
+	section *io.SectionReader
+}
+
+func resourceArmStorageBlobPageUploadFromSource(container, name string, source *resourceArmStorageBlobSource, contentType string, client *storage.BlobStorageClient, parallelism, attempts int) error {
+	workerCount := parallelism * runtime.NumCPU()
+
+	blobSize, pageList, err := resourceArmStorageBlobPageSplit(source.reader)
 	if err != nil {
-		return fmt.Errorf("Error splitting source file %q into pages: %s", source, err)
+		return fmt.Errorf("Error splitting source %s into pages: %s", source.name, err)
 	}
 
 	options := &storage.PutBlobOptions{}
@@ -238,7 +363,7 @@ func resourceArmStorageBlobPageUploadFromSource(container, name, source, content
 		go resourceArmStorageBlobPageUploadWorker(resourceArmStorageBlobPageUploadContext{
 			container: container,
 			name:      name,
-			source:    source,
+			source:    source.name,
 			blobSize:  blobSize,
 			client:    client,
 			pages:     pages,
@@ -251,26 +376,21 @@ func resourceArmStorageBlobPageUploadFromSource(container, name, source, content
 	wg.Wait()
 
 	if len(errors) > 0 {
-		return fmt.Errorf("Error while uploading source file %q: %s", source, <-errors)
+		return fmt.Errorf("Error while uploading source %s: %s", source.name, <-errors)
 	}
 
 	return nil
 }
 
-func resourceArmStorageBlobPageSplit(file *os.File) (int64, []resourceArmStorageBlobPage, error) {
+func resourceArmStorageBlobPageSplit(source *io.SectionReader) (int64, []resourceArmStorageBlobPage, error) {
 	const (
 		minPageSize int64 = 4 * 1024
 		maxPageSize int64 = 4 * 1024 * 1024
 	)
 
-	info, err := file.Stat()
-	if err != nil {
-		return int64(0), nil, fmt.Errorf("Could not stat file %q: %s", file.Name(), err)
-	}
-
-	blobSize := info.Size()
-	if info.Size()%minPageSize != 0 {
-		blobSize = info.Size() + (minPageSize - (info.Size() % minPageSize))
+	blobSize := source.Size()
+	if source.Size()%minPageSize != 0 {
+		blobSize = source.Size() + (minPageSize - (source.Size() % minPageSize))
 	}
 
 	emptyPage := make([]byte, minPageSize)
@@ -284,7 +404,7 @@ func resourceArmStorageBlobPageSplit(file *os.File) (int64, []resourceArmStorage
 	var currentRange byteRange
 	for i := int64(0); i < blobSize; i += minPageSize {
 		pageBuf := make([]byte, minPageSize)
-		_, err = file.ReadAt(pageBuf, i)
+		_, err := source.ReadAt(pageBuf, i)
 		if err != nil && err != io.EOF {
 			return int64(0), nil, fmt.Errorf("Could not read chunk at %d: %s", i, err)
 		}
@@ -311,11 +431,11 @@ func resourceArmStorageBlobPageSplit(file *os.File) (int64, []resourceArmStorage
 	for _, nonEmptyRange := range nonEmptyRanges {
 		pages = append(pages, resourceArmStorageBlobPage{
 			offset:  nonEmptyRange.offset,
-			section: io.NewSectionReader(file, nonEmptyRange.offset, nonEmptyRange.length),
+			section: io.NewSectionReader(source, nonEmptyRange.offset, nonEmptyRange.length),
 		})
 	}
 
-	return info.Size(), pages, nil
+	return source.Size(), pages, nil
 }
 
 type resourceArmStorageBlobPageUploadContext struct {
@@ -342,7 +462,7 @@ func resourceArmStorageBlobPageUploadWorker(ctx resourceArmStorageBlobPageUpload
 		chunk := make([]byte, size)
 		_, err := page.section.Read(chunk)
 		if err != nil && err != io.EOF {
-			ctx.errors <- fmt.Errorf("Error reading source file %q at offset %d: %s", ctx.source, page.offset, err)
+			ctx.errors <- fmt.Errorf("Error reading source %s at offset %d: %s", ctx.source, page.offset, err)
 			ctx.wg.Done()
 			continue
 		}
@@ -362,7 +482,7 @@ func resourceArmStorageBlobPageUploadWorker(ctx resourceArmStorageBlobPageUpload
 			}
 		}
 		if err != nil {
-			ctx.errors <- fmt.Errorf("Error writing page at offset %d for file %q: %s", page.offset, ctx.source, err)
+			ctx.errors <- fmt.Errorf("Error writing page at offset %d for source %s: %s", page.offset, ctx.source, err)
 			ctx.wg.Done()
 			continue
 		}
@@ -376,18 +496,32 @@ type resourceArmStorageBlobBlock struct {
 	id      string
 }
 
-func resourceArmStorageBlobBlockUploadFromSource(container, name, source, contentType string, client *storage.BlobStorageClient, parallelism, attempts int) error {
-	workerCount := parallelism * runtime.NumCPU()
+func resourceArmStorageBlobBlockUploadFromSource(container, name string, source *resourceArmStorageBlobSource, contentType string, client *storage.BlobStorageClient, parallelism, attempts int) error {
+	blockList, err := resourceArmStorageBlobBlockUploadBlocks(container, name, source, client, parallelism, attempts)
+	if err != nil {
+		return err
+	}
 
-	file, err := os.Open(source)
+	containerReference := client.GetContainerReference(container)
+	blobReference := containerReference.GetBlobReference(name)
+	blobReference.Properties.ContentType = contentType
+	options := &storage.PutBlockListOptions{}
+	err = blobReference.PutBlockList(blockList, options)
 	if err != nil {
-		return fmt.Errorf("Error opening source file for upload %q: %s", source, err)
+		return fmt.Errorf("Error updating block list for source %s: %s", source.name, err)
 	}
-	defer utils.IoCloseAndLogError(file, fmt.Sprintf("Error closing Storage Blob `%s` file `%s` after upload", name, source))
 
-	blockList, parts, err := resourceArmStorageBlobBlockSplit(file)
+	return nil
+}
+
+// resourceArmStorageBlobBlockUploadBlocks uploads the source as uncommitted blocks using a pool of workers, returning
+// the list of blocks which needs to be committed to complete the upload
+func resourceArmStorageBlobBlockUploadBlocks(container, name string, source *resourceArmStorageBlobSource, client *storage.BlobStorageClient, parallelism, attempts int) ([]storage.Block, error) {
+	workerCount := parallelism * runtime.NumCPU()
+
+	blockList, parts, err := resourceArmStorageBlobBlockSplit(source)
 	if err != nil {
-		return fmt.Errorf("Error reading and splitting source file for upload %q: %s", source, err)
+		return nil, fmt.Errorf("Error reading and splitting source %s for upload: %s", source.name, err)
 	}
 
 	wg := &sync.WaitGroup{}
@@ -403,7 +537,7 @@ func resourceArmStorageBlobBlockUploadFromSource(container, name, source, conten
 	for i := 0; i < workerCount; i++ {
 		go resourceArmStorageBlobBlockUploadWorker(resourceArmStorageBlobBlockUploadContext{
 			client:    client,
-			source:    source,
+			source:    source.name,
 			container: container,
 			name:      name,
 			blocks:    blocks,
@@ -416,22 +550,13 @@ func resourceArmStorageBlobBlockUploadFromSource(container, name, source, conten
 	wg.Wait()
 
 	if len(errors) > 0 {
-		return fmt.Errorf("Error while uploading source file %q: %s", source, <-errors)
-	}
-
-	containerReference := client.GetContainerReference(container)
-	blobReference := containerReference.GetBlobReference(name)
-	blobReference.Properties.ContentType = contentType
-	options := &storage.PutBlockListOptions{}
-	err = blobReference.PutBlockList(blockList, options)
-	if err != nil {
-		return fmt.Errorf("Error updating block list for source file %q: %s", source, err)
+		return nil, fmt.Errorf("Error while uploading source %s: %s", source.name, <-errors)
 	}
 
-	return nil
+	return blockList, nil
 }
 
-func resourceArmStorageBlobBlockSplit(file *os.File) ([]storage.Block, []resourceArmStorageBlobBlock, error) {
+func resourceArmStorageBlobBlockSplit(source *resourceArmStorageBlobSource) ([]storage.Block, []resourceArmStorageBlobBlock, error) {
 	const (
 		idSize          = 64
 		blockSize int64 = 4 * 1024 * 1024
@@ -439,20 +564,15 @@ func resourceArmStorageBlobBlockSplit(file *os.File) ([]storage.Block, []resourc
 	var parts []resourceArmStorageBlobBlock
 	var blockList []storage.Block
 
-	info, err := file.Stat()
-	if err != nil {
-		return nil, nil, fmt.Errorf("Error stating source file %q: %s", file.Name(), err)
-	}
-
-	for i := int64(0); i < info.Size(); i = i + blockSize {
+	for i := int64(0); i < source.reader.Size(); i = i + blockSize {
 		entropy := make([]byte, idSize)
-		_, err = rand.Read(entropy)
+		_, err := rand.Read(entropy)
 		if err != nil {
-			return nil, nil, fmt.Errorf("Error generating a random block ID for source file %q: %s", file.Name(), err)
+			return nil, nil, fmt.Errorf("Error generating a random block ID for source %s: %s", source.name, err)
 		}
 
 		sectionSize := blockSize
-		remainder := info.Size() - i
+		remainder := source.reader.Size() - i
 		if remainder < blockSize {
 			sectionSize = remainder
 		}
@@ -466,7 +586,7 @@ func resourceArmStorageBlobBlockSplit(file *os.File) ([]storage.Block, []resourc
 
 		parts = append(parts, resourceArmStorageBlobBlock{
 			id:      block.ID,
-			section: io.NewSectionReader(file, i, sectionSize),
+			section: io.NewSectionReader(source.reader, i, sectionSize),
 		})
 	}
 
@@ -490,7 +610,7 @@ func resourceArmStorageBlobBlockUploadWorker(ctx resourceArmStorageBlobBlockUplo
 
 		_, err := block.section.Read(buffer)
 		if err != nil {
-			ctx.errors <- fmt.Errorf("Error reading source file %q: %s", ctx.source, err)
+			ctx.errors <- fmt.Errorf("Error reading source %s: %s", ctx.source, err)
 			ctx.wg.Done()
 			continue
 		}
@@ -504,7 +624,7 @@ func resourceArmStorageBlobBlockUploadWorker(ctx resourceArmStorageBlobBlockUplo
 			}
 		}
 		if err != nil {
-			ctx.errors <- fmt.Errorf("Error uploading block %q for source file %q: %s", block.id, ctx.source, err)
+			ctx.errors <- fmt.Errorf("Error uploading block %q for source %s: %s", block.id, ctx.source, err)
 			ctx.wg.Done()
 			continue
 		}
@@ -513,6 +633,220 @@ func resourceArmStorageBlobBlockUploadWorker(ctx resourceArmStorageBlobBlockUplo
 	}
 }
 
+func resourceArmStorageBlobAppendUploadFromSource(container, name string, source *resourceArmStorageBlobSource, contentType string, client *storage.BlobStorageClient, attempts int) error {
+	const blockSize int64 = 4 * 1024 * 1024
+
+	containerReference := client.GetContainerReference(container)
+	blob := containerReference.GetBlobReference(name)
+	blob.Properties.ContentType = contentType
+	if err := blob.PutAppendBlob(&storage.PutBlobOptions{}); err != nil {
+		return fmt.Errorf("Error creating storage blob on Azure: %s", err)
+	}
+
+	// blocks are appended in the order they're sent, so unlike Block and Page Blobs these can't be uploaded in parallel
+	for offset := int64(0); offset < source.reader.Size(); offset += blockSize {
+		size := blockSize
+		if remainder := source.reader.Size() - offset; remainder < blockSize {
+			size = remainder
+		}
+
+		chunk := make([]byte, size)
+		if _, err := source.reader.ReadAt(chunk, offset); err != nil && err != io.EOF {
+			return fmt.Errorf("Error reading source %s at offset %d: %s", source.name, offset, err)
+		}
+
+		var err error
+		position := uint(offset)
+		for i := 0; i < attempts; i++ {
+			// the append position ensures a block which was committed during a failed attempt isn't appended twice
+			options := &storage.AppendBlockOptions{
+				AppendPosition: &position,
+				ContentMD5:     true,
+			}
+			if err = blob.AppendBlock(chunk, options); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("Error appending block at offset %d for source %s: %s", offset, source.name, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceArmStorageBlobUploadFromSource(d *schema.ResourceData, blob *storage.Blob, source *resourceArmStorageBlobSource, client *storage.BlobStorageClient) error {
+	contentType := d.Get("content_type").(string)
+	parallelism := d.Get("parallelism").(int)
+	attempts := d.Get("attempts").(int)
+
+	switch strings.ToLower(string(blob.Properties.BlobType)) {
+	case strings.ToLower(string(storage.BlobTypeAppend)):
+		return resourceArmStorageBlobAppendUploadFromSource(blob.Container.Name, blob.Name, source, contentType, client, attempts)
+	case strings.ToLower(string(storage.BlobTypeBlock)):
+		return resourceArmStorageBlobBlockUploadFromSource(blob.Container.Name, blob.Name, source, contentType, client, parallelism, attempts)
+	case strings.ToLower(string(storage.BlobTypePage)):
+		return resourceArmStorageBlobPageUploadFromSource(blob.Container.Name, blob.Name, source, contentType, client, parallelism, attempts)
+	}
+
+	return fmt.Errorf("Unable to upload source %s to Storage Blob %q: unsupported Blob Type %q", source.name, blob.Name, blob.Properties.BlobType)
+}
+
+// resourceArmStorageBlobSetContentMD5 sets the `Content-MD5` of the Blob to the hash of the source - since this isn't
+// calculated by the API for Blobs which were uploaded in blocks, pages or appended to
+func resourceArmStorageBlobSetContentMD5(blob *storage.Blob, source *resourceArmStorageBlobSource) error {
+	contentMD5, err := source.contentMD5()
+	if err != nil {
+		return err
+	}
+
+	err = updateStorageProperties(
+		func() error { return blob.GetProperties(&storage.GetBlobPropertiesOptions{}) },
+		func() { blob.Properties.ContentMD5 = contentMD5 },
+		func() error { return blob.SetProperties(&storage.SetBlobPropertiesOptions{}) })
+	if err != nil {
+		return fmt.Errorf("Error setting the Content MD5 of blob %s (container %s): %+v", blob.Name, blob.Container.Name, err)
+	}
+
+	return nil
+}
+
+// updateStorageProperties retrieves the existing properties of a Blob or File using `get`, modifies them using `update`
+// and then sends them using `set` - since the properties are replaced as a whole, those which aren't being modified
+// need to be sent again with their existing values
+func updateStorageProperties(get func() error, update func(), set func() error) error {
+	if err := get(); err != nil {
+		return fmt.Errorf("Error retrieving the existing properties: %+v", err)
+	}
+
+	update()
+
+	if err := set(); err != nil {
+		return fmt.Errorf("Error setting the properties: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceArmStorageBlobSetAccessTier(ctx context.Context, armClient *ArmClient, resourceGroup, storageAccountName string, blob *storage.Blob, accessTier string) error {
+	log.Printf("[INFO] Setting the Access Tier for blob %q in container %q to %q", blob.Name, blob.Container.Name, accessTier)
+
+	// the Storage SDK doesn't support Set Blob Tier, so this request is sent directly
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s?comp=tier", blob.GetURL()), nil)
+	if err != nil {
+		return fmt.Errorf("Error building the request to set the Access Tier of blob %s (container %s): %+v", blob.Name, blob.Container.Name, err)
+	}
+	req.Header.Set("x-ms-access-tier", accessTier)
+
+	resp, err := armClient.sendStorageDataPlaneRequest(ctx, resourceGroup, storageAccountName, true, req)
+	if err != nil {
+		return fmt.Errorf("Error setting the Access Tier of blob %s (container %s): %+v", blob.Name, blob.Container.Name, err)
+	}
+	defer utils.IoCloseAndLogError(resp.Body, fmt.Sprintf("Error closing the response body when setting the Access Tier of blob %s", blob.Name))
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("Error setting the Access Tier of blob %s (container %s): unexpected status %d (%s)", blob.Name, blob.Container.Name, resp.StatusCode, resp.Header.Get("x-ms-error-code"))
+	}
+
+	return nil
+}
+
+// storageBlobAccessTierSender captures the Access Tier of a blob from the response to Get Blob Properties, since the
+// version of the Storage SDK in use doesn't expose it - this avoids sending an additional request to retrieve it
+type storageBlobAccessTierSender struct {
+	sender     storage.Sender
+	accessTier string
+}
+
+func (s *storageBlobAccessTierSender) Send(c *storage.Client, req *http.Request) (*http.Response, error) {
+	resp, err := s.sender.Send(c, req)
+	if resp != nil && req.Method == http.MethodHead {
+		// this header is only returned for Block Blobs within Blob Storage and General Purpose v2 accounts
+		s.accessTier = resp.Header.Get("x-ms-access-tier")
+	}
+	return resp, err
+}
+
+func expandStorageBlobMetaData(input map[string]interface{}) storage.BlobMetadata {
+	output := make(storage.BlobMetadata)
+
+	for k, v := range input {
+		output[k] = v.(string)
+	}
+
+	return output
+}
+
+func flattenStorageBlobMetaData(input storage.BlobMetadata) map[string]interface{} {
+	output := make(map[string]interface{})
+
+	for k, v := range input {
+		output[k] = v
+	}
+
+	return output
+}
+
+func resourceArmStorageBlobCustomizeDiff(d *schema.ResourceDiff, _ interface{}) error {
+	blobType := d.Get("type").(string)
+	if accessTier := d.Get("access_tier").(string); accessTier != "" && blobType != "" && !strings.EqualFold(blobType, "block") {
+		return fmt.Errorf("`access_tier` can only be set for Block Blobs")
+	}
+
+	// Page Blobs are uploaded sparsely and don't have a `content_md5` to compare against
+	if strings.EqualFold(blobType, "page") {
+		return nil
+	}
+
+	if !d.NewValueKnown("source") || !d.NewValueKnown("source_content") {
+		return nil
+	}
+
+	return diffContentMD5ForStorageSource(d, d.Get("source").(string), d.Get("source_content").(string))
+}
+
+// diffContentMD5ForStorageSource compares the MD5 hash of the source against the `content_md5` of the resource, so that
+// the content is uploaded again when it differs from that which was uploaded
+func diffContentMD5ForStorageSource(d *schema.ResourceDiff, sourcePath, sourceContent string) error {
+	// the content of a new resource is hashed when it's uploaded, and there's nothing to compare against for resources
+	// created before `content_md5` was tracked (or uploaded without a hash) - so these aren't planned to be uploaded again
+	if d.Id() == "" {
+		return nil
+	}
+	if existing, _ := d.GetChange("content_md5"); existing.(string) == "" {
+		return nil
+	}
+
+	if sourcePath != "" {
+		// the file may be created by another resource during the apply, in which case it'll be hashed then
+		if _, err := os.Stat(sourcePath); os.IsNotExist(err) {
+			return nil
+		}
+	}
+
+	source, err := resourceArmStorageBlobOpenSource(sourcePath, sourceContent)
+	if err != nil {
+		return err
+	}
+	if source == nil {
+		return nil
+	}
+	defer utils.IoCloseAndLogError(source, fmt.Sprintf("Error closing source %s after hashing", source.name))
+
+	contentMD5, err := source.contentMD5()
+	if err != nil {
+		return err
+	}
+
+	if contentMD5 != d.Get("content_md5").(string) {
+		if err := d.SetNew("content_md5", contentMD5); err != nil {
+			return fmt.Errorf("Error setting `content_md5`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
 func resourceArmStorageBlobUpdate(d *schema.ResourceData, meta interface{}) error {
 	armClient := meta.(*ArmClient)
 	ctx := armClient.StopContext
@@ -542,17 +876,59 @@ func resourceArmStorageBlobUpdate(d *schema.ResourceData, meta interface{}) erro
 	container := blobClient.GetContainerReference(id.containerName)
 	blob := container.GetBlobReference(id.blobName)
 
+	// the Blob Type is needed to upload the source again
+	if err := blob.GetProperties(&storage.GetBlobPropertiesOptions{}); err != nil {
+		return fmt.Errorf("Error getting properties of blob %s (container %s, storage account %s): %+v", id.blobName, id.containerName, id.storageAccountName, err)
+	}
+
+	reuploaded := false
+	if d.HasChange("content_md5") || d.HasChange("source_content") {
+		source, err := resourceArmStorageBlobOpenSource(d.Get("source").(string), d.Get("source_content").(string))
+		if err != nil {
+			return err
+		}
+
+		if source != nil {
+			defer utils.IoCloseAndLogError(source, fmt.Sprintf("Error closing source %s for Storage Blob %q after upload", source.name, id.blobName))
+
+			log.Printf("[INFO] The content of source %s has changed - uploading blob %q in container %q again", source.name, id.blobName, id.containerName)
+			if err := resourceArmStorageBlobUploadFromSource(d, blob, source, blobClient); err != nil {
+				return fmt.Errorf("Error uploading blob %s (container %s, storage account %s): %s", id.blobName, id.containerName, id.storageAccountName, err)
+			}
+
+			if err := resourceArmStorageBlobSetContentMD5(blob, source); err != nil {
+				return err
+			}
+
+			reuploaded = true
+		}
+	}
+
 	if d.HasChange("content_type") {
-		blob.Properties.ContentType = d.Get("content_type").(string)
+		err = updateStorageProperties(
+			func() error { return blob.GetProperties(&storage.GetBlobPropertiesOptions{}) },
+			func() { blob.Properties.ContentType = d.Get("content_type").(string) },
+			func() error { return blob.SetProperties(&storage.SetBlobPropertiesOptions{}) })
+		if err != nil {
+			return fmt.Errorf("Error setting properties of blob %s (container %s, storage account %s): %+v", id.blobName, id.containerName, id.storageAccountName, err)
+		}
 	}
 
-	options := &storage.SetBlobPropertiesOptions{}
-	err = blob.SetProperties(options)
-	if err != nil {
-		return fmt.Errorf("Error setting properties of blob %s (container %s, storage account %s): %+v", id.blobName, id.containerName, id.storageAccountName, err)
+	// uploading the Blob again replaces the MetaData, so this needs to be set again too
+	if d.HasChange("metadata") || reuploaded {
+		blob.Metadata = expandStorageBlobMetaData(d.Get("metadata").(map[string]interface{}))
+		if err := blob.SetMetadata(&storage.SetBlobMetadataOptions{}); err != nil {
+			return fmt.Errorf("Error setting MetaData for blob %s (container %s, storage account %s): %+v", id.blobName, id.containerName, id.storageAccountName, err)
+		}
 	}
 
-	return nil
+	if accessTier := d.Get("access_tier").(string); accessTier != "" && (d.HasChange("access_tier") || reuploaded) {
+		if err := resourceArmStorageBlobSetAccessTier(ctx, armClient, *resourceGroup, id.storageAccountName, blob, accessTier); err != nil {
+			return err
+		}
+	}
+
+	return resourceArmStorageBlobRead(d, meta)
 }
 
 func resourceArmStorageBlobRead(d *schema.ResourceData, meta interface{}) error {
@@ -573,7 +949,7 @@ func resourceArmStorageBlobRead(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Unable to determine Resource Group for Storage Account %q", id.storageAccountName)
 	}
 
-	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(ctx, *resourceGroup, id.storageAccountName)
+	storageClient, accountExists, err := armClient.getStorageClientForStorageAccount(ctx, *resourceGroup, id.storageAccountName, true)
 	if err != nil {
 		return err
 	}
@@ -583,6 +959,12 @@ func resourceArmStorageBlobRead(d *schema.ResourceData, meta interface{}) error
 		return nil
 	}
 
+	accessTierSender := &storageBlobAccessTierSender{
+		sender: storageClient.Sender,
+	}
+	storageClient.Sender = accessTierSender
+	blobClient := storageClient.GetBlobService()
+
 	log.Printf("[INFO] Checking for existence of storage blob %q in container %q.", id.blobName, id.containerName)
 	container := blobClient.GetContainerReference(id.containerName)
 	blob := container.GetBlobReference(id.blobName)
@@ -609,12 +991,23 @@ func resourceArmStorageBlobRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("resource_group_name", resourceGroup)
 
 	d.Set("content_type", blob.Properties.ContentType)
+	d.Set("content_md5", blob.Properties.ContentMD5)
 
 	d.Set("source_uri", blob.Properties.CopySource)
 
 	blobType := strings.ToLower(strings.Replace(string(blob.Properties.BlobType), "Blob", "", 1))
 	d.Set("type", blobType)
 
+	if err := d.Set("metadata", flattenStorageBlobMetaData(blob.Metadata)); err != nil {
+		return fmt.Errorf("Error setting `metadata`: %+v", err)
+	}
+
+	accessTier := ""
+	if blob.Properties.BlobType == storage.BlobTypeBlock {
+		accessTier = accessTierSender.accessTier
+	}
+	d.Set("access_tier", accessTier)
+
 	u := blob.GetURL()
 	if u == "" {
 		log.Printf("[INFO] URL for %q is empty", id.blobName)
@@ -0,0 +1,671 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+const storageBlobDirectoryDefaultContentType = "application/octet-stream"
+
+func resourceArmStorageBlobDirectory() *schema.Resource {
+	return &schema.Resource{
+		Create: retryOnStorageAuthenticationFailure(resourceArmStorageBlobDirectoryCreateUpdate),
+		Read:   retryOnStorageAuthenticationFailure(resourceArmStorageBlobDirectoryRead),
+		Update: retryOnStorageAuthenticationFailure(resourceArmStorageBlobDirectoryCreateUpdate),
+		Delete: retryOnStorageAuthenticationFailure(resourceArmStorageBlobDirectoryDelete),
+		Importer: &schema.ResourceImporter{
+			State: resourceArmStorageBlobDirectoryImport,
+		},
+		CustomizeDiff: resourceArmStorageBlobDirectoryCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"storage_account_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageAccountName,
+			},
+
+			"storage_container_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"prefix": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageBlobDirectoryPrefix,
+			},
+
+			"source": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"include": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateArmStorageBlobDirectoryPattern,
+				},
+			},
+
+			"exclude": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateArmStorageBlobDirectoryPattern,
+				},
+			},
+
+			"content_types": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validateArmStorageBlobDirectoryContentTypes,
+			},
+
+			"parallelism": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      8,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"attempts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			// a mapping of the names of the Blobs uploaded by this resource (relative to the prefix) to their Content MD5 -
+			// only these Blobs are deleted, so that other Blobs within the Container are left untouched
+			"files": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceArmStorageBlobDirectoryCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	storageAccountName := d.Get("storage_account_name").(string)
+	containerName := d.Get("storage_container_name").(string)
+	prefix := d.Get("prefix").(string)
+
+	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(ctx, resourceGroup, storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q Not Found", storageAccountName)
+	}
+
+	files, err := resourceArmStorageBlobDirectoryWalk(d.Get("source").(string), d.Get("include").([]interface{}), d.Get("exclude").([]interface{}), d.Get("content_types").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	container := blobClient.GetContainerReference(containerName)
+	existing, err := listStorageBlobsWithPrefix(container, prefix)
+	if err != nil {
+		return fmt.Errorf("Error listing Blobs with the prefix %q (Container %q / Storage Account %q): %s", prefix, containerName, storageAccountName, err)
+	}
+
+	parallelism := d.Get("parallelism").(int)
+	attempts := d.Get("attempts").(int)
+
+	// only the Blobs which were previously uploaded by this resource are deleted when their file is removed
+	previousFiles, _ := d.GetChange("files")
+
+	uploaded := 0
+	for _, file := range files {
+		blob := container.GetBlobReference(prefix + file.name)
+
+		if current, ok := existing[blob.Name]; ok && current.Properties.ContentMD5 == file.contentMD5 {
+			if current.Properties.ContentType != file.contentType {
+				if err := resourceArmStorageBlobDirectorySetContentType(blob, file.contentType); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		log.Printf("[DEBUG] Uploading file %q to Blob %q (Container %q / Storage Account %q)", file.path, blob.Name, containerName, storageAccountName)
+		if err := resourceArmStorageBlobDirectoryUpload(blob, file, blobClient, parallelism, attempts); err != nil {
+			return fmt.Errorf("Error uploading Blob %q (Container %q / Storage Account %q): %s", blob.Name, containerName, storageAccountName, err)
+		}
+		uploaded++
+	}
+
+	deleted := 0
+	for relativeName := range previousFiles.(map[string]interface{}) {
+		if _, ok := files[relativeName]; ok {
+			continue
+		}
+
+		name := prefix + relativeName
+		if _, ok := existing[name]; !ok {
+			continue
+		}
+
+		log.Printf("[DEBUG] Deleting Blob %q (Container %q / Storage Account %q) since the file no longer exists", name, containerName, storageAccountName)
+		blob := container.GetBlobReference(name)
+		if _, err := blob.DeleteIfExists(&storage.DeleteBlobOptions{}); err != nil {
+			return fmt.Errorf("Error deleting Blob %q (Container %q / Storage Account %q): %s", name, containerName, storageAccountName, err)
+		}
+		deleted++
+	}
+
+	log.Printf("[INFO] Synchronised %d files to the prefix %q (Container %q / Storage Account %q): %d uploaded, %d deleted", len(files), prefix, containerName, storageAccountName, uploaded, deleted)
+
+	d.SetId(storageBlobDirectoryID(storageAccountName, containerName, prefix, armClient.environment))
+
+	// the Blobs which have been uploaded are tracked, so that they can be read back (and deleted) later
+	hashes := make(map[string]interface{})
+	for name, file := range files {
+		hashes[name] = file.contentMD5
+	}
+	if err := d.Set("files", hashes); err != nil {
+		return fmt.Errorf("Error setting `files`: %+v", err)
+	}
+
+	return resourceArmStorageBlobDirectoryRead(d, meta)
+}
+
+func resourceArmStorageBlobDirectoryRead(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageBlobDirectoryID(d.Id(), armClient.environment)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup, err := determineResourceGroupForStorageAccount(id.storageAccountName, armClient)
+	if err != nil {
+		return err
+	}
+	if resourceGroup == nil {
+		log.Printf("[DEBUG] Unable to determine Resource Group for Storage Account %q - removing Blob Directory %q from state", id.storageAccountName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(ctx, *resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		log.Printf("[DEBUG] Storage Account %q not found, removing Blob Directory %q from state", id.storageAccountName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	container := blobClient.GetContainerReference(id.containerName)
+	exists, err := container.Exists()
+	if err != nil {
+		return fmt.Errorf("Error checking if Container %q exists (Storage Account %q): %s", id.containerName, id.storageAccountName, err)
+	}
+	if !exists {
+		log.Printf("[INFO] Container %q no longer exists in Storage Account %q, removing Blob Directory %q from state...", id.containerName, id.storageAccountName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	blobs, err := listStorageBlobsWithPrefix(container, id.prefix)
+	if err != nil {
+		return fmt.Errorf("Error listing Blobs with the prefix %q (Container %q / Storage Account %q): %s", id.prefix, id.containerName, id.storageAccountName, err)
+	}
+
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("storage_account_name", id.storageAccountName)
+	d.Set("storage_container_name", id.containerName)
+	d.Set("prefix", id.prefix)
+
+	// only the Blobs uploaded by this resource are read - so any Blobs which have been removed are uploaded again, and
+	// other Blobs with the same prefix aren't tracked (and as such won't be deleted)
+	files := make(map[string]interface{})
+	for relativeName := range d.Get("files").(map[string]interface{}) {
+		if blob, ok := blobs[id.prefix+relativeName]; ok {
+			files[relativeName] = blob.Properties.ContentMD5
+		}
+	}
+	if err := d.Set("files", files); err != nil {
+		return fmt.Errorf("Error setting `files`: %+v", err)
+	}
+
+	return nil
+}
+
+// resourceArmStorageBlobDirectoryImport tracks all of the Blobs which currently exist with the prefix, since there's
+// no way of knowing which of them were uploaded from the `source` directory - Read then refreshes these as usual
+func resourceArmStorageBlobDirectoryImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageBlobDirectoryID(d.Id(), armClient.environment)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceGroup, err := determineResourceGroupForStorageAccount(id.storageAccountName, armClient)
+	if err != nil {
+		return nil, err
+	}
+	if resourceGroup == nil {
+		return nil, fmt.Errorf("Unable to determine Resource Group for Storage Account %q", id.storageAccountName)
+	}
+
+	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(ctx, *resourceGroup, id.storageAccountName)
+	if err != nil {
+		return nil, err
+	}
+	if !accountExists {
+		return nil, fmt.Errorf("Storage Account %q (Resource Group %q) was not found", id.storageAccountName, *resourceGroup)
+	}
+
+	container := blobClient.GetContainerReference(id.containerName)
+	blobs, err := listStorageBlobsWithPrefix(container, id.prefix)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing Blobs with the prefix %q (Container %q / Storage Account %q): %s", id.prefix, id.containerName, id.storageAccountName, err)
+	}
+	if len(blobs) == 0 {
+		return nil, fmt.Errorf("No Blobs were found with the prefix %q (Container %q / Storage Account %q)", id.prefix, id.containerName, id.storageAccountName)
+	}
+
+	files := make(map[string]interface{})
+	for name, blob := range blobs {
+		files[strings.TrimPrefix(name, id.prefix)] = blob.Properties.ContentMD5
+	}
+	if err := d.Set("files", files); err != nil {
+		return nil, fmt.Errorf("Error setting `files`: %+v", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceArmStorageBlobDirectoryDelete(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageBlobDirectoryID(d.Id(), armClient.environment)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup, err := determineResourceGroupForStorageAccount(id.storageAccountName, armClient)
+	if err != nil {
+		return fmt.Errorf("Unable to determine Resource Group for Storage Account %q: %+v", id.storageAccountName, err)
+	}
+	if resourceGroup == nil {
+		log.Printf("[INFO] Resource Group doesn't exist so the Blobs won't exist")
+		return nil
+	}
+
+	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(ctx, *resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		log.Printf("[INFO] Storage Account %q doesn't exist so the Blobs won't exist", id.storageAccountName)
+		return nil
+	}
+
+	container := blobClient.GetContainerReference(id.containerName)
+	exists, err := container.Exists()
+	if err != nil {
+		return fmt.Errorf("Error checking if Container %q exists (Storage Account %q): %s", id.containerName, id.storageAccountName, err)
+	}
+	if !exists {
+		log.Printf("[INFO] Container %q doesn't exist so the Blobs won't exist", id.containerName)
+		return nil
+	}
+
+	// only the Blobs uploaded by this resource are deleted
+	files := d.Get("files").(map[string]interface{})
+	log.Printf("[INFO] Deleting %d Blobs with the prefix %q (Container %q / Storage Account %q)", len(files), id.prefix, id.containerName, id.storageAccountName)
+	for relativeName := range files {
+		name := id.prefix + relativeName
+		blob := container.GetBlobReference(name)
+		if _, err := blob.DeleteIfExists(&storage.DeleteBlobOptions{}); err != nil {
+			return fmt.Errorf("Error deleting Blob %q (Container %q / Storage Account %q): %s", name, id.containerName, id.storageAccountName, err)
+		}
+	}
+
+	return nil
+}
+
+// resourceArmStorageBlobDirectoryCustomizeDiff hashes the files in the source directory, so that the changed files are
+// uploaded (and the removed files are deleted) when they differ from those in the Container
+func resourceArmStorageBlobDirectoryCustomizeDiff(d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("source") || !d.NewValueKnown("include") || !d.NewValueKnown("exclude") || !d.NewValueKnown("content_types") {
+		return d.SetNewComputed("files")
+	}
+
+	// the directory may be created by another resource during the apply, in which case it'll be hashed then
+	source := d.Get("source").(string)
+	if _, err := os.Stat(source); os.IsNotExist(err) {
+		return d.SetNewComputed("files")
+	}
+
+	files, err := resourceArmStorageBlobDirectoryWalk(source, d.Get("include").([]interface{}), d.Get("exclude").([]interface{}), d.Get("content_types").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	hashes := make(map[string]interface{})
+	for name, file := range files {
+		hashes[name] = file.contentMD5
+	}
+
+	if !reflect.DeepEqual(hashes, d.Get("files").(map[string]interface{})) {
+		if err := d.SetNew("files", hashes); err != nil {
+			return fmt.Errorf("Error setting `files`: %+v", err)
+		}
+	}
+
+	return nil
+}
+
+// resourceArmStorageBlobDirectoryFile is a file within the source directory which should be uploaded as a Block Blob
+type resourceArmStorageBlobDirectoryFile struct {
+	name        string
+	path        string
+	contentMD5  string
+	contentType string
+}
+
+// resourceArmStorageBlobDirectoryWalk returns the files within the source directory matching the include and exclude
+// patterns, keyed by their path relative to the source directory (using `/` as the separator)
+func resourceArmStorageBlobDirectoryWalk(source string, include, exclude []interface{}, contentTypes map[string]interface{}) (map[string]resourceArmStorageBlobDirectoryFile, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading source directory %q: %s", source, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("Expected source %q to be a directory", source)
+	}
+
+	includePatterns := expandStorageBlobDirectoryPatterns(include)
+	excludePatterns := expandStorageBlobDirectoryPatterns(exclude)
+
+	files := make(map[string]resourceArmStorageBlobDirectoryFile)
+	err = filepath.Walk(source, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(source, filePath)
+		if err != nil {
+			return err
+		}
+		if relativePath == "." {
+			return nil
+		}
+		name := filepath.ToSlash(relativePath)
+
+		if info.IsDir() {
+			// excluding a directory excludes everything within it
+			if matchesStorageBlobDirectoryPatterns(excludePatterns, name) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// symbolic links and other special files aren't uploaded
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		if matchesStorageBlobDirectoryPatterns(excludePatterns, name) {
+			return nil
+		}
+		if len(includePatterns) > 0 && !matchesStorageBlobDirectoryPatterns(includePatterns, name) {
+			return nil
+		}
+
+		content, err := resourceArmStorageBlobOpenSource(filePath, "")
+		if err != nil {
+			return err
+		}
+		defer utils.IoCloseAndLogError(content, fmt.Sprintf("Error closing source %s after hashing", content.name))
+
+		contentMD5, err := content.contentMD5()
+		if err != nil {
+			return err
+		}
+
+		files[name] = resourceArmStorageBlobDirectoryFile{
+			name:        name,
+			path:        filePath,
+			contentMD5:  contentMD5,
+			contentType: storageBlobDirectoryContentType(name, contentTypes),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error reading source directory %q: %s", source, err)
+	}
+
+	return files, nil
+}
+
+// resourceArmStorageBlobDirectoryUpload uploads the file as a Block Blob using the same pool of workers as the
+// `azurerm_storage_blob` resource, setting the Content MD5 when the blocks are committed
+func resourceArmStorageBlobDirectoryUpload(blob *storage.Blob, file resourceArmStorageBlobDirectoryFile, client *storage.BlobStorageClient, parallelism, attempts int) error {
+	source, err := resourceArmStorageBlobOpenSource(file.path, "")
+	if err != nil {
+		return err
+	}
+	defer utils.IoCloseAndLogError(source, fmt.Sprintf("Error closing source %s after upload", source.name))
+
+	// the file may have changed since it was hashed
+	contentMD5, err := source.contentMD5()
+	if err != nil {
+		return err
+	}
+
+	blockList, err := resourceArmStorageBlobBlockUploadBlocks(blob.Container.Name, blob.Name, source, client, parallelism, attempts)
+	if err != nil {
+		return err
+	}
+
+	blob.Properties.ContentType = file.contentType
+	blob.Properties.ContentMD5 = contentMD5
+	if err := blob.PutBlockList(blockList, &storage.PutBlockListOptions{}); err != nil {
+		return fmt.Errorf("Error updating block list for source %s: %s", source.name, err)
+	}
+
+	return nil
+}
+
+func resourceArmStorageBlobDirectorySetContentType(blob *storage.Blob, contentType string) error {
+	err := updateStorageProperties(
+		func() error { return blob.GetProperties(&storage.GetBlobPropertiesOptions{}) },
+		func() { blob.Properties.ContentType = contentType },
+		func() error { return blob.SetProperties(&storage.SetBlobPropertiesOptions{}) })
+	if err != nil {
+		return fmt.Errorf("Error setting the Content Type of blob %s (container %s): %+v", blob.Name, blob.Container.Name, err)
+	}
+
+	return nil
+}
+
+// listStorageBlobsWithPrefix returns all of the Blobs in the Container beginning with the prefix, keyed by their name
+func listStorageBlobsWithPrefix(container *storage.Container, prefix string) (map[string]storage.Blob, error) {
+	blobs := make(map[string]storage.Blob)
+
+	params := storage.ListBlobsParameters{
+		Prefix: prefix,
+	}
+	for {
+		resp, err := container.ListBlobs(params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, blob := range resp.Blobs {
+			blobs[blob.Name] = blob
+		}
+
+		if resp.NextMarker == "" {
+			break
+		}
+		params.Marker = resp.NextMarker
+	}
+
+	return blobs, nil
+}
+
+// storageBlobDirectoryContentType returns the Content Type for the file, using the `content_types` mapping before
+// falling back to the Content Type which is registered for the extension
+func storageBlobDirectoryContentType(name string, contentTypes map[string]interface{}) string {
+	extension := strings.ToLower(path.Ext(name))
+	if extension == "" {
+		return storageBlobDirectoryDefaultContentType
+	}
+
+	for k, v := range contentTypes {
+		if strings.ToLower(k) == extension {
+			return v.(string)
+		}
+	}
+
+	if contentType := mime.TypeByExtension(extension); contentType != "" {
+		return contentType
+	}
+
+	return storageBlobDirectoryDefaultContentType
+}
+
+func expandStorageBlobDirectoryPatterns(input []interface{}) []string {
+	patterns := make([]string, 0)
+	for _, v := range input {
+		patterns = append(patterns, v.(string))
+	}
+	return patterns
+}
+
+// matchesStorageBlobDirectoryPatterns returns whether the relative path matches any of the patterns - where patterns
+// containing a `/` are matched against the whole path and other patterns are matched against the name of the file
+// or directory at any depth
+func matchesStorageBlobDirectoryPatterns(patterns []string, relativePath string) bool {
+	for _, pattern := range patterns {
+		name := relativePath
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(relativePath)
+		}
+
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateArmStorageBlobDirectoryPrefix(v interface{}, k string) (warnings []string, errors []error) {
+	value := v.(string)
+	if value == "" || value == "/" {
+		errors = append(errors, fmt.Errorf("%q must not be empty", k))
+		return warnings, errors
+	}
+
+	if strings.HasPrefix(value, "/") || !strings.HasSuffix(value, "/") {
+		errors = append(errors, fmt.Errorf("%q must not begin with a `/` and must end with a `/`: %q", k, value))
+	}
+	if len(value) > 1024 {
+		errors = append(errors, fmt.Errorf("%q must be at most 1024 characters: %q", k, value))
+	}
+
+	return warnings, errors
+}
+
+func validateArmStorageBlobDirectoryPattern(v interface{}, k string) (warnings []string, errors []error) {
+	value := v.(string)
+	if value == "" {
+		errors = append(errors, fmt.Errorf("%q must not be empty", k))
+		return warnings, errors
+	}
+
+	if _, err := path.Match(value, ""); err != nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid pattern: %q", k, value))
+	}
+
+	return warnings, errors
+}
+
+func validateArmStorageBlobDirectoryContentTypes(v interface{}, k string) (warnings []string, errors []error) {
+	for extension, contentType := range v.(map[string]interface{}) {
+		if !strings.HasPrefix(extension, ".") || strings.Contains(extension, "/") {
+			errors = append(errors, fmt.Errorf("the keys of %q must be file extensions beginning with a `.`: %q", k, extension))
+		}
+		if contentType.(string) == "" {
+			errors = append(errors, fmt.Errorf("the Content Type for the extension %q in %q must not be empty", extension, k))
+		}
+	}
+
+	return warnings, errors
+}
+
+type storageBlobDirectoryId struct {
+	storageAccountName string
+	containerName      string
+	prefix             string
+}
+
+// storageBlobDirectoryID returns the URL of the Container followed by the prefix,
+// e.g. https://example.blob.core.windows.net/container/assets/
+func storageBlobDirectoryID(storageAccountName, containerName, prefix string, environment azure.Environment) string {
+	uri := url.URL{
+		Scheme: "https",
+		Host:   fmt.Sprintf("%s.blob.%s", storageAccountName, environment.StorageEndpointSuffix),
+		Path:   fmt.Sprintf("/%s/%s", containerName, prefix),
+	}
+	return uri.String()
+}
+
+func parseStorageBlobDirectoryID(input string, environment azure.Environment) (*storageBlobDirectoryId, error) {
+	uri, err := url.Parse(input)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing %q as URI: %+v", input, err)
+	}
+
+	// trim the leading `/` - the prefix is everything after the Container Name
+	segments := strings.SplitN(strings.TrimPrefix(uri.Path, "/"), "/", 2)
+	if len(segments) != 2 || segments[0] == "" || segments[1] == "" {
+		return nil, fmt.Errorf("Expected the path of %q to contain a Container Name and a prefix", input)
+	}
+
+	id := storageBlobDirectoryId{
+		storageAccountName: strings.Replace(uri.Host, fmt.Sprintf(".blob.%s", environment.StorageEndpointSuffix), "", 1),
+		containerName:      segments[0],
+		prefix:             segments[1],
+	}
+
+	return &id, nil
+}
@@ -0,0 +1,420 @@
+package azurerm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAzureRMStorageBlobDirectory_basic(t *testing.T) {
+	resourceName := "azurerm_storage_blob_directory.test"
+	foreignResourceName := "azurerm_storage_blob.foreign"
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	location := testLocation()
+
+	source, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Failed to create the source directory: %+v", err)
+	}
+	defer os.RemoveAll(source)
+
+	testWriteStorageBlobDirectoryFiles(t, source, map[string]string{
+		"index.html":     "<h1>Hello World</h1>",
+		"css/site.css":   "h1 { color: red; }",
+		"js/site.js":     "console.log('hello');",
+		"js/site.js.map": "{}",
+	})
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageBlobDirectoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMStorageBlobDirectory_basic(ri, rs, source, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageBlobDirectoryBlobs(resourceName, map[string]string{
+						"index.html":   "text/html; charset=utf-8",
+						"css/site.css": "text/css; charset=utf-8",
+						"js/site.js":   "application/x-javascript",
+					}),
+					testCheckAzureRMStorageBlobExists(foreignResourceName),
+					resource.TestCheckResourceAttr(resourceName, "files.%", "3"),
+				),
+			},
+			{
+				// update a file, remove a file and add a file
+				PreConfig: func() {
+					testWriteStorageBlobDirectoryFiles(t, source, map[string]string{
+						"index.html": "<h1>Hello Again</h1>",
+						"about.html": "<h1>About</h1>",
+					})
+					if err := os.Remove(filepath.Join(source, "css", "site.css")); err != nil {
+						t.Fatalf("Failed to remove the file: %+v", err)
+					}
+				},
+				Config: testAccAzureRMStorageBlobDirectory_basic(ri, rs, source, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageBlobDirectoryBlobs(resourceName, map[string]string{
+						"index.html": "text/html; charset=utf-8",
+						"about.html": "text/html; charset=utf-8",
+						"js/site.js": "application/x-javascript",
+					}),
+					testCheckAzureRMStorageBlobExists(foreignResourceName),
+					resource.TestCheckResourceAttr(resourceName, "files.%", "3"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// all of the Blobs with the prefix are tracked when importing, including the foreign Blob
+				ImportStateVerifyIgnore: []string{"source", "exclude", "content_types", "parallelism", "attempts", "files"},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("Expected 1 imported state but got %d", len(states))
+					}
+					if v := states[0].Attributes["files.%"]; v != "4" {
+						return fmt.Errorf("Expected 4 files to be tracked after importing but got %q", v)
+					}
+					if _, ok := states[0].Attributes["files."+testAccAzureRMStorageBlobDirectoryForeignBlobName]; !ok {
+						return fmt.Errorf("Expected the foreign Blob %q to be tracked after importing", testAccAzureRMStorageBlobDirectoryForeignBlobName)
+					}
+					return nil
+				},
+			},
+			{
+				// destroying the Blob Directory should leave the other Blob with the same prefix untouched
+				Config: testAccAzureRMStorageBlobDirectory_template(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageBlobExists(foreignResourceName),
+				),
+			},
+		},
+	})
+}
+
+func testWriteStorageBlobDirectoryFiles(t *testing.T, directory string, files map[string]string) {
+	for name, content := range files {
+		filePath := filepath.Join(directory, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatalf("Failed to create the directory for %q: %+v", name, err)
+		}
+		if err := ioutil.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %q: %+v", name, err)
+		}
+	}
+}
+
+// testCheckAzureRMStorageBlobDirectoryBlobs checks that exactly the expected Blobs (other than the foreign Blob) exist
+// with the prefix, with the expected Content Types
+func testCheckAzureRMStorageBlobDirectoryBlobs(resourceName string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		storageAccountName := rs.Primary.Attributes["storage_account_name"]
+		containerName := rs.Primary.Attributes["storage_container_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+		prefix := rs.Primary.Attributes["prefix"]
+
+		armClient := testAccProvider.Meta().(*ArmClient)
+		ctx := armClient.StopContext
+		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(ctx, resourceGroup, storageAccountName)
+		if err != nil {
+			return err
+		}
+		if !accountExists {
+			return fmt.Errorf("Bad: Storage Account %q does not exist", storageAccountName)
+		}
+
+		blobs, err := listStorageBlobsWithPrefix(blobClient.GetContainerReference(containerName), prefix)
+		if err != nil {
+			return fmt.Errorf("Bad: Error listing Blobs with the prefix %q (Container %q): %+v", prefix, containerName, err)
+		}
+
+		delete(blobs, prefix+testAccAzureRMStorageBlobDirectoryForeignBlobName)
+		if len(blobs) != len(expected) {
+			return fmt.Errorf("Bad: Expected %d Blobs with the prefix %q (Container %q) but got %d", len(expected), prefix, containerName, len(blobs))
+		}
+
+		for name, contentType := range expected {
+			blob, ok := blobs[prefix+name]
+			if !ok {
+				return fmt.Errorf("Bad: Blob %q was not found with the prefix %q (Container %q)", name, prefix, containerName)
+			}
+			if blob.Properties.ContentType != contentType {
+				return fmt.Errorf("Bad: Expected Blob %q to have the Content Type %q but got %q", name, contentType, blob.Properties.ContentType)
+			}
+			if blob.Properties.ContentMD5 == "" {
+				return fmt.Errorf("Bad: Expected Blob %q to have a Content MD5", name)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMStorageBlobDirectoryDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_storage_blob_directory" {
+			continue
+		}
+
+		storageAccountName := rs.Primary.Attributes["storage_account_name"]
+		containerName := rs.Primary.Attributes["storage_container_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+		prefix := rs.Primary.Attributes["prefix"]
+
+		armClient := testAccProvider.Meta().(*ArmClient)
+		ctx := armClient.StopContext
+		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(ctx, resourceGroup, storageAccountName)
+		if err != nil {
+			// if we can't get the keys then the Blobs can't exist
+			return nil
+		}
+		if !accountExists {
+			return nil
+		}
+
+		container := blobClient.GetContainerReference(containerName)
+		exists, err := container.Exists()
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return nil
+		}
+
+		blobs, err := listStorageBlobsWithPrefix(container, prefix)
+		if err != nil {
+			return err
+		}
+
+		// only the Blobs uploaded by the resource should have been deleted
+		for key := range rs.Primary.Attributes {
+			if !strings.HasPrefix(key, "files.") || key == "files.%" {
+				continue
+			}
+
+			name := prefix + strings.TrimPrefix(key, "files.")
+			if _, ok := blobs[name]; ok {
+				return fmt.Errorf("Bad: Blob %q still exists (Container %q)", name, containerName)
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestResourceArmStorageBlobDirectoryWalk(t *testing.T) {
+	source, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Failed to create the source directory: %+v", err)
+	}
+	defer os.RemoveAll(source)
+
+	testWriteStorageBlobDirectoryFiles(t, source, map[string]string{
+		"index.html":            "<h1>Hello World</h1>",
+		"app.js":                "console.log('hello');",
+		"app.js.map":            "{}",
+		"data/config.json":      "{}",
+		"data/README":           "readme",
+		"node_modules/dep.js":   "module.exports = {};",
+		"images/logo.png":       "png",
+		"images/drafts/old.png": "png",
+	})
+
+	cases := []struct {
+		Include  []interface{}
+		Exclude  []interface{}
+		Expected []string
+	}{
+		{
+			Expected: []string{"index.html", "app.js", "app.js.map", "data/config.json", "data/README", "node_modules/dep.js", "images/logo.png", "images/drafts/old.png"},
+		},
+		{
+			Exclude:  []interface{}{"*.map", "node_modules", "images/drafts"},
+			Expected: []string{"index.html", "app.js", "data/config.json", "data/README", "images/logo.png"},
+		},
+		{
+			Include:  []interface{}{"*.png", "data/*.json"},
+			Exclude:  []interface{}{"drafts"},
+			Expected: []string{"data/config.json", "images/logo.png"},
+		},
+	}
+
+	for _, v := range cases {
+		files, err := resourceArmStorageBlobDirectoryWalk(source, v.Include, v.Exclude, map[string]interface{}{})
+		if err != nil {
+			t.Fatalf("Error walking %q: %+v", source, err)
+		}
+
+		if len(files) != len(v.Expected) {
+			t.Fatalf("Expected %d files for the include %+v and exclude %+v but got %d: %+v", len(v.Expected), v.Include, v.Exclude, len(files), files)
+		}
+
+		for _, name := range v.Expected {
+			file, ok := files[name]
+			if !ok {
+				t.Fatalf("Expected %q to be included for the include %+v and exclude %+v", name, v.Include, v.Exclude)
+			}
+			if file.name != name || file.contentMD5 == "" {
+				t.Fatalf("Expected %q to be hashed but got %+v", name, file)
+			}
+		}
+	}
+
+	if _, err := resourceArmStorageBlobDirectoryWalk(filepath.Join(source, "index.html"), nil, nil, map[string]interface{}{}); err == nil {
+		t.Fatalf("Expected an error when the source is a file")
+	}
+}
+
+func TestStorageBlobDirectoryContentType(t *testing.T) {
+	contentTypes := map[string]interface{}{
+		".JSON": "application/vnd.example+json",
+		".woff": "font/woff",
+	}
+
+	cases := map[string]string{
+		"index.html":         "text/html; charset=utf-8",
+		"css/site.css":       "text/css; charset=utf-8",
+		"data/config.json":   "application/vnd.example+json",
+		"fonts/example.woff": "font/woff",
+		"images/logo.PNG":    "image/png",
+		"README":             "application/octet-stream",
+		"archive.unknownext": "application/octet-stream",
+	}
+
+	for name, expected := range cases {
+		if actual := storageBlobDirectoryContentType(name, contentTypes); actual != expected {
+			t.Fatalf("Expected the Content Type for %q to be %q but got %q", name, expected, actual)
+		}
+	}
+}
+
+func TestValidateArmStorageBlobDirectoryPrefix(t *testing.T) {
+	cases := map[string]bool{
+		"":                              false,
+		"/":                             false,
+		"assets/":                       true,
+		"assets/images/":                true,
+		"assets":                        false,
+		"/assets/":                      false,
+		strings.Repeat("a", 1024) + "/": false,
+	}
+
+	for input, valid := range cases {
+		_, errors := validateArmStorageBlobDirectoryPrefix(input, "prefix")
+		if (len(errors) == 0) != valid {
+			t.Fatalf("Expected the prefix %q to be valid: %t but got %+v", input, valid, errors)
+		}
+	}
+}
+
+func TestParseStorageBlobDirectoryID(t *testing.T) {
+	cases := []struct {
+		Prefix string
+		ID     string
+	}{
+		{
+			Prefix: "assets/",
+			ID:     "https://example.blob.core.windows.net/$web/assets/",
+		},
+		{
+			Prefix: "release notes/v1?#/",
+			ID:     "https://example.blob.core.windows.net/$web/release%20notes/v1%3F%23/",
+		},
+	}
+
+	for _, v := range cases {
+		id := storageBlobDirectoryID("example", "$web", v.Prefix, azure.PublicCloud)
+		if id != v.ID {
+			t.Fatalf("Expected the ID %q but got %q", v.ID, id)
+		}
+
+		parsed, err := parseStorageBlobDirectoryID(id, azure.PublicCloud)
+		if err != nil {
+			t.Fatalf("Error parsing %q: %+v", id, err)
+		}
+		if parsed.storageAccountName != "example" || parsed.containerName != "$web" || parsed.prefix != v.Prefix {
+			t.Fatalf("Expected %q to be parsed as the prefix %q but got %+v", id, v.Prefix, parsed)
+		}
+	}
+
+	for _, id := range []string{"https://example.blob.core.windows.net/", "https://example.blob.core.windows.net/$web", "https://example.blob.core.windows.net/$web/"} {
+		if _, err := parseStorageBlobDirectoryID(id, azure.PublicCloud); err == nil {
+			t.Fatalf("Expected an error when the ID %q doesn't contain a Container Name and a prefix", id)
+		}
+	}
+}
+
+// testAccAzureRMStorageBlobDirectoryForeignBlobName is the name (relative to the prefix) of a Blob which isn't managed
+// by the Blob Directory, and matches the `exclude` patterns
+const testAccAzureRMStorageBlobDirectoryForeignBlobName = "foreign.js.map"
+
+func testAccAzureRMStorageBlobDirectory_basic(rInt int, rString string, source string, location string) string {
+	template := testAccAzureRMStorageBlobDirectory_template(rInt, rString, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_blob_directory" "test" {
+  resource_group_name    = "${azurerm_resource_group.test.name}"
+  storage_account_name   = "${azurerm_storage_account.test.name}"
+  storage_container_name = "${azurerm_storage_container.test.name}"
+  prefix                 = "site/"
+  source                 = "%s"
+  exclude                = ["*.map"]
+
+  content_types = {
+    ".js" = "application/x-javascript"
+  }
+
+  depends_on = ["azurerm_storage_blob.foreign"]
+}
+`, template, filepath.ToSlash(source))
+}
+
+func testAccAzureRMStorageBlobDirectory_template(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "assets"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  storage_account_name  = "${azurerm_storage_account.test.name}"
+  container_access_type = "private"
+}
+
+resource "azurerm_storage_blob" "foreign" {
+  name                   = "site/%s"
+  resource_group_name    = "${azurerm_resource_group.test.name}"
+  storage_account_name   = "${azurerm_storage_account.test.name}"
+  storage_container_name = "${azurerm_storage_container.test.name}"
+  type                   = "block"
+  source_content         = "{}"
+}
+`, rInt, location, rString, testAccAzureRMStorageBlobDirectoryForeignBlobName)
+}
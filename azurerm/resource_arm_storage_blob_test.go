@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
 	"testing"
 
 	"strings"
@@ -247,6 +248,196 @@ func TestAccAzureRMStorageBlobBlock_blockContentType(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMStorageBlobAppend_source(t *testing.T) {
+	resourceName := "azurerm_storage_blob.source"
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	sourceBlob, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatalf("Failed to create local source blob file")
+	}
+
+	_, err = io.CopyN(sourceBlob, rand.Reader, 9*1024*1024+123)
+	if err != nil {
+		t.Fatalf("Failed to write random test to source blob")
+	}
+
+	err = sourceBlob.Close()
+	if err != nil {
+		t.Fatalf("Failed to close source blob")
+	}
+
+	config := testAccAzureRMStorageBlobAppend_source(ri, rs, sourceBlob.Name(), testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageBlobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageBlobMatchesFile(resourceName, storage.BlobTypeAppend, sourceBlob.Name()),
+					resource.TestCheckResourceAttrSet(resourceName, "content_md5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageBlobBlock_sourceChanged(t *testing.T) {
+	resourceName := "azurerm_storage_blob.source"
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	sourceBlob, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatalf("Failed to create local source blob file")
+	}
+
+	writeSourceBlob := func() {
+		if err := sourceBlob.Truncate(0); err != nil {
+			t.Fatalf("Failed to truncate source blob")
+		}
+
+		if _, err := sourceBlob.Seek(0, io.SeekStart); err != nil {
+			t.Fatalf("Failed to seek source blob")
+		}
+
+		if _, err := io.CopyN(sourceBlob, rand.Reader, 5*1024*1024); err != nil {
+			t.Fatalf("Failed to write random test to source blob")
+		}
+	}
+	writeSourceBlob()
+	defer sourceBlob.Close()
+
+	config := testAccAzureRMStorageBlobBlock_source(ri, rs, sourceBlob.Name(), testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageBlobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageBlobMatchesFile(resourceName, storage.BlobTypeBlock, sourceBlob.Name()),
+				),
+			},
+			{
+				PreConfig: writeSourceBlob,
+				Config:    config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageBlobMatchesFile(resourceName, storage.BlobTypeBlock, sourceBlob.Name()),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageBlobBlock_sourceContent(t *testing.T) {
+	resourceName := "azurerm_storage_blob.test"
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageBlobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMStorageBlobBlock_sourceContent(ri, rs, location, "Hello World", "Hot", "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageBlobExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "content_md5", "sQqNsWTgdUEFt6mb5y4/5Q=="),
+					resource.TestCheckResourceAttr(resourceName, "access_tier", "Hot"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.hello", "first"),
+				),
+			},
+			{
+				Config: testAccAzureRMStorageBlobBlock_sourceContent(ri, rs, location, "Goodbye World", "Cool", "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageBlobExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "content_md5", "Knmb/svsHnxs69wmORruDQ=="),
+					resource.TestCheckResourceAttr(resourceName, "access_tier", "Cool"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.hello", "second"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"attempts", "parallelism", "size", "source_content", "type"},
+			},
+		},
+	})
+}
+
+func TestResourceArmStorageBlobSource_contentMD5(t *testing.T) {
+	file, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatalf("Failed to create local source file")
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString("Hello World"); err != nil {
+		t.Fatalf("Failed to write to local source file")
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("Failed to close local source file")
+	}
+
+	cases := []struct {
+		Name          string
+		Source        string
+		SourceContent string
+		Expected      string
+	}{
+		{
+			Name:     "Neither",
+			Expected: "",
+		},
+		{
+			Name:          "Source Content",
+			SourceContent: "Hello World",
+			Expected:      "sQqNsWTgdUEFt6mb5y4/5Q==",
+		},
+		{
+			Name:     "Source File",
+			Source:   file.Name(),
+			Expected: "sQqNsWTgdUEFt6mb5y4/5Q==",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			source, err := resourceArmStorageBlobOpenSource(tc.Source, tc.SourceContent)
+			if err != nil {
+				t.Fatalf("Error opening source: %+v", err)
+			}
+
+			if source == nil {
+				if tc.Expected != "" {
+					t.Fatalf("Expected a source but didn't get one")
+				}
+				return
+			}
+			defer source.Close()
+
+			actual, err := source.contentMD5()
+			if err != nil {
+				t.Fatalf("Error computing the MD5 hash: %+v", err)
+			}
+
+			if actual != tc.Expected {
+				t.Fatalf("Expected the MD5 hash to be %q but got %q", tc.Expected, actual)
+			}
+		})
+	}
+}
+
 func testCheckAzureRMStorageBlobExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
@@ -638,3 +829,81 @@ resource "azurerm_storage_blob" "source" {
 }
 `, rInt, location, rString, sourceBlobName, contentType)
 }
+
+func testAccAzureRMStorageBlobAppend_source(rInt int, rString string, sourceBlobName string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "source" {
+  name                     = "acctestacc%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "source" {
+  name                  = "source"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  storage_account_name  = "${azurerm_storage_account.source.name}"
+  container_access_type = "private"
+}
+
+resource "azurerm_storage_blob" "source" {
+  name = "source.log"
+
+  resource_group_name    = "${azurerm_resource_group.test.name}"
+  storage_account_name   = "${azurerm_storage_account.source.name}"
+  storage_container_name = "${azurerm_storage_container.source.name}"
+
+  type     = "append"
+  source   = "%s"
+  attempts = 2
+}
+`, rInt, location, rString, sourceBlobName)
+}
+
+func testAccAzureRMStorageBlobBlock_sourceContent(rInt int, rString, location, content, accessTier, metaDataValue string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_kind             = "StorageV2"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "content"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  storage_account_name  = "${azurerm_storage_account.test.name}"
+  container_access_type = "private"
+}
+
+resource "azurerm_storage_blob" "test" {
+  name = "hello.txt"
+
+  resource_group_name    = "${azurerm_resource_group.test.name}"
+  storage_account_name   = "${azurerm_storage_account.test.name}"
+  storage_container_name = "${azurerm_storage_container.test.name}"
+
+  type           = "block"
+  source_content = "%s"
+  content_type   = "text/plain"
+  access_tier    = "%s"
+
+  metadata {
+    hello = "%s"
+  }
+}
+`, rInt, location, rString, content, accessTier, metaDataValue)
+}
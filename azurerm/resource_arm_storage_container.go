@@ -12,14 +12,15 @@ import (
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 )
 
 func resourceArmStorageContainer() *schema.Resource {
 	return &schema.Resource{
-		Create:        resourceArmStorageContainerCreateUpdate,
-		Read:          resourceArmStorageContainerRead,
-		Delete:        resourceArmStorageContainerDelete,
-		Update:        resourceArmStorageContainerCreateUpdate,
+		Create:        retryOnStorageAuthenticationFailure(resourceArmStorageContainerCreateUpdate),
+		Read:          retryOnStorageAuthenticationFailure(resourceArmStorageContainerRead),
+		Delete:        retryOnStorageAuthenticationFailure(resourceArmStorageContainerDelete),
+		Update:        retryOnStorageAuthenticationFailure(resourceArmStorageContainerCreateUpdate),
 		MigrateState:  resourceStorageContainerMigrateState,
 		SchemaVersion: 1,
 		Importer: &schema.ResourceImporter{
@@ -46,6 +47,14 @@ func resourceArmStorageContainer() *schema.Resource {
 				ValidateFunc: validateArmStorageContainerAccessType,
 			},
 
+			"acl": storageAccessPolicySchema("racwdl"),
+
+			"metadata": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validate.StorageMetaDataKeys,
+			},
+
 			"properties": {
 				Type:     schema.TypeMap,
 				Computed: true,
@@ -118,15 +127,27 @@ func resourceArmStorageContainerCreateUpdate(d *schema.ResourceData, meta interf
 		return fmt.Errorf("Error creating container %q in storage account %q: %s", name, storageAccountName, err)
 	}
 
-	permissions := storage.ContainerPermissions{
-		AccessType: accessType,
-	}
-	permissionOptions := &storage.SetContainerPermissionOptions{}
-	err = reference.SetPermissions(permissions, permissionOptions)
+	acls, err := expandStorageAccessPolicies(d.Get("acl").([]interface{}))
 	if err != nil {
+		return fmt.Errorf("Error expanding `acl` for container %s in storage account %s: %+v", name, storageAccountName, err)
+	}
+
+	// the Storage SDK only supports the `r`, `w` and `d` permissions for a Container's Stored Access Policies, so
+	// the Container ACL (which also sets the public access level) is set directly
+	aclHeaders := make(map[string]string)
+	if accessType != storage.ContainerAccessTypePrivate {
+		aclHeaders[storage.ContainerAccessHeader] = string(accessType)
+	}
+	aclURI := fmt.Sprintf("%s?restype=container&comp=acl", reference.GetURL())
+	if err := armClient.setStorageAccessPolicies(ctx, resourceGroupName, storageAccountName, true, aclURI, aclHeaders, acls); err != nil {
 		return fmt.Errorf("Error setting permissions for container %s in storage account %s: %+v", name, storageAccountName, err)
 	}
 
+	reference.Metadata = expandStorageContainerMetaData(d.Get("metadata").(map[string]interface{}))
+	if err := reference.SetMetadata(&storage.ContainerMetadataOptions{}); err != nil {
+		return fmt.Errorf("Error setting metadata for container %s in storage account %s: %+v", name, storageAccountName, err)
+	}
+
 	id := fmt.Sprintf("https://%s.blob.%s/%s", storageAccountName, armClient.environment.StorageEndpointSuffix, name)
 	d.SetId(id)
 	return resourceArmStorageContainerRead(d, meta)
@@ -207,6 +228,25 @@ func resourceArmStorageContainerRead(d *schema.ResourceData, meta interface{}) e
 		return fmt.Errorf("Error setting `properties`: %+v", err)
 	}
 
+	reference := blobClient.GetContainerReference(id.containerName)
+	if err := reference.GetMetadata(&storage.ContainerMetadataOptions{}); err != nil {
+		return fmt.Errorf("Error retrieving metadata for container %q in storage account %q: %s", id.containerName, id.storageAccountName, err)
+	}
+
+	if err := d.Set("metadata", flattenStorageContainerMetaData(reference.Metadata)); err != nil {
+		return fmt.Errorf("Error setting `metadata`: %+v", err)
+	}
+
+	aclURI := fmt.Sprintf("%s?restype=container&comp=acl", reference.GetURL())
+	acls, _, err := armClient.getStorageAccessPolicies(ctx, *resourceGroup, id.storageAccountName, true, aclURI)
+	if err != nil {
+		return fmt.Errorf("Error retrieving permissions for container %q in storage account %q: %s", id.containerName, id.storageAccountName, err)
+	}
+
+	if err := d.Set("acl", flattenStorageAccessPolicies(acls)); err != nil {
+		return fmt.Errorf("Error setting `acl`: %+v", err)
+	}
+
 	return nil
 }
 
@@ -261,6 +301,26 @@ func checkContainerIsCreated(reference *storage.Container) func() *resource.Retr
 	}
 }
 
+func expandStorageContainerMetaData(input map[string]interface{}) map[string]string {
+	output := make(map[string]string)
+
+	for k, v := range input {
+		output[k] = v.(string)
+	}
+
+	return output
+}
+
+func flattenStorageContainerMetaData(input map[string]string) map[string]interface{} {
+	output := make(map[string]interface{})
+
+	for k, v := range input {
+		output[k] = v
+	}
+
+	return output
+}
+
 type storageContainerId struct {
 	storageAccountName string
 	containerName      string
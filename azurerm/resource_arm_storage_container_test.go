@@ -132,6 +132,50 @@ func TestAccAzureRMStorageContainer_root(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMStorageContainer_aclAndMetadata(t *testing.T) {
+	resourceName := "azurerm_storage_container.test"
+	var c storage.Container
+
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageContainerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMStorageContainer_aclAndMetadata(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageContainerExists(resourceName, &c),
+					resource.TestCheckResourceAttr(resourceName, "metadata.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.hello", "world"),
+					resource.TestCheckResourceAttr(resourceName, "acl.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "acl.0.access_policy.0.permissions", "racwdl"),
+				),
+			},
+			{
+				Config: testAccAzureRMStorageContainer_aclAndMetadataUpdated(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageContainerExists(resourceName, &c),
+					resource.TestCheckResourceAttr(resourceName, "container_access_type", "blob"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.panda", "pops"),
+					resource.TestCheckResourceAttr(resourceName, "acl.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "acl.0.access_policy.0.permissions", "rl"),
+					resource.TestCheckResourceAttr(resourceName, "acl.1.access_policy.0.expiry", "2019-07-02T11:38:21Z"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testCheckAzureRMStorageContainerExists(name string, c *storage.Container) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
@@ -378,3 +422,98 @@ resource "azurerm_storage_container" "test" {
 }
 `, rInt, location, rString)
 }
+
+func testAccAzureRMStorageContainer_aclAndMetadata(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  tags {
+    environment = "staging"
+  }
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "vhds"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  storage_account_name  = "${azurerm_storage_account.test.name}"
+  container_access_type = "private"
+
+  metadata {
+    hello = "world"
+  }
+
+  acl {
+    id = "MTIzNDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTI"
+
+    access_policy {
+      start       = "2019-07-02T09:38:21Z"
+      expiry      = "2019-07-02T10:38:21Z"
+      permissions = "racwdl"
+    }
+  }
+}
+`, rInt, location, rString)
+}
+
+func testAccAzureRMStorageContainer_aclAndMetadataUpdated(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  tags {
+    environment = "staging"
+  }
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "vhds"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  storage_account_name  = "${azurerm_storage_account.test.name}"
+  container_access_type = "blob"
+
+  metadata {
+    hello = "world"
+    panda = "pops"
+  }
+
+  acl {
+    id = "AAAANDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTI"
+
+    access_policy {
+      start       = "2019-07-02T09:38:21Z"
+      expiry      = "2019-07-02T10:38:21Z"
+      permissions = "rl"
+    }
+  }
+
+  acl {
+    id = "MTIzNDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTI"
+
+    access_policy {
+      start       = "2019-07-02T09:38:21Z"
+      expiry      = "2019-07-02T11:38:21Z"
+      permissions = "rw"
+    }
+  }
+}
+`, rInt, location, rString)
+}
@@ -9,13 +9,15 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/storage"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
 )
 
 func resourceArmStorageQueue() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceArmStorageQueueCreate,
-		Read:   resourceArmStorageQueueRead,
-		Delete: resourceArmStorageQueueDelete,
+		Create: retryOnStorageAuthenticationFailure(resourceArmStorageQueueCreate),
+		Read:   retryOnStorageAuthenticationFailure(resourceArmStorageQueueRead),
+		Update: retryOnStorageAuthenticationFailure(resourceArmStorageQueueUpdate),
+		Delete: retryOnStorageAuthenticationFailure(resourceArmStorageQueueDelete),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -35,6 +37,12 @@ func resourceArmStorageQueue() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"acl": storageAccessPolicySchema("raup"),
+			"metadata": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validate.StorageMetaDataKeys,
+			},
 		},
 	}
 }
@@ -87,12 +95,25 @@ func resourceArmStorageQueueCreate(d *schema.ResourceData, meta interface{}) err
 
 	log.Printf("[INFO] Creating queue %q in storage account %q", name, storageAccountName)
 	queueReference := queueClient.GetQueueReference(name)
+	queueReference.Metadata = expandStorageQueueMetaData(d.Get("metadata").(map[string]interface{}))
 	options := &storage.QueueServiceOptions{}
 	err = queueReference.Create(options)
 	if err != nil {
 		return fmt.Errorf("Error creating storage queue on Azure: %s", err)
 	}
 
+	if acls := d.Get("acl").([]interface{}); len(acls) > 0 {
+		permissions, err := expandStorageQueuePermissions(acls)
+		if err != nil {
+			return fmt.Errorf("Error expanding `acl` for storage queue %q: %+v", name, err)
+		}
+
+		log.Printf("[INFO] Setting the Stored Access Policies for queue %q in storage account %q", name, storageAccountName)
+		if err := queueReference.SetPermissions(*permissions, &storage.SetQueuePermissionOptions{}); err != nil {
+			return fmt.Errorf("Error setting the Stored Access Policies for storage queue %q: %s", name, err)
+		}
+	}
+
 	id := fmt.Sprintf("https://%s.queue.%s/%s", storageAccountName, environment.StorageEndpointSuffix, name)
 	d.SetId(id)
 	return resourceArmStorageQueueRead(d, meta)
@@ -145,9 +166,69 @@ func resourceArmStorageQueueRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("storage_account_name", id.storageAccountName)
 	d.Set("resource_group_name", *resourceGroup)
 
+	if err := queueReference.GetMetadata(&storage.QueueServiceOptions{}); err != nil {
+		return fmt.Errorf("Error retrieving the metadata for storage queue %q: %s", id.queueName, err)
+	}
+
+	if err := d.Set("metadata", flattenStorageQueueMetaData(queueReference.Metadata)); err != nil {
+		return fmt.Errorf("Error setting `metadata`: %+v", err)
+	}
+
+	permissions, err := queueReference.GetPermissions(&storage.GetQueuePermissionOptions{})
+	if err != nil {
+		return fmt.Errorf("Error retrieving the Stored Access Policies for storage queue %q: %s", id.queueName, err)
+	}
+
+	if err := d.Set("acl", flattenStorageQueuePermissions(permissions)); err != nil {
+		return fmt.Errorf("Error setting `acl`: %+v", err)
+	}
+
 	return nil
 }
 
+func resourceArmStorageQueueUpdate(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageQueueID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	queueClient, accountExists, err := armClient.getQueueServiceClientForStorageAccount(ctx, resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q Not Found", id.storageAccountName)
+	}
+
+	queueReference := queueClient.GetQueueReference(id.queueName)
+
+	if d.HasChange("metadata") {
+		log.Printf("[INFO] Updating the metadata for queue %q in storage account %q", id.queueName, id.storageAccountName)
+		queueReference.Metadata = expandStorageQueueMetaData(d.Get("metadata").(map[string]interface{}))
+		if err := queueReference.SetMetadata(&storage.QueueServiceOptions{}); err != nil {
+			return fmt.Errorf("Error updating the metadata for storage queue %q: %s", id.queueName, err)
+		}
+	}
+
+	if d.HasChange("acl") {
+		permissions, err := expandStorageQueuePermissions(d.Get("acl").([]interface{}))
+		if err != nil {
+			return fmt.Errorf("Error expanding `acl` for storage queue %q: %+v", id.queueName, err)
+		}
+
+		log.Printf("[INFO] Updating the Stored Access Policies for queue %q in storage account %q", id.queueName, id.storageAccountName)
+		if err := queueReference.SetPermissions(*permissions, &storage.SetQueuePermissionOptions{}); err != nil {
+			return fmt.Errorf("Error updating the Stored Access Policies for storage queue %q: %s", id.queueName, err)
+		}
+	}
+
+	return resourceArmStorageQueueRead(d, meta)
+}
+
 func resourceArmStorageQueueDelete(d *schema.ResourceData, meta interface{}) error {
 	armClient := meta.(*ArmClient)
 	ctx := armClient.StopContext
@@ -186,6 +267,84 @@ func resourceArmStorageQueueDelete(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
+func expandStorageQueueMetaData(input map[string]interface{}) map[string]string {
+	output := make(map[string]string)
+
+	for k, v := range input {
+		output[k] = v.(string)
+	}
+
+	return output
+}
+
+func flattenStorageQueueMetaData(input map[string]string) map[string]interface{} {
+	output := make(map[string]interface{})
+
+	for k, v := range input {
+		output[k] = v
+	}
+
+	return output
+}
+
+func expandStorageQueuePermissions(input []interface{}) (*storage.QueuePermissions, error) {
+	identifiers, err := expandStorageAccessPolicies(input)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]storage.QueueAccessPolicy, 0)
+	for _, v := range identifiers {
+		policies = append(policies, storage.QueueAccessPolicy{
+			ID:         v.ID,
+			StartTime:  v.AccessPolicy.StartTime,
+			ExpiryTime: v.AccessPolicy.ExpiryTime,
+			CanRead:    strings.Contains(v.AccessPolicy.Permission, "r"),
+			CanAdd:     strings.Contains(v.AccessPolicy.Permission, "a"),
+			CanUpdate:  strings.Contains(v.AccessPolicy.Permission, "u"),
+			CanProcess: strings.Contains(v.AccessPolicy.Permission, "p"),
+		})
+	}
+
+	return &storage.QueuePermissions{
+		AccessPolicies: policies,
+	}, nil
+}
+
+func flattenStorageQueuePermissions(input *storage.QueuePermissions) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	identifiers := make([]storage.SignedIdentifier, 0)
+	for _, v := range input.AccessPolicies {
+		permissions := ""
+		if v.CanRead {
+			permissions += "r"
+		}
+		if v.CanAdd {
+			permissions += "a"
+		}
+		if v.CanUpdate {
+			permissions += "u"
+		}
+		if v.CanProcess {
+			permissions += "p"
+		}
+
+		identifiers = append(identifiers, storage.SignedIdentifier{
+			ID: v.ID,
+			AccessPolicy: storage.AccessPolicyDetailsXML{
+				StartTime:  v.StartTime,
+				ExpiryTime: v.ExpiryTime,
+				Permission: permissions,
+			},
+		})
+	}
+
+	return flattenStorageAccessPolicies(identifiers)
+}
+
 type storageQueueId struct {
 	storageAccountName string
 	queueName          string
@@ -76,6 +76,48 @@ func TestAccAzureRMStorageQueue_basic(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMStorageQueue_aclAndMetadata(t *testing.T) {
+	resourceName := "azurerm_storage_queue.test"
+
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageQueueDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMStorageQueue_aclAndMetadata(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageQueueExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "metadata.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.hello", "world"),
+					resource.TestCheckResourceAttr(resourceName, "acl.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "acl.0.access_policy.0.permissions", "raup"),
+				),
+			},
+			{
+				Config: testAccAzureRMStorageQueue_aclAndMetadataUpdated(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageQueueExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "metadata.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.panda", "pops"),
+					resource.TestCheckResourceAttr(resourceName, "acl.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "acl.0.access_policy.0.permissions", "rp"),
+					resource.TestCheckResourceAttr(resourceName, "acl.1.access_policy.0.expiry", "2019-07-02T11:38:21Z"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testCheckAzureRMStorageQueueExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 
@@ -178,3 +220,96 @@ resource "azurerm_storage_queue" "test" {
 }
 `, rInt, location, rString, rInt)
 }
+
+func testAccAzureRMStorageQueue_aclAndMetadata(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  tags {
+    environment = "staging"
+  }
+}
+
+resource "azurerm_storage_queue" "test" {
+  name                 = "mysamplequeue-%d"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_name = "${azurerm_storage_account.test.name}"
+
+  metadata {
+    hello = "world"
+  }
+
+  acl {
+    id = "MTIzNDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTI"
+
+    access_policy {
+      start       = "2019-07-02T09:38:21Z"
+      expiry      = "2019-07-02T10:38:21Z"
+      permissions = "raup"
+    }
+  }
+}
+`, rInt, location, rString, rInt)
+}
+
+func testAccAzureRMStorageQueue_aclAndMetadataUpdated(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  tags {
+    environment = "staging"
+  }
+}
+
+resource "azurerm_storage_queue" "test" {
+  name                 = "mysamplequeue-%d"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_name = "${azurerm_storage_account.test.name}"
+
+  metadata {
+    hello = "world"
+    panda = "pops"
+  }
+
+  acl {
+    id = "AAAANDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTI"
+
+    access_policy {
+      start       = "2019-07-02T09:38:21Z"
+      expiry      = "2019-07-02T10:38:21Z"
+      permissions = "rp"
+    }
+  }
+
+  acl {
+    id = "MTIzNDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTI"
+
+    access_policy {
+      start       = "2019-07-02T09:38:21Z"
+      expiry      = "2019-07-02T11:38:21Z"
+      permissions = "ra"
+    }
+  }
+}
+`, rInt, location, rString, rInt)
+}
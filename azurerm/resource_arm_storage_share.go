@@ -14,10 +14,10 @@ import (
 
 func resourceArmStorageShare() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceArmStorageShareCreate,
-		Read:   resourceArmStorageShareRead,
-		Update: resourceArmStorageShareUpdate,
-		Delete: resourceArmStorageShareDelete,
+		Create: retryOnStorageAuthenticationFailure(resourceArmStorageShareCreate),
+		Read:   retryOnStorageAuthenticationFailure(resourceArmStorageShareRead),
+		Update: retryOnStorageAuthenticationFailure(resourceArmStorageShareUpdate),
+		Delete: retryOnStorageAuthenticationFailure(resourceArmStorageShareDelete),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -43,6 +43,7 @@ func resourceArmStorageShare() *schema.Resource {
 				Default:      5120,
 				ValidateFunc: validation.IntBetween(1, 5120),
 			},
+			"acl": storageAccessPolicySchema("rcwdl"),
 			"url": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -90,6 +91,10 @@ func resourceArmStorageShareCreate(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("Error setting properties on Storage Share %q: %+v", name, err)
 	}
 
+	if err := resourceArmStorageShareSetAccessPolicies(d, armClient, resourceGroupName, storageAccountName, reference); err != nil {
+		return err
+	}
+
 	d.SetId(fmt.Sprintf("%s/%s/%s", name, resourceGroupName, storageAccountName))
 	return resourceArmStorageShareRead(d, meta)
 }
@@ -142,6 +147,33 @@ func resourceArmStorageShareRead(d *schema.ResourceData, meta interface{}) error
 	}
 	d.Set("quota", reference.Properties.Quota)
 
+	aclURI := fmt.Sprintf("%s?restype=share&comp=acl", reference.URL())
+	acls, _, err := armClient.getStorageAccessPolicies(ctx, resourceGroupName, storageAccountName, false, aclURI)
+	if err != nil {
+		return fmt.Errorf("Error retrieving the Stored Access Policies for Storage Share %q: %+v", name, err)
+	}
+
+	if err := d.Set("acl", flattenStorageAccessPolicies(acls)); err != nil {
+		return fmt.Errorf("Error setting `acl`: %+v", err)
+	}
+
+	return nil
+}
+
+// resourceArmStorageShareSetAccessPolicies sets the Stored Access Policies for the Share, which is done directly
+// since the Storage SDK doesn't support the Share ACL operations
+func resourceArmStorageShareSetAccessPolicies(d *schema.ResourceData, armClient *ArmClient, resourceGroupName, storageAccountName string, reference *storage.Share) error {
+	acls, err := expandStorageAccessPolicies(d.Get("acl").([]interface{}))
+	if err != nil {
+		return fmt.Errorf("Error expanding `acl` for Storage Share %q: %+v", reference.Name, err)
+	}
+
+	log.Printf("[INFO] Setting share %q Stored Access Policies in storage account %q", reference.Name, storageAccountName)
+	aclURI := fmt.Sprintf("%s?restype=share&comp=acl", reference.URL())
+	if err := armClient.setStorageAccessPolicies(armClient.StopContext, resourceGroupName, storageAccountName, false, aclURI, nil, acls); err != nil {
+		return fmt.Errorf("Error setting the Stored Access Policies for Storage Share %q: %+v", reference.Name, err)
+	}
+
 	return nil
 }
 
@@ -177,6 +209,12 @@ func resourceArmStorageShareUpdate(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("Error setting properties on Storage Share %q: %+v", name, err)
 	}
 
+	if d.HasChange("acl") {
+		if err := resourceArmStorageShareSetAccessPolicies(d, armClient, resourceGroupName, storageAccountName, reference); err != nil {
+			return err
+		}
+	}
+
 	return resourceArmStorageShareRead(d, meta)
 }
 
@@ -0,0 +1,286 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+)
+
+func resourceArmStorageShareDirectory() *schema.Resource {
+	return &schema.Resource{
+		Create: retryOnStorageAuthenticationFailure(resourceArmStorageShareDirectoryCreate),
+		Read:   retryOnStorageAuthenticationFailure(resourceArmStorageShareDirectoryRead),
+		Update: retryOnStorageAuthenticationFailure(resourceArmStorageShareDirectoryUpdate),
+		Delete: retryOnStorageAuthenticationFailure(resourceArmStorageShareDirectoryDelete),
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageShareDirectoryName,
+			},
+
+			"share_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageShareName,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"storage_account_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageAccountName,
+			},
+
+			"metadata": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validate.StorageMetaDataKeys,
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceArmStorageShareDirectoryCreate(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+	env := armClient.environment
+
+	resourceGroupName := d.Get("resource_group_name").(string)
+	storageAccountName := d.Get("storage_account_name").(string)
+
+	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(ctx, resourceGroupName, storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q Not Found", storageAccountName)
+	}
+
+	name := d.Get("name").(string)
+	shareName := d.Get("share_name").(string)
+
+	share := fileClient.GetShareReference(shareName)
+	directory := share.GetRootDirectoryReference().GetDirectoryReference(name)
+
+	log.Printf("[INFO] Creating Directory %q in Share %q within Storage Account %q", name, shareName, storageAccountName)
+	directory.Metadata = expandStorageShareMetaData(d.Get("metadata").(map[string]interface{}))
+	if err := directory.Create(&storage.FileRequestOptions{}); err != nil {
+		return fmt.Errorf("Error creating Directory %q (Share %q / Storage Account %q): %s", name, shareName, storageAccountName, err)
+	}
+
+	// gives us https://example.file.core.windows.net/share/directory
+	d.SetId(fmt.Sprintf("https://%s.file.%s/%s/%s", storageAccountName, env.StorageEndpointSuffix, shareName, name))
+
+	return resourceArmStorageShareDirectoryRead(d, meta)
+}
+
+func resourceArmStorageShareDirectoryRead(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageShareDirectoryID(d.Id(), armClient.environment)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup, err := determineResourceGroupForStorageAccount(id.storageAccountName, armClient)
+	if err != nil {
+		return err
+	}
+	if resourceGroup == nil {
+		log.Printf("[DEBUG] Unable to determine Resource Group for Storage Account %q - removing Directory %q from state", id.storageAccountName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(ctx, *resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		log.Printf("[DEBUG] Storage Account %q not found, removing Directory %q from state", id.storageAccountName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	share := fileClient.GetShareReference(id.shareName)
+	directory := share.GetRootDirectoryReference().GetDirectoryReference(id.directoryName)
+
+	exists, err := directory.Exists()
+	if err != nil {
+		return fmt.Errorf("Error checking for the presence of Directory %q (Share %q / Storage Account %q): %s", id.directoryName, id.shareName, id.storageAccountName, err)
+	}
+	if !exists {
+		log.Printf("[INFO] Directory %q no longer exists in Share %q, removing from state...", id.directoryName, id.shareName)
+		d.SetId("")
+		return nil
+	}
+
+	if err := directory.FetchAttributes(&storage.FileRequestOptions{}); err != nil {
+		return fmt.Errorf("Error retrieving Directory %q (Share %q / Storage Account %q): %s", id.directoryName, id.shareName, id.storageAccountName, err)
+	}
+
+	d.Set("name", id.directoryName)
+	d.Set("share_name", id.shareName)
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("storage_account_name", id.storageAccountName)
+	d.Set("url", directory.URL())
+
+	if err := d.Set("metadata", flattenStorageShareMetaData(directory.Metadata)); err != nil {
+		return fmt.Errorf("Error setting `metadata`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceArmStorageShareDirectoryUpdate(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageShareDirectoryID(d.Id(), armClient.environment)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(ctx, resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q Not Found", id.storageAccountName)
+	}
+
+	share := fileClient.GetShareReference(id.shareName)
+	directory := share.GetRootDirectoryReference().GetDirectoryReference(id.directoryName)
+
+	if d.HasChange("metadata") {
+		log.Printf("[INFO] Updating the MetaData for Directory %q (Share %q / Storage Account %q)", id.directoryName, id.shareName, id.storageAccountName)
+		directory.Metadata = expandStorageShareMetaData(d.Get("metadata").(map[string]interface{}))
+		if err := directory.SetMetadata(&storage.FileRequestOptions{}); err != nil {
+			return fmt.Errorf("Error updating the MetaData for Directory %q (Share %q / Storage Account %q): %s", id.directoryName, id.shareName, id.storageAccountName, err)
+		}
+	}
+
+	return resourceArmStorageShareDirectoryRead(d, meta)
+}
+
+func resourceArmStorageShareDirectoryDelete(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageShareDirectoryID(d.Id(), armClient.environment)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(ctx, resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		log.Printf("[INFO] Storage Account %q doesn't exist so the Directory won't exist", id.storageAccountName)
+		return nil
+	}
+
+	share := fileClient.GetShareReference(id.shareName)
+	directory := share.GetRootDirectoryReference().GetDirectoryReference(id.directoryName)
+
+	log.Printf("[INFO] Deleting Directory %q (Share %q / Storage Account %q)", id.directoryName, id.shareName, id.storageAccountName)
+	if _, err := directory.DeleteIfExists(&storage.FileRequestOptions{}); err != nil {
+		return fmt.Errorf("Error deleting Directory %q (Share %q / Storage Account %q): %s", id.directoryName, id.shareName, id.storageAccountName, err)
+	}
+
+	return nil
+}
+
+type storageShareDirectoryId struct {
+	storageAccountName string
+	shareName          string
+	directoryName      string
+}
+
+func parseStorageShareDirectoryID(input string, environment azure.Environment) (*storageShareDirectoryId, error) {
+	uri, err := url.Parse(input)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing %q as URI: %+v", input, err)
+	}
+
+	// trim the leading `/`
+	segments := strings.Split(strings.TrimPrefix(uri.Path, "/"), "/")
+	if len(segments) < 2 {
+		return nil, fmt.Errorf("Expected number of segments in the path to be at least 2 but got %d", len(segments))
+	}
+
+	storageAccountName := strings.Replace(uri.Host, fmt.Sprintf(".file.%s", environment.StorageEndpointSuffix), "", 1)
+	shareName := segments[0]
+	directoryName := strings.Join(segments[1:], "/")
+
+	id := storageShareDirectoryId{
+		storageAccountName: storageAccountName,
+		shareName:          shareName,
+		directoryName:      directoryName,
+	}
+	return &id, nil
+}
+
+func expandStorageShareMetaData(input map[string]interface{}) map[string]string {
+	output := make(map[string]string)
+
+	for k, v := range input {
+		output[k] = v.(string)
+	}
+
+	return output
+}
+
+func flattenStorageShareMetaData(input map[string]string) map[string]interface{} {
+	output := make(map[string]interface{})
+
+	for k, v := range input {
+		output[k] = v
+	}
+
+	return output
+}
+
+func validateArmStorageShareDirectoryName(v interface{}, k string) (warnings []string, errors []error) {
+	value := v.(string)
+
+	if value == "" || strings.HasPrefix(value, "/") || strings.HasSuffix(value, "/") {
+		errors = append(errors, fmt.Errorf("%q must not be empty or start or end with a `/`: %q", k, value))
+	}
+
+	for _, segment := range strings.Split(value, "/") {
+		if len(segment) > 255 {
+			errors = append(errors, fmt.Errorf("each segment of %q must be at most 255 characters: %q", k, value))
+		}
+
+		if strings.ContainsAny(segment, `"\:|<>*?`) {
+			errors = append(errors, fmt.Errorf("%q must not contain any of the characters `\" \\ : | < > * ?`: %q", k, value))
+		}
+	}
+
+	return warnings, errors
+}
@@ -0,0 +1,288 @@
+package azurerm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAzureRMStorageShareDirectory_basic(t *testing.T) {
+	resourceName := "azurerm_storage_share_directory.test"
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageShareDirectoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMStorageShareDirectory_basic(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageShareDirectoryExists(resourceName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageShareDirectory_nestedWithMetaData(t *testing.T) {
+	resourceName := "azurerm_storage_share_directory.child"
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageShareDirectoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMStorageShareDirectory_nestedWithMetaData(ri, rs, location, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageShareDirectoryExists("azurerm_storage_share_directory.parent"),
+					testCheckAzureRMStorageShareDirectoryExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "parent/child"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.hello", "first"),
+				),
+			},
+			{
+				Config: testAccAzureRMStorageShareDirectory_nestedWithMetaData(ri, rs, location, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageShareDirectoryExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "metadata.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.hello", "second"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckAzureRMStorageShareDirectoryExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		shareName := rs.Primary.Attributes["share_name"]
+		storageAccountName := rs.Primary.Attributes["storage_account_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		armClient := testAccProvider.Meta().(*ArmClient)
+		ctx := armClient.StopContext
+		fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(ctx, resourceGroup, storageAccountName)
+		if err != nil {
+			return err
+		}
+		if !accountExists {
+			return fmt.Errorf("Bad: Storage Account %q does not exist", storageAccountName)
+		}
+
+		directory := fileClient.GetShareReference(shareName).GetRootDirectoryReference().GetDirectoryReference(name)
+		exists, err := directory.Exists()
+		if err != nil {
+			return fmt.Errorf("Bad: Error checking for the presence of Directory %q (Share %q): %+v", name, shareName, err)
+		}
+
+		if !exists {
+			return fmt.Errorf("Bad: Directory %q (Share %q / Storage Account %q) does not exist", name, shareName, storageAccountName)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMStorageShareDirectoryDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_storage_share_directory" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+		shareName := rs.Primary.Attributes["share_name"]
+		storageAccountName := rs.Primary.Attributes["storage_account_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		armClient := testAccProvider.Meta().(*ArmClient)
+		ctx := armClient.StopContext
+		fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(ctx, resourceGroup, storageAccountName)
+		if err != nil {
+			return nil
+		}
+		if !accountExists {
+			return nil
+		}
+
+		directory := fileClient.GetShareReference(shareName).GetRootDirectoryReference().GetDirectoryReference(name)
+		exists, err := directory.Exists()
+		if err != nil {
+			return nil
+		}
+
+		if exists {
+			return fmt.Errorf("Bad: Directory %q (Share %q / Storage Account %q) still exists", name, shareName, storageAccountName)
+		}
+	}
+
+	return nil
+}
+
+func TestParseStorageShareDirectoryID(t *testing.T) {
+	environment := azure.PublicCloud
+
+	testData := []struct {
+		Input     string
+		Directory string
+		Error     bool
+	}{
+		{
+			Input: fmt.Sprintf("https://example.file.%s/share", environment.StorageEndpointSuffix),
+			Error: true,
+		},
+		{
+			Input:     fmt.Sprintf("https://example.file.%s/share/config", environment.StorageEndpointSuffix),
+			Directory: "config",
+		},
+		{
+			Input:     fmt.Sprintf("https://example.file.%s/share/config/nginx", environment.StorageEndpointSuffix),
+			Directory: "config/nginx",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := parseStorageShareDirectoryID(v.Input, environment)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expected a value but got an error: %s", err)
+		}
+
+		if v.Error {
+			t.Fatalf("Expected an error but didn't get one for %q", v.Input)
+		}
+
+		if actual.storageAccountName != "example" || actual.shareName != "share" {
+			t.Fatalf("Expected the Storage Account and Share to be `example` and `share` but got %q and %q", actual.storageAccountName, actual.shareName)
+		}
+
+		if actual.directoryName != v.Directory {
+			t.Fatalf("Expected the Directory to be %q but got %q", v.Directory, actual.directoryName)
+		}
+	}
+}
+
+func TestValidateArmStorageShareDirectoryName(t *testing.T) {
+	validNames := []string{
+		"config",
+		"config/nginx",
+		"with spaces",
+		strings.Repeat("w", 255),
+	}
+	for _, v := range validNames {
+		_, errors := validateArmStorageShareDirectoryName(v, "name")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid Directory Name: %q", v, errors)
+		}
+	}
+
+	invalidNames := []string{
+		"",
+		"/config",
+		"config/",
+		"con:fig",
+		"config?",
+		strings.Repeat("w", 256),
+	}
+	for _, v := range invalidNames {
+		_, errors := validateArmStorageShareDirectoryName(v, "name")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid Directory Name", v)
+		}
+	}
+}
+
+func testAccAzureRMStorageShareDirectory_template(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_share" "test" {
+  name                 = "testshare"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_name = "${azurerm_storage_account.test.name}"
+  quota                = 5
+}
+`, rInt, location, rString)
+}
+
+func testAccAzureRMStorageShareDirectory_basic(rInt int, rString string, location string) string {
+	template := testAccAzureRMStorageShareDirectory_template(rInt, rString, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_share_directory" "test" {
+  name                 = "dir"
+  share_name           = "${azurerm_storage_share.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_name = "${azurerm_storage_account.test.name}"
+}
+`, template)
+}
+
+func testAccAzureRMStorageShareDirectory_nestedWithMetaData(rInt int, rString string, location string, metaDataValue string) string {
+	template := testAccAzureRMStorageShareDirectory_template(rInt, rString, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_share_directory" "parent" {
+  name                 = "parent"
+  share_name           = "${azurerm_storage_share.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_name = "${azurerm_storage_account.test.name}"
+}
+
+resource "azurerm_storage_share_directory" "child" {
+  name                 = "${azurerm_storage_share_directory.parent.name}/child"
+  share_name           = "${azurerm_storage_share.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_name = "${azurerm_storage_account.test.name}"
+
+  metadata {
+    hello = "%s"
+  }
+}
+`, template, metaDataValue)
+}
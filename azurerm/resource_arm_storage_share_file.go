@@ -0,0 +1,504 @@
+package azurerm
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmStorageShareFile() *schema.Resource {
+	return &schema.Resource{
+		Create: retryOnStorageAuthenticationFailure(resourceArmStorageShareFileCreate),
+		Read:   retryOnStorageAuthenticationFailure(resourceArmStorageShareFileRead),
+		Update: retryOnStorageAuthenticationFailure(resourceArmStorageShareFileUpdate),
+		Delete: retryOnStorageAuthenticationFailure(resourceArmStorageShareFileDelete),
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		CustomizeDiff: resourceArmStorageShareFileCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageShareFileName,
+			},
+
+			"path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "",
+				ValidateFunc: validateArmStorageShareFilePath,
+			},
+
+			"share_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageShareName,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"storage_account_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageAccountName,
+			},
+
+			"source": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"content_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "application/octet-stream",
+			},
+
+			"content_md5": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"metadata": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validate.StorageMetaDataKeys,
+			},
+
+			"parallelism": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      4,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"attempts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceArmStorageShareFileCreate(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+	env := armClient.environment
+
+	resourceGroupName := d.Get("resource_group_name").(string)
+	storageAccountName := d.Get("storage_account_name").(string)
+
+	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(ctx, resourceGroupName, storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q Not Found", storageAccountName)
+	}
+
+	name := d.Get("name").(string)
+	path := d.Get("path").(string)
+	shareName := d.Get("share_name").(string)
+
+	file := getStorageShareFileReference(fileClient, shareName, path, name)
+
+	log.Printf("[INFO] Creating File %q in Share %q within Storage Account %q", name, shareName, storageAccountName)
+	if err := resourceArmStorageShareFileCreateFromSource(d, file); err != nil {
+		return fmt.Errorf("Error creating File %q (Share %q / Storage Account %q): %s", name, shareName, storageAccountName, err)
+	}
+
+	// gives us https://example.file.core.windows.net/share/path/file.txt
+	id := fmt.Sprintf("https://%s.file.%s/%s/%s", storageAccountName, env.StorageEndpointSuffix, shareName, name)
+	if path != "" {
+		id = fmt.Sprintf("https://%s.file.%s/%s/%s/%s", storageAccountName, env.StorageEndpointSuffix, shareName, path, name)
+	}
+	d.SetId(id)
+
+	return resourceArmStorageShareFileRead(d, meta)
+}
+
+func resourceArmStorageShareFileRead(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageShareFileID(d.Id(), armClient.environment)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup, err := determineResourceGroupForStorageAccount(id.storageAccountName, armClient)
+	if err != nil {
+		return err
+	}
+	if resourceGroup == nil {
+		log.Printf("[DEBUG] Unable to determine Resource Group for Storage Account %q - removing File %q from state", id.storageAccountName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(ctx, *resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		log.Printf("[DEBUG] Storage Account %q not found, removing File %q from state", id.storageAccountName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	file := getStorageShareFileReference(fileClient, id.shareName, id.path, id.fileName)
+
+	exists, err := file.Exists()
+	if err != nil {
+		return fmt.Errorf("Error checking for the presence of File %q (Share %q / Storage Account %q): %s", id.fileName, id.shareName, id.storageAccountName, err)
+	}
+	if !exists {
+		log.Printf("[INFO] File %q no longer exists in Share %q, removing from state...", id.fileName, id.shareName)
+		d.SetId("")
+		return nil
+	}
+
+	if err := file.FetchAttributes(&storage.FileRequestOptions{}); err != nil {
+		return fmt.Errorf("Error retrieving File %q (Share %q / Storage Account %q): %s", id.fileName, id.shareName, id.storageAccountName, err)
+	}
+
+	d.Set("name", id.fileName)
+	d.Set("path", id.path)
+	d.Set("share_name", id.shareName)
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("storage_account_name", id.storageAccountName)
+	d.Set("content_type", file.Properties.Type)
+	d.Set("content_md5", file.Properties.MD5)
+	d.Set("url", file.URL())
+
+	if err := d.Set("metadata", flattenStorageShareMetaData(file.Metadata)); err != nil {
+		return fmt.Errorf("Error setting `metadata`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceArmStorageShareFileUpdate(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageShareFileID(d.Id(), armClient.environment)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(ctx, resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q Not Found", id.storageAccountName)
+	}
+
+	file := getStorageShareFileReference(fileClient, id.shareName, id.path, id.fileName)
+
+	if d.HasChange("content_md5") && d.Get("source").(string) != "" {
+		// creating the File again replaces it, including the properties and MetaData
+		log.Printf("[INFO] The content of the source has changed - uploading File %q (Share %q / Storage Account %q) again", id.fileName, id.shareName, id.storageAccountName)
+		if err := resourceArmStorageShareFileCreateFromSource(d, file); err != nil {
+			return fmt.Errorf("Error uploading File %q (Share %q / Storage Account %q): %s", id.fileName, id.shareName, id.storageAccountName, err)
+		}
+
+		return resourceArmStorageShareFileRead(d, meta)
+	}
+
+	if d.HasChange("content_type") {
+		err := updateStorageProperties(
+			func() error { return file.FetchAttributes(&storage.FileRequestOptions{}) },
+			func() { file.Properties.Type = d.Get("content_type").(string) },
+			func() error { return file.SetProperties(&storage.FileRequestOptions{}) })
+		if err != nil {
+			return fmt.Errorf("Error updating the properties of File %q (Share %q / Storage Account %q): %s", id.fileName, id.shareName, id.storageAccountName, err)
+		}
+	}
+
+	if d.HasChange("metadata") {
+		file.Metadata = expandStorageShareMetaData(d.Get("metadata").(map[string]interface{}))
+		if err := file.SetMetadata(&storage.FileRequestOptions{}); err != nil {
+			return fmt.Errorf("Error updating the MetaData for File %q (Share %q / Storage Account %q): %s", id.fileName, id.shareName, id.storageAccountName, err)
+		}
+	}
+
+	return resourceArmStorageShareFileRead(d, meta)
+}
+
+func resourceArmStorageShareFileDelete(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageShareFileID(d.Id(), armClient.environment)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(ctx, resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		log.Printf("[INFO] Storage Account %q doesn't exist so the File won't exist", id.storageAccountName)
+		return nil
+	}
+
+	file := getStorageShareFileReference(fileClient, id.shareName, id.path, id.fileName)
+
+	log.Printf("[INFO] Deleting File %q (Share %q / Storage Account %q)", id.fileName, id.shareName, id.storageAccountName)
+	if _, err := file.DeleteIfExists(&storage.FileRequestOptions{}); err != nil {
+		return fmt.Errorf("Error deleting File %q (Share %q / Storage Account %q): %s", id.fileName, id.shareName, id.storageAccountName, err)
+	}
+
+	return nil
+}
+
+func resourceArmStorageShareFileCustomizeDiff(d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("source") {
+		return nil
+	}
+
+	return diffContentMD5ForStorageSource(d, d.Get("source").(string), "")
+}
+
+// resourceArmStorageShareFileCreateFromSource creates (or replaces) the File and then uploads the source into it
+func resourceArmStorageShareFileCreateFromSource(d *schema.ResourceData, file *storage.File) error {
+	source, err := resourceArmStorageBlobOpenSource(d.Get("source").(string), "")
+	if err != nil {
+		return err
+	}
+
+	size := int64(0)
+	file.Properties.MD5 = ""
+	if source != nil {
+		defer utils.IoCloseAndLogError(source, fmt.Sprintf("Error closing source %s for File %q after upload", source.name, file.Name))
+
+		size = source.reader.Size()
+
+		// the Content MD5 isn't calculated by the API when the File is uploaded in ranges, so this is set when it's created
+		file.Properties.MD5, err = source.contentMD5()
+		if err != nil {
+			return err
+		}
+	}
+
+	file.Properties.Type = d.Get("content_type").(string)
+	file.Metadata = expandStorageShareMetaData(d.Get("metadata").(map[string]interface{}))
+	if err := file.Create(uint64(size), &storage.FileRequestOptions{}); err != nil {
+		return err
+	}
+
+	if source == nil {
+		return nil
+	}
+
+	parallelism := d.Get("parallelism").(int)
+	attempts := d.Get("attempts").(int)
+	return resourceArmStorageShareFileUploadFromSource(file, source, parallelism, attempts)
+}
+
+type resourceArmStorageShareFileRange struct {
+	offset  int64
+	section *io.SectionReader
+}
+
+func resourceArmStorageShareFileUploadFromSource(file *storage.File, source *resourceArmStorageBlobSource, parallelism, attempts int) error {
+	workerCount := parallelism * runtime.NumCPU()
+
+	rangeList, err := resourceArmStorageShareFileSplit(source.reader)
+	if err != nil {
+		return fmt.Errorf("Error splitting source %s into ranges: %s", source.name, err)
+	}
+
+	ranges := make(chan resourceArmStorageShareFileRange, len(rangeList))
+	errors := make(chan error, len(rangeList))
+	wg := &sync.WaitGroup{}
+	wg.Add(len(rangeList))
+
+	for _, r := range rangeList {
+		ranges <- r
+	}
+	close(ranges)
+
+	for i := 0; i < workerCount; i++ {
+		go resourceArmStorageShareFileUploadWorker(resourceArmStorageShareFileUploadContext{
+			file:     file,
+			source:   source.name,
+			ranges:   ranges,
+			errors:   errors,
+			wg:       wg,
+			attempts: attempts,
+		})
+	}
+
+	wg.Wait()
+
+	if len(errors) > 0 {
+		return fmt.Errorf("Error while uploading source %s: %s", source.name, <-errors)
+	}
+
+	return nil
+}
+
+func resourceArmStorageShareFileSplit(source *io.SectionReader) ([]resourceArmStorageShareFileRange, error) {
+	rangeSize := int64(storage.MaxRangeSize)
+
+	var ranges []resourceArmStorageShareFileRange
+	for offset := int64(0); offset < source.Size(); offset += rangeSize {
+		size := rangeSize
+		if remainder := source.Size() - offset; remainder < rangeSize {
+			size = remainder
+		}
+
+		chunk := make([]byte, size)
+		if _, err := source.ReadAt(chunk, offset); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("Could not read chunk at %d: %s", offset, err)
+		}
+
+		// a new File is filled with zeros, so ranges which only contain zeros don't need to be uploaded
+		if bytes.Equal(chunk, make([]byte, size)) {
+			continue
+		}
+
+		ranges = append(ranges, resourceArmStorageShareFileRange{
+			offset:  offset,
+			section: io.NewSectionReader(source, offset, size),
+		})
+	}
+
+	return ranges, nil
+}
+
+type resourceArmStorageShareFileUploadContext struct {
+	file     *storage.File
+	source   string
+	ranges   chan resourceArmStorageShareFileRange
+	errors   chan error
+	wg       *sync.WaitGroup
+	attempts int
+}
+
+func resourceArmStorageShareFileUploadWorker(ctx resourceArmStorageShareFileUploadContext) {
+	for r := range ctx.ranges {
+		chunk := make([]byte, r.section.Size())
+		_, err := r.section.ReadAt(chunk, 0)
+		if err != nil && err != io.EOF {
+			ctx.errors <- fmt.Errorf("Error reading source %s at offset %d: %s", ctx.source, r.offset, err)
+			ctx.wg.Done()
+			continue
+		}
+
+		hash := md5.Sum(chunk)
+		fileRange := storage.FileRange{
+			Start: uint64(r.offset),
+			End:   uint64(r.offset + r.section.Size() - 1),
+		}
+		options := &storage.WriteRangeOptions{
+			ContentMD5: base64.StdEncoding.EncodeToString(hash[:]),
+		}
+
+		for i := 0; i < ctx.attempts; i++ {
+			if err = ctx.file.WriteRange(bytes.NewReader(chunk), fileRange, options); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			ctx.errors <- fmt.Errorf("Error writing range at offset %d for source %s: %s", r.offset, ctx.source, err)
+			ctx.wg.Done()
+			continue
+		}
+
+		ctx.wg.Done()
+	}
+}
+
+func getStorageShareFileReference(client *storage.FileServiceClient, shareName, path, fileName string) *storage.File {
+	directory := client.GetShareReference(shareName).GetRootDirectoryReference()
+	if path != "" {
+		directory = directory.GetDirectoryReference(path)
+	}
+
+	return directory.GetFileReference(fileName)
+}
+
+type storageShareFileId struct {
+	storageAccountName string
+	shareName          string
+	path               string
+	fileName           string
+}
+
+func parseStorageShareFileID(input string, environment azure.Environment) (*storageShareFileId, error) {
+	uri, err := url.Parse(input)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing %q as URI: %+v", input, err)
+	}
+
+	// trim the leading `/`
+	segments := strings.Split(strings.TrimPrefix(uri.Path, "/"), "/")
+	if len(segments) < 2 {
+		return nil, fmt.Errorf("Expected number of segments in the path to be at least 2 but got %d", len(segments))
+	}
+
+	storageAccountName := strings.Replace(uri.Host, fmt.Sprintf(".file.%s", environment.StorageEndpointSuffix), "", 1)
+
+	id := storageShareFileId{
+		storageAccountName: storageAccountName,
+		shareName:          segments[0],
+		path:               strings.Join(segments[1:len(segments)-1], "/"),
+		fileName:           segments[len(segments)-1],
+	}
+	return &id, nil
+}
+
+func validateArmStorageShareFileName(v interface{}, k string) (warnings []string, errors []error) {
+	value := v.(string)
+
+	if strings.Contains(value, "/") {
+		errors = append(errors, fmt.Errorf("%q must not contain a `/` - the directory should be specified in `path` instead: %q", k, value))
+		return warnings, errors
+	}
+
+	return validateArmStorageShareDirectoryName(v, k)
+}
+
+func validateArmStorageShareFilePath(v interface{}, k string) (warnings []string, errors []error) {
+	// files can be created in the root of the share
+	if v.(string) == "" {
+		return warnings, errors
+	}
+
+	return validateArmStorageShareDirectoryName(v, k)
+}
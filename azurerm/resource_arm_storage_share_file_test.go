@@ -0,0 +1,350 @@
+package azurerm
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAzureRMStorageShareFile_basic(t *testing.T) {
+	resourceName := "azurerm_storage_share_file.test"
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	config := testAccAzureRMStorageShareFile_basic(ri, rs, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageShareFileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageShareFileMatchesContent(resourceName, []byte{}),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"attempts", "parallelism"},
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageShareFile_source(t *testing.T) {
+	resourceName := "azurerm_storage_share_file.test"
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	sourceFile, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatalf("Failed to create local source file")
+	}
+	defer os.Remove(sourceFile.Name())
+
+	writeSourceFile := func(marker string) func() {
+		return func() {
+			if err := sourceFile.Truncate(0); err != nil {
+				t.Fatalf("Failed to truncate source file")
+			}
+
+			// include a range of zeros, which is skipped when uploading
+			if err := sourceFile.Truncate(12 * 1024 * 1024); err != nil {
+				t.Fatalf("Failed to extend source file")
+			}
+
+			if _, err := sourceFile.Seek(0, io.SeekStart); err != nil {
+				t.Fatalf("Failed to seek source file")
+			}
+
+			if _, err := io.CopyN(sourceFile, rand.Reader, 5*1024*1024); err != nil {
+				t.Fatalf("Failed to write random data to source file")
+			}
+
+			if _, err := sourceFile.WriteAt([]byte(marker), 11*1024*1024); err != nil {
+				t.Fatalf("Failed to write to source file")
+			}
+		}
+	}
+	writeSourceFile("first")()
+
+	config := testAccAzureRMStorageShareFile_source(ri, rs, sourceFile.Name(), testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageShareFileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageShareFileMatchesFile(resourceName, sourceFile.Name()),
+					resource.TestCheckResourceAttrSet(resourceName, "content_md5"),
+					resource.TestCheckResourceAttr(resourceName, "metadata.%", "1"),
+				),
+			},
+			{
+				PreConfig: writeSourceFile("second"),
+				Config:    config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageShareFileMatchesFile(resourceName, sourceFile.Name()),
+					resource.TestCheckResourceAttr(resourceName, "metadata.%", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMStorageShareFileMatchesFile(resourceName string, filePath string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		expected, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+
+		return testCheckAzureRMStorageShareFileMatchesContent(resourceName, expected)(s)
+	}
+}
+
+func testCheckAzureRMStorageShareFileMatchesContent(resourceName string, expected []byte) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		path := rs.Primary.Attributes["path"]
+		shareName := rs.Primary.Attributes["share_name"]
+		storageAccountName := rs.Primary.Attributes["storage_account_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		armClient := testAccProvider.Meta().(*ArmClient)
+		ctx := armClient.StopContext
+		fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(ctx, resourceGroup, storageAccountName)
+		if err != nil {
+			return err
+		}
+		if !accountExists {
+			return fmt.Errorf("Bad: Storage Account %q does not exist", storageAccountName)
+		}
+
+		file := getStorageShareFileReference(fileClient, shareName, path, name)
+		exists, err := file.Exists()
+		if err != nil {
+			return fmt.Errorf("Bad: Error checking for the presence of File %q (Share %q): %+v", name, shareName, err)
+		}
+		if !exists {
+			return fmt.Errorf("Bad: File %q (Share %q / Storage Account %q) does not exist", name, shareName, storageAccountName)
+		}
+
+		if len(expected) == 0 {
+			if file.Properties.Length != 0 {
+				return fmt.Errorf("Bad: File %q (Share %q) was expected to be empty but was %d bytes", name, shareName, file.Properties.Length)
+			}
+
+			return nil
+		}
+
+		stream, err := file.DownloadToStream(nil)
+		if err != nil {
+			return err
+		}
+		defer stream.Close()
+
+		actual, err := ioutil.ReadAll(stream)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(actual, expected) {
+			return fmt.Errorf("Bad: File %q (Share %q) does not match contents", name, shareName)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMStorageShareFileDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_storage_share_file" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+		path := rs.Primary.Attributes["path"]
+		shareName := rs.Primary.Attributes["share_name"]
+		storageAccountName := rs.Primary.Attributes["storage_account_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		armClient := testAccProvider.Meta().(*ArmClient)
+		ctx := armClient.StopContext
+		fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(ctx, resourceGroup, storageAccountName)
+		if err != nil {
+			return nil
+		}
+		if !accountExists {
+			return nil
+		}
+
+		file := getStorageShareFileReference(fileClient, shareName, path, name)
+		exists, err := file.Exists()
+		if err != nil {
+			return nil
+		}
+
+		if exists {
+			return fmt.Errorf("Bad: File %q (Share %q / Storage Account %q) still exists", name, shareName, storageAccountName)
+		}
+	}
+
+	return nil
+}
+
+func TestParseStorageShareFileID(t *testing.T) {
+	environment := azure.PublicCloud
+
+	testData := []struct {
+		Input string
+		Path  string
+		Name  string
+		Error bool
+	}{
+		{
+			Input: fmt.Sprintf("https://example.file.%s/share", environment.StorageEndpointSuffix),
+			Error: true,
+		},
+		{
+			Input: fmt.Sprintf("https://example.file.%s/share/file.txt", environment.StorageEndpointSuffix),
+			Path:  "",
+			Name:  "file.txt",
+		},
+		{
+			Input: fmt.Sprintf("https://example.file.%s/share/config/nginx/nginx.conf", environment.StorageEndpointSuffix),
+			Path:  "config/nginx",
+			Name:  "nginx.conf",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := parseStorageShareFileID(v.Input, environment)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expected a value but got an error: %s", err)
+		}
+
+		if v.Error {
+			t.Fatalf("Expected an error but didn't get one for %q", v.Input)
+		}
+
+		if actual.storageAccountName != "example" || actual.shareName != "share" {
+			t.Fatalf("Expected the Storage Account and Share to be `example` and `share` but got %q and %q", actual.storageAccountName, actual.shareName)
+		}
+
+		if actual.path != v.Path || actual.fileName != v.Name {
+			t.Fatalf("Expected the Path and Name to be %q and %q but got %q and %q", v.Path, v.Name, actual.path, actual.fileName)
+		}
+	}
+}
+
+func TestResourceArmStorageShareFileSplit(t *testing.T) {
+	rangeSize := 4 * 1024 * 1024
+	content := make([]byte, 3*rangeSize+10)
+	copy(content[1:], []byte("first"))
+	copy(content[3*rangeSize:], []byte("last"))
+
+	ranges, err := resourceArmStorageShareFileSplit(io.NewSectionReader(bytes.NewReader(content), 0, int64(len(content))))
+	if err != nil {
+		t.Fatalf("Error splitting content: %+v", err)
+	}
+
+	// the second and third ranges only contain zeros, so shouldn't be uploaded
+	if len(ranges) != 2 {
+		t.Fatalf("Expected 2 ranges but got %d", len(ranges))
+	}
+
+	if ranges[0].offset != 0 || ranges[0].section.Size() != int64(rangeSize) {
+		t.Fatalf("Expected the first range to be at offset 0 with size %d but got %d / %d", rangeSize, ranges[0].offset, ranges[0].section.Size())
+	}
+
+	if ranges[1].offset != int64(3*rangeSize) || ranges[1].section.Size() != 10 {
+		t.Fatalf("Expected the last range to be at offset %d with size 10 but got %d / %d", 3*rangeSize, ranges[1].offset, ranges[1].section.Size())
+	}
+}
+
+func TestValidateArmStorageShareFileName(t *testing.T) {
+	validNames := []string{
+		"file.txt",
+		"nginx.conf",
+	}
+	for _, v := range validNames {
+		_, errors := validateArmStorageShareFileName(v, "name")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid File Name: %q", v, errors)
+		}
+	}
+
+	invalidNames := []string{
+		"",
+		"config/nginx.conf",
+		"file?.txt",
+	}
+	for _, v := range invalidNames {
+		_, errors := validateArmStorageShareFileName(v, "name")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid File Name", v)
+		}
+	}
+}
+
+func testAccAzureRMStorageShareFile_basic(rInt int, rString string, location string) string {
+	template := testAccAzureRMStorageShareDirectory_template(rInt, rString, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_share_file" "test" {
+  name                 = "empty.txt"
+  share_name           = "${azurerm_storage_share.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_name = "${azurerm_storage_account.test.name}"
+}
+`, template)
+}
+
+func testAccAzureRMStorageShareFile_source(rInt int, rString string, sourceFileName string, location string) string {
+	template := testAccAzureRMStorageShareDirectory_basic(rInt, rString, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_share_file" "test" {
+  name                 = "source.bin"
+  path                 = "${azurerm_storage_share_directory.test.name}"
+  share_name           = "${azurerm_storage_share.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_name = "${azurerm_storage_account.test.name}"
+  source               = "%s"
+  parallelism          = 2
+  attempts             = 2
+
+  metadata {
+    hello = "world"
+  }
+}
+`, template, sourceFileName)
+}
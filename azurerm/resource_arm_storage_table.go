@@ -13,9 +13,10 @@ import (
 
 func resourceArmStorageTable() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceArmStorageTableCreate,
-		Read:   resourceArmStorageTableRead,
-		Delete: resourceArmStorageTableDelete,
+		Create: retryOnStorageAuthenticationFailure(resourceArmStorageTableCreate),
+		Read:   retryOnStorageAuthenticationFailure(resourceArmStorageTableRead),
+		Update: retryOnStorageAuthenticationFailure(resourceArmStorageTableUpdate),
+		Delete: retryOnStorageAuthenticationFailure(resourceArmStorageTableDelete),
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -35,6 +36,7 @@ func resourceArmStorageTable() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"acl": storageAccessPolicySchema("raud"),
 		},
 	}
 }
@@ -83,6 +85,18 @@ func resourceArmStorageTableCreate(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("Error creating table %q in storage account %q: %s", name, storageAccountName, err)
 	}
 
+	if acls := d.Get("acl").([]interface{}); len(acls) > 0 {
+		policies, err := expandStorageTableAccessPolicies(acls)
+		if err != nil {
+			return fmt.Errorf("Error expanding `acl` for table %q in storage account %q: %+v", name, storageAccountName, err)
+		}
+
+		log.Printf("[INFO] Setting the Stored Access Policies for table %q in storage account %q.", name, storageAccountName)
+		if err := table.SetPermissions(policies, timeout, options); err != nil {
+			return fmt.Errorf("Error setting the Stored Access Policies for table %q in storage account %q: %s", name, storageAccountName, err)
+		}
+	}
+
 	id := fmt.Sprintf("https://%s.table.%s/%s", storageAccountName, environment.StorageEndpointSuffix, name)
 	d.SetId(id)
 	return resourceArmStorageTableRead(d, meta)
@@ -144,9 +158,54 @@ func resourceArmStorageTableRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("storage_account_name", id.storageAccountName)
 	d.Set("resource_group_name", resourceGroup)
 
+	table := tableClient.GetTableReference(id.tableName)
+	policies, err := table.GetPermissions(60, &storage.TableOptions{})
+	if err != nil {
+		return fmt.Errorf("Error retrieving the Stored Access Policies for table %q in storage account %q: %s", id.tableName, id.storageAccountName, err)
+	}
+
+	if err := d.Set("acl", flattenStorageTableAccessPolicies(policies)); err != nil {
+		return fmt.Errorf("Error setting `acl`: %+v", err)
+	}
+
 	return nil
 }
 
+func resourceArmStorageTableUpdate(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageTableID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(ctx, resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q Not Found", id.storageAccountName)
+	}
+
+	table := tableClient.GetTableReference(id.tableName)
+
+	if d.HasChange("acl") {
+		policies, err := expandStorageTableAccessPolicies(d.Get("acl").([]interface{}))
+		if err != nil {
+			return fmt.Errorf("Error expanding `acl` for table %q in storage account %q: %+v", id.tableName, id.storageAccountName, err)
+		}
+
+		log.Printf("[INFO] Updating the Stored Access Policies for table %q in storage account %q.", id.tableName, id.storageAccountName)
+		if err := table.SetPermissions(policies, 60, &storage.TableOptions{}); err != nil {
+			return fmt.Errorf("Error updating the Stored Access Policies for table %q in storage account %q: %s", id.tableName, id.storageAccountName, err)
+		}
+	}
+
+	return resourceArmStorageTableRead(d, meta)
+}
+
 func resourceArmStorageTableDelete(d *schema.ResourceData, meta interface{}) error {
 	armClient := meta.(*ArmClient)
 	ctx := armClient.StopContext
@@ -212,3 +271,56 @@ func parseStorageTableID(input string) (*storageTableId, error) {
 
 	return nil, nil
 }
+
+func expandStorageTableAccessPolicies(input []interface{}) ([]storage.TableAccessPolicy, error) {
+	identifiers, err := expandStorageAccessPolicies(input)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]storage.TableAccessPolicy, 0)
+	for _, v := range identifiers {
+		policies = append(policies, storage.TableAccessPolicy{
+			ID:         v.ID,
+			StartTime:  v.AccessPolicy.StartTime,
+			ExpiryTime: v.AccessPolicy.ExpiryTime,
+			CanRead:    strings.Contains(v.AccessPolicy.Permission, "r"),
+			CanAppend:  strings.Contains(v.AccessPolicy.Permission, "a"),
+			CanUpdate:  strings.Contains(v.AccessPolicy.Permission, "u"),
+			CanDelete:  strings.Contains(v.AccessPolicy.Permission, "d"),
+		})
+	}
+
+	return policies, nil
+}
+
+func flattenStorageTableAccessPolicies(input []storage.TableAccessPolicy) []interface{} {
+	identifiers := make([]storage.SignedIdentifier, 0)
+
+	for _, v := range input {
+		permissions := ""
+		if v.CanRead {
+			permissions += "r"
+		}
+		if v.CanAppend {
+			permissions += "a"
+		}
+		if v.CanUpdate {
+			permissions += "u"
+		}
+		if v.CanDelete {
+			permissions += "d"
+		}
+
+		identifiers = append(identifiers, storage.SignedIdentifier{
+			ID: v.ID,
+			AccessPolicy: storage.AccessPolicyDetailsXML{
+				StartTime:  v.StartTime,
+				ExpiryTime: v.ExpiryTime,
+				Permission: permissions,
+			},
+		})
+	}
+
+	return flattenStorageAccessPolicies(identifiers)
+}
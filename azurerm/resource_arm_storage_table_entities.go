@@ -0,0 +1,428 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// a Batch Transaction can contain at most 100 operations
+// - see https://docs.microsoft.com/en-us/rest/api/storageservices/performing-entity-group-transactions
+const storageTableEntitiesMaxBatchOperations = 100
+
+func resourceArmStorageTableEntities() *schema.Resource {
+	return &schema.Resource{
+		Create: retryOnStorageAuthenticationFailure(resourceArmStorageTableEntitiesCreate),
+		Read:   retryOnStorageAuthenticationFailure(resourceArmStorageTableEntitiesRead),
+		Update: retryOnStorageAuthenticationFailure(resourceArmStorageTableEntitiesUpdate),
+		Delete: retryOnStorageAuthenticationFailure(resourceArmStorageTableEntitiesDelete),
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"table_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageTableName,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"storage_account_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageAccountName,
+			},
+
+			// Batch Transactions can only contain Entities within a single Partition
+			"partition_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageTableEntityKey,
+			},
+
+			"entity": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				MaxItems: storageTableEntitiesMaxBatchOperations,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"row_key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateArmStorageTableEntityKey,
+						},
+
+						"properties": storageTableEntityPropertiesSchema(),
+
+						"property_types": storageTableEntityPropertyTypesSchema(),
+
+						"etag": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceArmStorageTableEntitiesCreate(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+	environment := armClient.environment
+
+	tableName := d.Get("table_name").(string)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	storageAccountName := d.Get("storage_account_name").(string)
+	partitionKey := d.Get("partition_key").(string)
+
+	tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(ctx, resourceGroupName, storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q Not Found", storageAccountName)
+	}
+
+	table := tableClient.GetTableReference(tableName)
+	entities, err := expandStorageTableEntities(table, partitionKey, d.Get("entity").([]interface{}))
+	if err != nil {
+		return fmt.Errorf("Error expanding `entity` for Partition %q (Table %q / Storage Account %q): %+v", partitionKey, tableName, storageAccountName, err)
+	}
+
+	// Insert fails if any of the Entities already exist, in which case none of them are created
+	batch := table.NewBatch()
+	for _, entity := range entities {
+		batch.InsertEntity(entity)
+	}
+
+	log.Printf("[INFO] Creating %d Entities in Partition %q of Table %q within Storage Account %q", len(entities), partitionKey, tableName, storageAccountName)
+	if err := batch.ExecuteBatch(); err != nil {
+		return fmt.Errorf("Error creating Entities in Partition %q (Table %q / Storage Account %q): %s", partitionKey, tableName, storageAccountName, err)
+	}
+
+	d.SetId(storageTableEntityID(storageAccountName, environment, tableName, partitionKey))
+	return resourceArmStorageTableEntitiesRead(d, meta)
+}
+
+func resourceArmStorageTableEntitiesRead(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageTableEntitySegments(d.Id(), armClient.environment, 2)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup, err := determineResourceGroupForStorageAccount(id.storageAccountName, armClient)
+	if err != nil {
+		return err
+	}
+	if resourceGroup == nil {
+		log.Printf("[DEBUG] Unable to determine Resource Group for Storage Account %q - removing Entities %q from state", id.storageAccountName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(ctx, *resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		log.Printf("[DEBUG] Storage Account %q not found, removing Entities %q from state", id.storageAccountName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	table := tableClient.GetTableReference(id.tableName)
+	entities, err := queryStorageTableEntitiesInPartition(table, id.partitionKey)
+	if err != nil {
+		if isStorageTableEntityNotFound(err) {
+			log.Printf("[INFO] Table %q no longer exists in Storage Account %q, removing Entities %q from state...", id.tableName, id.storageAccountName, d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Entities in Partition %q (Table %q / Storage Account %q): %s", id.partitionKey, id.tableName, id.storageAccountName, err)
+	}
+
+	output := flattenStorageTableEntities(entities, d.Get("entity").([]interface{}))
+	if len(output) == 0 {
+		log.Printf("[INFO] None of the Entities in Partition %q exist in Table %q, removing from state...", id.partitionKey, id.tableName)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("table_name", id.tableName)
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("storage_account_name", id.storageAccountName)
+	d.Set("partition_key", id.partitionKey)
+
+	if err := d.Set("entity", output); err != nil {
+		return fmt.Errorf("Error setting `entity`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceArmStorageTableEntitiesUpdate(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageTableEntitySegments(d.Id(), armClient.environment, 2)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(ctx, resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q Not Found", id.storageAccountName)
+	}
+
+	if d.HasChange("entity") {
+		table := tableClient.GetTableReference(id.tableName)
+
+		o, n := d.GetChange("entity")
+		batch, err := expandStorageTableEntitiesBatch(table, id.partitionKey, o.([]interface{}), n.([]interface{}))
+		if err != nil {
+			return fmt.Errorf("Error expanding `entity` for Partition %q (Table %q / Storage Account %q): %+v", id.partitionKey, id.tableName, id.storageAccountName, err)
+		}
+
+		if len(batch.BatchEntitySlice) > 0 {
+			log.Printf("[INFO] Updating %d Entities in Partition %q of Table %q within Storage Account %q", len(batch.BatchEntitySlice), id.partitionKey, id.tableName, id.storageAccountName)
+			if err := batch.ExecuteBatch(); err != nil {
+				return fmt.Errorf("Error updating Entities in Partition %q (Table %q / Storage Account %q) - they may have been modified since they were last read: %s", id.partitionKey, id.tableName, id.storageAccountName, err)
+			}
+		}
+	}
+
+	return resourceArmStorageTableEntitiesRead(d, meta)
+}
+
+func resourceArmStorageTableEntitiesDelete(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageTableEntitySegments(d.Id(), armClient.environment, 2)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup, err := determineResourceGroupForStorageAccount(id.storageAccountName, armClient)
+	if err != nil {
+		return err
+	}
+	if resourceGroup == nil {
+		log.Printf("[INFO] Unable to determine Resource Group for Storage Account %q (assuming removed)", id.storageAccountName)
+		return nil
+	}
+
+	tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(ctx, *resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		log.Printf("[INFO] Storage Account %q doesn't exist so the Entities won't exist", id.storageAccountName)
+		return nil
+	}
+
+	table := tableClient.GetTableReference(id.tableName)
+
+	// a Batch Transaction fails as a whole if any of the Entities don't exist, so only delete the ones which do
+	existing, err := queryStorageTableEntitiesInPartition(table, id.partitionKey)
+	if err != nil {
+		if isStorageTableEntityNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Entities in Partition %q (Table %q / Storage Account %q): %s", id.partitionKey, id.tableName, id.storageAccountName, err)
+	}
+
+	existingRowKeys := make(map[string]bool)
+	for _, entity := range existing {
+		existingRowKeys[entity.RowKey] = true
+	}
+
+	batch := table.NewBatch()
+	for _, v := range d.Get("entity").([]interface{}) {
+		raw := v.(map[string]interface{})
+		rowKey := raw["row_key"].(string)
+		if !existingRowKeys[rowKey] {
+			continue
+		}
+
+		entity := table.GetEntityReference(id.partitionKey, rowKey)
+		entity.OdataEtag = raw["etag"].(string)
+		batch.DeleteEntity(entity, false)
+	}
+
+	if len(batch.BatchEntitySlice) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Deleting %d Entities from Partition %q of Table %q within Storage Account %q", len(batch.BatchEntitySlice), id.partitionKey, id.tableName, id.storageAccountName)
+	if err := batch.ExecuteBatch(); err != nil {
+		return fmt.Errorf("Error deleting Entities from Partition %q (Table %q / Storage Account %q) - they may have been modified since they were last read: %s", id.partitionKey, id.tableName, id.storageAccountName, err)
+	}
+
+	return nil
+}
+
+// queryStorageTableEntitiesInPartition returns all of the Entities within the specified Partition of the Table
+func queryStorageTableEntitiesInPartition(table *storage.Table, partitionKey string) ([]*storage.Entity, error) {
+	// single quotes are escaped by doubling them within an OData string literal
+	filter := fmt.Sprintf("PartitionKey eq '%s'", strings.Replace(partitionKey, "'", "''", -1))
+
+	result, err := table.QueryEntities(60, storage.MinimalMetadata, &storage.QueryOptions{
+		Filter: filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entities := result.Entities
+	for result.NextLink != nil {
+		result, err = result.NextResults(&storage.TableOptions{})
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, result.Entities...)
+	}
+
+	return entities, nil
+}
+
+func expandStorageTableEntities(table *storage.Table, partitionKey string, input []interface{}) ([]*storage.Entity, error) {
+	output := make([]*storage.Entity, 0)
+	rowKeys := make(map[string]bool)
+
+	for _, v := range input {
+		raw := v.(map[string]interface{})
+		rowKey := raw["row_key"].(string)
+
+		if rowKeys[rowKey] {
+			return nil, fmt.Errorf("the Row Key %q is specified more than once", rowKey)
+		}
+		rowKeys[rowKey] = true
+
+		properties, err := expandStorageTableEntityProperties(raw["properties"].(map[string]interface{}), raw["property_types"].(map[string]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("Error expanding the Entity with the Row Key %q: %+v", rowKey, err)
+		}
+
+		entity := table.GetEntityReference(partitionKey, rowKey)
+		entity.Properties = properties
+		output = append(output, entity)
+	}
+
+	return output, nil
+}
+
+// expandStorageTableEntitiesBatch builds a Batch Transaction which inserts, replaces and deletes the Entities
+// needed to go from `old` to `new`. Replacements and deletions use the ETag of the Entity from when it was last
+// read, so that the whole transaction fails if any of these Entities have been modified in the meantime
+func expandStorageTableEntitiesBatch(table *storage.Table, partitionKey string, old []interface{}, new []interface{}) (*storage.TableBatch, error) {
+	entities, err := expandStorageTableEntities(table, partitionKey, new)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]map[string]interface{})
+	for _, v := range old {
+		raw := v.(map[string]interface{})
+		existing[raw["row_key"].(string)] = raw
+	}
+
+	batch := table.NewBatch()
+	for i, entity := range entities {
+		previous, ok := existing[entity.RowKey]
+		if !ok {
+			batch.InsertEntity(entity)
+			continue
+		}
+		delete(existing, entity.RowKey)
+
+		raw := new[i].(map[string]interface{})
+		if reflect.DeepEqual(previous["properties"], raw["properties"]) && reflect.DeepEqual(previous["property_types"], raw["property_types"]) {
+			continue
+		}
+
+		entity.OdataEtag = previous["etag"].(string)
+		batch.ReplaceEntity(entity)
+	}
+
+	for _, v := range old {
+		rowKey := v.(map[string]interface{})["row_key"].(string)
+		previous, ok := existing[rowKey]
+		if !ok {
+			continue
+		}
+
+		entity := table.GetEntityReference(partitionKey, rowKey)
+		entity.OdataEtag = previous["etag"].(string)
+		batch.DeleteEntity(entity, false)
+	}
+
+	if len(batch.BatchEntitySlice) > storageTableEntitiesMaxBatchOperations {
+		return nil, fmt.Errorf("this change requires %d operations but a Batch Transaction supports at most %d - split the change into multiple applies", len(batch.BatchEntitySlice), storageTableEntitiesMaxBatchOperations)
+	}
+
+	return batch, nil
+}
+
+// flattenStorageTableEntities returns the Entities in the same order as `existing` (the Entities currently in the
+// state) - Entities which aren't in the state are only included when there are none, which is the case when importing
+func flattenStorageTableEntities(entities []*storage.Entity, existing []interface{}) []interface{} {
+	output := make([]interface{}, 0)
+
+	rowKeys := make([]string, 0)
+	configuredTypes := make(map[string]map[string]interface{})
+	for _, v := range existing {
+		raw := v.(map[string]interface{})
+		rowKey := raw["row_key"].(string)
+		rowKeys = append(rowKeys, rowKey)
+		if types, ok := raw["property_types"].(map[string]interface{}); ok {
+			configuredTypes[rowKey] = types
+		}
+	}
+
+	entitiesByRowKey := make(map[string]*storage.Entity)
+	for _, entity := range entities {
+		entitiesByRowKey[entity.RowKey] = entity
+		if len(existing) == 0 {
+			rowKeys = append(rowKeys, entity.RowKey)
+		}
+	}
+
+	for _, rowKey := range rowKeys {
+		entity, ok := entitiesByRowKey[rowKey]
+		if !ok {
+			continue
+		}
+
+		properties, types := flattenStorageTableEntityProperties(entity.Properties, configuredTypes[rowKey])
+		output = append(output, map[string]interface{}{
+			"row_key":        entity.RowKey,
+			"properties":     properties,
+			"property_types": types,
+			"etag":           entity.OdataEtag,
+		})
+	}
+
+	return output
+}
@@ -0,0 +1,310 @@
+package azurerm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAzureRMStorageTableEntities_basic(t *testing.T) {
+	resourceName := "azurerm_storage_table_entities.test"
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	config := testAccAzureRMStorageTableEntities_basic(ri, rs, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageTableEntitiesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageTableEntitiesCount(resourceName, 2),
+					resource.TestCheckResourceAttr(resourceName, "entity.#", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "entity.0.etag"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageTableEntities_update(t *testing.T) {
+	resourceName := "azurerm_storage_table_entities.test"
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageTableEntitiesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMStorageTableEntities_basic(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageTableEntitiesCount(resourceName, 2),
+				),
+			},
+			{
+				Config: testAccAzureRMStorageTableEntities_updated(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageTableEntitiesCount(resourceName, 2),
+					resource.TestCheckResourceAttr(resourceName, "entity.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "entity.0.row_key", "contoso"),
+					resource.TestCheckResourceAttr(resourceName, "entity.0.properties.max_users", "250"),
+					resource.TestCheckResourceAttr(resourceName, "entity.1.row_key", "northwind"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMStorageTableEntitiesCount(resourceName string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		tableName := rs.Primary.Attributes["table_name"]
+		storageAccountName := rs.Primary.Attributes["storage_account_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+		partitionKey := rs.Primary.Attributes["partition_key"]
+
+		armClient := testAccProvider.Meta().(*ArmClient)
+		ctx := armClient.StopContext
+		tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(ctx, resourceGroup, storageAccountName)
+		if err != nil {
+			return err
+		}
+		if !accountExists {
+			return fmt.Errorf("Bad: Storage Account %q does not exist", storageAccountName)
+		}
+
+		entities, err := queryStorageTableEntitiesInPartition(tableClient.GetTableReference(tableName), partitionKey)
+		if err != nil {
+			return fmt.Errorf("Bad: Error retrieving Entities in Partition %q (Table %q): %+v", partitionKey, tableName, err)
+		}
+
+		if len(entities) != expected {
+			return fmt.Errorf("Bad: Expected %d Entities in Partition %q (Table %q) but got %d", expected, partitionKey, tableName, len(entities))
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMStorageTableEntitiesDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_storage_table_entities" {
+			continue
+		}
+
+		tableName := rs.Primary.Attributes["table_name"]
+		storageAccountName := rs.Primary.Attributes["storage_account_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+		partitionKey := rs.Primary.Attributes["partition_key"]
+
+		armClient := testAccProvider.Meta().(*ArmClient)
+		ctx := armClient.StopContext
+		tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(ctx, resourceGroup, storageAccountName)
+		if err != nil {
+			// if we can't get the keys then the Entities can't exist
+			return nil
+		}
+		if !accountExists {
+			return nil
+		}
+
+		entities, err := queryStorageTableEntitiesInPartition(tableClient.GetTableReference(tableName), partitionKey)
+		if err != nil {
+			if isStorageTableEntityNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		if len(entities) > 0 {
+			return fmt.Errorf("Bad: %d Entities still exist in Partition %q (Table %q)", len(entities), partitionKey, tableName)
+		}
+	}
+
+	return nil
+}
+
+func TestExpandStorageTableEntitiesBatch(t *testing.T) {
+	client, err := storage.NewClient("example", "dGVzdC1hY2Nlc3Mta2V5LWZvci1zaWduaW5nLXJlcXVlc3Rz", storage.DefaultBaseURL, storage.DefaultAPIVersion, true)
+	if err != nil {
+		t.Fatalf("Error creating client: %+v", err)
+	}
+	tableClient := client.GetTableService()
+	table := tableClient.GetTableReference("tenants")
+
+	entity := func(rowKey, value, etag string) interface{} {
+		return map[string]interface{}{
+			"row_key":        rowKey,
+			"properties":     map[string]interface{}{"region": value},
+			"property_types": map[string]interface{}{},
+			"etag":           etag,
+		}
+	}
+
+	old := []interface{}{
+		entity("unchanged", "westeurope", "W/\"1\""),
+		entity("changed", "westeurope", "W/\"2\""),
+		entity("removed", "westeurope", "W/\"3\""),
+	}
+	new := []interface{}{
+		entity("unchanged", "westeurope", ""),
+		entity("changed", "northeurope", ""),
+		entity("added", "westeurope", ""),
+	}
+
+	batch, err := expandStorageTableEntitiesBatch(table, "tenants", old, new)
+	if err != nil {
+		t.Fatalf("Error building the Batch: %+v", err)
+	}
+
+	expected := map[string]struct {
+		op   storage.Operation
+		etag string
+	}{
+		"changed": {storage.ReplaceOp, "W/\"2\""},
+		"added":   {storage.InsertOp, ""},
+		"removed": {storage.DeleteOp, "W/\"3\""},
+	}
+
+	if len(batch.BatchEntitySlice) != len(expected) {
+		t.Fatalf("Expected %d operations but got %d", len(expected), len(batch.BatchEntitySlice))
+	}
+
+	for _, v := range batch.BatchEntitySlice {
+		e, ok := expected[v.Entity.RowKey]
+		if !ok {
+			t.Fatalf("Unexpected operation for the Row Key %q", v.Entity.RowKey)
+		}
+		if v.Op != e.op || v.Entity.OdataEtag != e.etag {
+			t.Fatalf("Expected operation %d with the ETag %q for the Row Key %q but got %d with %q", e.op, e.etag, v.Entity.RowKey, v.Op, v.Entity.OdataEtag)
+		}
+		if v.Entity.PartitionKey != "tenants" {
+			t.Fatalf("Expected the Partition Key for the Row Key %q to be `tenants` but got %q", v.Entity.RowKey, v.Entity.PartitionKey)
+		}
+	}
+
+	if _, err := expandStorageTableEntitiesBatch(table, "tenants", old, append(new, entity("added", "uksouth", ""))); err == nil {
+		t.Fatalf("Expected an error when a Row Key is specified more than once")
+	}
+}
+
+func TestFlattenStorageTableEntities(t *testing.T) {
+	entities := []*storage.Entity{
+		{RowKey: "a", OdataEtag: "etag-a", Properties: map[string]interface{}{"name": "first"}},
+		{RowKey: "b", OdataEtag: "etag-b", Properties: map[string]interface{}{"name": "second"}},
+		{RowKey: "c", OdataEtag: "etag-c", Properties: map[string]interface{}{"name": "unmanaged"}},
+	}
+
+	// when importing all of the Entities in the Partition are included
+	imported := flattenStorageTableEntities(entities, []interface{}{})
+	if len(imported) != 3 {
+		t.Fatalf("Expected 3 Entities when importing but got %d", len(imported))
+	}
+
+	// otherwise only the Entities in the state are included, in the same order
+	existing := []interface{}{
+		map[string]interface{}{"row_key": "b", "property_types": map[string]interface{}{}},
+		map[string]interface{}{"row_key": "a", "property_types": map[string]interface{}{}},
+		map[string]interface{}{"row_key": "deleted", "property_types": map[string]interface{}{}},
+	}
+	actual := flattenStorageTableEntities(entities, existing)
+	if len(actual) != 2 {
+		t.Fatalf("Expected 2 Entities but got %d", len(actual))
+	}
+
+	for i, rowKey := range []string{"b", "a"} {
+		raw := actual[i].(map[string]interface{})
+		if raw["row_key"] != rowKey || raw["etag"] != "etag-"+rowKey {
+			t.Fatalf("Expected Entity %d to have the Row Key %q but got %+v", i, rowKey, raw)
+		}
+	}
+}
+
+func testAccAzureRMStorageTableEntities_basic(rInt int, rString string, location string) string {
+	template := testAccAzureRMStorageTableEntity_template(rInt, rString, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_table_entities" "test" {
+  table_name           = "${azurerm_storage_table.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_name = "${azurerm_storage_account.test.name}"
+  partition_key        = "tenants"
+
+  entity {
+    row_key = "contoso"
+
+    properties = {
+      region    = "westeurope"
+      max_users = "100"
+    }
+
+    property_types = {
+      max_users = "Edm.Int32"
+    }
+  }
+
+  entity {
+    row_key = "fabrikam"
+
+    properties = {
+      region = "eastus"
+    }
+  }
+}
+`, template)
+}
+
+func testAccAzureRMStorageTableEntities_updated(rInt int, rString string, location string) string {
+	template := testAccAzureRMStorageTableEntity_template(rInt, rString, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_table_entities" "test" {
+  table_name           = "${azurerm_storage_table.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_name = "${azurerm_storage_account.test.name}"
+  partition_key        = "tenants"
+
+  entity {
+    row_key = "contoso"
+
+    properties = {
+      region    = "westeurope"
+      max_users = "250"
+    }
+
+    property_types = {
+      max_users = "Edm.Int32"
+    }
+  }
+
+  entity {
+    row_key = "northwind"
+
+    properties = {
+      region = "uksouth"
+    }
+  }
+}
+`, template)
+}
@@ -0,0 +1,611 @@
+package azurerm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/satori/go.uuid"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/suppress"
+)
+
+const (
+	storageTableEntityTypeBinary   = "Edm.Binary"
+	storageTableEntityTypeBoolean  = "Edm.Boolean"
+	storageTableEntityTypeDateTime = "Edm.DateTime"
+	storageTableEntityTypeDouble   = "Edm.Double"
+	storageTableEntityTypeGuid     = "Edm.Guid"
+	storageTableEntityTypeInt32    = "Edm.Int32"
+	storageTableEntityTypeInt64    = "Edm.Int64"
+	storageTableEntityTypeString   = "Edm.String"
+)
+
+func resourceArmStorageTableEntity() *schema.Resource {
+	return &schema.Resource{
+		Create: retryOnStorageAuthenticationFailure(resourceArmStorageTableEntityCreate),
+		Read:   retryOnStorageAuthenticationFailure(resourceArmStorageTableEntityRead),
+		Update: retryOnStorageAuthenticationFailure(resourceArmStorageTableEntityUpdate),
+		Delete: retryOnStorageAuthenticationFailure(resourceArmStorageTableEntityDelete),
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"table_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageTableName,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"storage_account_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageAccountName,
+			},
+
+			"partition_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageTableEntityKey,
+			},
+
+			"row_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateArmStorageTableEntityKey,
+			},
+
+			"properties": storageTableEntityPropertiesSchema(),
+
+			"property_types": storageTableEntityPropertyTypesSchema(),
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// storageTableEntityPropertiesSchema returns the schema for the (string encoded) Properties of an Entity,
+// which is shared by the `azurerm_storage_table_entity` and `azurerm_storage_table_entities` resources
+func storageTableEntityPropertiesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeMap,
+		Required:         true,
+		ValidateFunc:     validateArmStorageTableEntityPropertyNames,
+		DiffSuppressFunc: suppressStorageTableEntityPropertyDiff,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// storageTableEntityPropertyTypesSchema returns the schema for the Edm Types of the Properties of an Entity,
+// where any Property which isn't listed is stored as an `Edm.String`
+func storageTableEntityPropertyTypesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeMap,
+		Optional:     true,
+		ValidateFunc: validateArmStorageTableEntityPropertyTypes,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+func resourceArmStorageTableEntityCreate(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+	environment := armClient.environment
+
+	tableName := d.Get("table_name").(string)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	storageAccountName := d.Get("storage_account_name").(string)
+	partitionKey := d.Get("partition_key").(string)
+	rowKey := d.Get("row_key").(string)
+
+	tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(ctx, resourceGroupName, storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q Not Found", storageAccountName)
+	}
+
+	properties, err := expandStorageTableEntityProperties(d.Get("properties").(map[string]interface{}), d.Get("property_types").(map[string]interface{}))
+	if err != nil {
+		return fmt.Errorf("Error expanding `properties` for Entity (Partition Key %q / Row Key %q / Table %q / Storage Account %q): %+v", partitionKey, rowKey, tableName, storageAccountName, err)
+	}
+
+	table := tableClient.GetTableReference(tableName)
+	entity := table.GetEntityReference(partitionKey, rowKey)
+	entity.Properties = properties
+
+	// Insert fails if the Entity already exists, in which case it needs to be imported
+	log.Printf("[INFO] Creating Entity (Partition Key %q / Row Key %q) in Table %q within Storage Account %q", partitionKey, rowKey, tableName, storageAccountName)
+	if err := entity.Insert(storage.MinimalMetadata, &storage.EntityOptions{}); err != nil {
+		return fmt.Errorf("Error creating Entity (Partition Key %q / Row Key %q / Table %q / Storage Account %q): %s", partitionKey, rowKey, tableName, storageAccountName, err)
+	}
+
+	d.SetId(storageTableEntityID(storageAccountName, environment, tableName, partitionKey, rowKey))
+	return resourceArmStorageTableEntityRead(d, meta)
+}
+
+func resourceArmStorageTableEntityRead(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageTableEntityID(d.Id(), armClient.environment)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup, err := determineResourceGroupForStorageAccount(id.storageAccountName, armClient)
+	if err != nil {
+		return err
+	}
+	if resourceGroup == nil {
+		log.Printf("[DEBUG] Unable to determine Resource Group for Storage Account %q - removing Entity %q from state", id.storageAccountName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(ctx, *resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		log.Printf("[DEBUG] Storage Account %q not found, removing Entity %q from state", id.storageAccountName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	table := tableClient.GetTableReference(id.tableName)
+	entity := table.GetEntityReference(id.partitionKey, id.rowKey)
+	if err := entity.Get(60, storage.MinimalMetadata, &storage.GetEntityOptions{}); err != nil {
+		if isStorageTableEntityNotFound(err) {
+			log.Printf("[INFO] Entity (Partition Key %q / Row Key %q) no longer exists in Table %q, removing from state...", id.partitionKey, id.rowKey, id.tableName)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Entity (Partition Key %q / Row Key %q / Table %q / Storage Account %q): %s", id.partitionKey, id.rowKey, id.tableName, id.storageAccountName, err)
+	}
+
+	d.Set("table_name", id.tableName)
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("storage_account_name", id.storageAccountName)
+	d.Set("partition_key", id.partitionKey)
+	d.Set("row_key", id.rowKey)
+	d.Set("etag", entity.OdataEtag)
+
+	properties, propertyTypes := flattenStorageTableEntityProperties(entity.Properties, d.Get("property_types").(map[string]interface{}))
+	if err := d.Set("properties", properties); err != nil {
+		return fmt.Errorf("Error setting `properties`: %+v", err)
+	}
+	if err := d.Set("property_types", propertyTypes); err != nil {
+		return fmt.Errorf("Error setting `property_types`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceArmStorageTableEntityUpdate(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageTableEntityID(d.Id(), armClient.environment)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup := d.Get("resource_group_name").(string)
+	tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(ctx, resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q Not Found", id.storageAccountName)
+	}
+
+	if d.HasChange("properties") || d.HasChange("property_types") {
+		properties, err := expandStorageTableEntityProperties(d.Get("properties").(map[string]interface{}), d.Get("property_types").(map[string]interface{}))
+		if err != nil {
+			return fmt.Errorf("Error expanding `properties` for Entity (Partition Key %q / Row Key %q / Table %q / Storage Account %q): %+v", id.partitionKey, id.rowKey, id.tableName, id.storageAccountName, err)
+		}
+
+		table := tableClient.GetTableReference(id.tableName)
+		entity := table.GetEntityReference(id.partitionKey, id.rowKey)
+		entity.Properties = properties
+
+		// the ETag from when the Entity was last read ensures we don't overwrite changes made since then
+		entity.OdataEtag = d.Get("etag").(string)
+		force := entity.OdataEtag == ""
+
+		log.Printf("[INFO] Updating Entity (Partition Key %q / Row Key %q) in Table %q within Storage Account %q", id.partitionKey, id.rowKey, id.tableName, id.storageAccountName)
+		if err := entity.Update(force, &storage.EntityOptions{}); err != nil {
+			return fmt.Errorf("Error updating Entity (Partition Key %q / Row Key %q / Table %q / Storage Account %q) - it may have been modified since it was last read: %s", id.partitionKey, id.rowKey, id.tableName, id.storageAccountName, err)
+		}
+	}
+
+	return resourceArmStorageTableEntityRead(d, meta)
+}
+
+func resourceArmStorageTableEntityDelete(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	ctx := armClient.StopContext
+
+	id, err := parseStorageTableEntityID(d.Id(), armClient.environment)
+	if err != nil {
+		return err
+	}
+
+	resourceGroup, err := determineResourceGroupForStorageAccount(id.storageAccountName, armClient)
+	if err != nil {
+		return err
+	}
+	if resourceGroup == nil {
+		log.Printf("[INFO] Unable to determine Resource Group for Storage Account %q (assuming removed)", id.storageAccountName)
+		return nil
+	}
+
+	tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(ctx, *resourceGroup, id.storageAccountName)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		log.Printf("[INFO] Storage Account %q doesn't exist so the Entity won't exist", id.storageAccountName)
+		return nil
+	}
+
+	table := tableClient.GetTableReference(id.tableName)
+	entity := table.GetEntityReference(id.partitionKey, id.rowKey)
+	entity.OdataEtag = d.Get("etag").(string)
+	force := entity.OdataEtag == ""
+
+	log.Printf("[INFO] Deleting Entity (Partition Key %q / Row Key %q) from Table %q within Storage Account %q", id.partitionKey, id.rowKey, id.tableName, id.storageAccountName)
+	if err := entity.Delete(force, &storage.EntityOptions{}); err != nil {
+		if isStorageTableEntityNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting Entity (Partition Key %q / Row Key %q / Table %q / Storage Account %q) - it may have been modified since it was last read: %s", id.partitionKey, id.rowKey, id.tableName, id.storageAccountName, err)
+	}
+
+	return nil
+}
+
+func isStorageTableEntityNotFound(err error) bool {
+	if serviceErr, ok := err.(storage.AzureStorageServiceError); ok {
+		return serviceErr.StatusCode == 404
+	}
+	return false
+}
+
+// storageTableEntityID returns the ID of an Entity in the form
+// https://example.table.core.windows.net/table/partitionKey/rowKey, or
+// https://example.table.core.windows.net/table/partitionKey for all of the Entities in a Partition
+func storageTableEntityID(storageAccountName string, environment azure.Environment, tableName string, keys ...string) string {
+	segments := []string{tableName}
+	for _, key := range keys {
+		segments = append(segments, url.PathEscape(key))
+	}
+
+	return fmt.Sprintf("https://%s.table.%s/%s", storageAccountName, environment.StorageEndpointSuffix, strings.Join(segments, "/"))
+}
+
+type storageTableEntityId struct {
+	storageAccountName string
+	tableName          string
+	partitionKey       string
+	rowKey             string
+}
+
+func parseStorageTableEntityID(input string, environment azure.Environment) (*storageTableEntityId, error) {
+	return parseStorageTableEntitySegments(input, environment, 3)
+}
+
+// parseStorageTableEntitySegments parses an ID generated by storageTableEntityID, which is expected to contain
+// `expected` segments in the path - the Table Name, the Partition Key and (optionally) the Row Key
+func parseStorageTableEntitySegments(input string, environment azure.Environment, expected int) (*storageTableEntityId, error) {
+	uri, err := url.Parse(input)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing %q as URI: %+v", input, err)
+	}
+
+	// the keys are escaped, so we need to split the escaped path before unescaping them
+	segments := strings.Split(strings.TrimPrefix(uri.EscapedPath(), "/"), "/")
+	if len(segments) != expected {
+		return nil, fmt.Errorf("Expected number of segments in the path to be %d but got %d", expected, len(segments))
+	}
+
+	values := make([]string, 0)
+	for _, segment := range segments {
+		value, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, fmt.Errorf("Error unescaping %q: %+v", segment, err)
+		}
+		if value == "" {
+			return nil, fmt.Errorf("Expected the segments in the path of %q to be non-empty", input)
+		}
+		values = append(values, value)
+	}
+
+	id := storageTableEntityId{
+		storageAccountName: strings.Replace(uri.Host, fmt.Sprintf(".table.%s", environment.StorageEndpointSuffix), "", 1),
+		tableName:          values[0],
+		partitionKey:       values[1],
+	}
+	if expected > 2 {
+		id.rowKey = values[2]
+	}
+
+	return &id, nil
+}
+
+func validateArmStorageTableEntityKey(v interface{}, k string) (warnings []string, errors []error) {
+	value := v.(string)
+
+	if len(value) == 0 || len(value) > 1024 {
+		errors = append(errors, fmt.Errorf("%q must be between 1 and 1024 characters long: %q", k, value))
+	}
+
+	// the Storage SDK doesn't escape single quotes when building the URI of an Entity
+	if strings.ContainsAny(value, `/\#?'`) {
+		errors = append(errors, fmt.Errorf("%q must not contain the characters `/`, `\\`, `#`, `?` or `'`: %q", k, value))
+	}
+
+	for _, c := range value {
+		if c < 0x20 || (c >= 0x7F && c <= 0x9F) {
+			errors = append(errors, fmt.Errorf("%q must not contain control characters: %q", k, value))
+			break
+		}
+	}
+
+	return warnings, errors
+}
+
+var storageTableEntityPropertyNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,254}$`)
+
+func validateArmStorageTableEntityPropertyNames(v interface{}, k string) (warnings []string, errors []error) {
+	for name := range v.(map[string]interface{}) {
+		if !storageTableEntityPropertyNameRegex.MatchString(name) {
+			errors = append(errors, fmt.Errorf("%q can only contain letters, numbers and underscores, must not begin with a number and must be at most 255 characters long: %q", k, name))
+		}
+
+		switch name {
+		case "PartitionKey", "RowKey", "Timestamp":
+			errors = append(errors, fmt.Errorf("%q must not contain the system property %q", k, name))
+		}
+	}
+
+	return warnings, errors
+}
+
+func validateArmStorageTableEntityPropertyTypes(v interface{}, k string) (warnings []string, errors []error) {
+	for name, propertyType := range v.(map[string]interface{}) {
+		if _, errs := validateArmStorageTableEntityPropertyNames(map[string]interface{}{name: nil}, k); len(errs) > 0 {
+			errors = append(errors, errs...)
+		}
+
+		_, errs := validation.StringInSlice([]string{
+			storageTableEntityTypeBinary,
+			storageTableEntityTypeBoolean,
+			storageTableEntityTypeDateTime,
+			storageTableEntityTypeDouble,
+			storageTableEntityTypeGuid,
+			storageTableEntityTypeInt32,
+			storageTableEntityTypeInt64,
+			storageTableEntityTypeString,
+		}, false)(propertyType, fmt.Sprintf("%s.%s", k, name))
+		errors = append(errors, errs...)
+	}
+
+	return warnings, errors
+}
+
+// suppressStorageTableEntityPropertyDiff suppresses the diff between two representations of the same typed value
+// (e.g. `1.50` and `1.5` for an `Edm.Double`), using the type from the corresponding `property_types` field
+func suppressStorageTableEntityPropertyDiff(k, old, new string, d *schema.ResourceData) bool {
+	index := strings.Index(k, "properties.")
+	if index == -1 || old == "" || new == "" {
+		return false
+	}
+
+	typeKey := fmt.Sprintf("%sproperty_types.%s", k[:index], k[index+len("properties."):])
+	propertyType, ok := d.Get(typeKey).(string)
+	if !ok {
+		return false
+	}
+
+	switch propertyType {
+	case storageTableEntityTypeBoolean:
+		o, oerr := strconv.ParseBool(old)
+		n, nerr := strconv.ParseBool(new)
+		return oerr == nil && nerr == nil && o == n
+
+	case storageTableEntityTypeDateTime:
+		return suppress.RFC3339Time(k, old, new, d)
+
+	case storageTableEntityTypeDouble:
+		o, oerr := strconv.ParseFloat(old, 64)
+		n, nerr := strconv.ParseFloat(new, 64)
+		return oerr == nil && nerr == nil && o == n
+
+	case storageTableEntityTypeGuid:
+		return strings.EqualFold(old, new)
+
+	case storageTableEntityTypeInt32, storageTableEntityTypeInt64:
+		o, oerr := strconv.ParseInt(old, 10, 64)
+		n, nerr := strconv.ParseInt(new, 10, 64)
+		return oerr == nil && nerr == nil && o == n
+	}
+
+	return false
+}
+
+// expandStorageTableEntityProperties converts the string encoded Properties into the Go types which the Storage SDK
+// serializes as the corresponding Edm Type
+func expandStorageTableEntityProperties(input map[string]interface{}, types map[string]interface{}) (map[string]interface{}, error) {
+	for name := range types {
+		if _, ok := input[name]; !ok {
+			return nil, fmt.Errorf("a type was specified for the Property %q which isn't defined in `properties`", name)
+		}
+	}
+
+	output := make(map[string]interface{})
+
+	for name, v := range input {
+		value := v.(string)
+
+		propertyType := storageTableEntityTypeString
+		if t, ok := types[name]; ok {
+			propertyType = t.(string)
+		}
+
+		switch propertyType {
+		case storageTableEntityTypeBinary:
+			b, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return nil, fmt.Errorf("the Property %q must be base64 encoded to be used as an %s: %+v", name, propertyType, err)
+			}
+			output[name] = b
+
+		case storageTableEntityTypeBoolean:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("the Property %q must be `true` or `false` to be used as an %s: %+v", name, propertyType, err)
+			}
+			output[name] = b
+
+		case storageTableEntityTypeDateTime:
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("the Property %q must be in RFC3339 format to be used as an %s: %+v", name, propertyType, err)
+			}
+			// the Storage SDK can only parse whole seconds when reading the Entity back
+			if t.Nanosecond() != 0 {
+				return nil, fmt.Errorf("the Property %q must not contain fractional seconds to be used as an %s: %q", name, propertyType, value)
+			}
+			output[name] = t.UTC()
+
+		case storageTableEntityTypeDouble:
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+				return nil, fmt.Errorf("the Property %q must be a finite number to be used as an %s: %q", name, propertyType, value)
+			}
+			// the Table Service infers the type of numbers without a type annotation (which the Storage SDK doesn't
+			// support for Doubles) - so we need to ensure whole numbers have a decimal point to be stored as a Double
+			number := strconv.FormatFloat(f, 'f', -1, 64)
+			if !strings.Contains(number, ".") {
+				number += ".0"
+			}
+			output[name] = json.Number(number)
+
+		case storageTableEntityTypeGuid:
+			u, err := uuid.FromString(value)
+			if err != nil {
+				return nil, fmt.Errorf("the Property %q must be a UUID to be used as an %s: %+v", name, propertyType, err)
+			}
+			output[name] = u
+
+		case storageTableEntityTypeInt32:
+			i, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("the Property %q must be a 32-bit integer to be used as an %s: %+v", name, propertyType, err)
+			}
+			output[name] = int32(i)
+
+		case storageTableEntityTypeInt64:
+			i, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("the Property %q must be a 64-bit integer to be used as an %s: %+v", name, propertyType, err)
+			}
+			output[name] = i
+
+		default:
+			output[name] = value
+		}
+	}
+
+	return output, nil
+}
+
+// flattenStorageTableEntityProperties converts the Properties returned by the Storage SDK into their string encoded
+// values and Edm Types. Numbers without a type annotation are returned as a float64 regardless of whether they're an
+// `Edm.Int32` or an `Edm.Double`, so the configured type is used where it's available
+func flattenStorageTableEntityProperties(input map[string]interface{}, configuredTypes map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	properties := make(map[string]interface{})
+	types := make(map[string]interface{})
+
+	for name, v := range input {
+		configuredType := ""
+		if t, ok := configuredTypes[name]; ok {
+			configuredType = t.(string)
+		}
+
+		switch value := v.(type) {
+		case []byte:
+			properties[name] = base64.StdEncoding.EncodeToString(value)
+			types[name] = storageTableEntityTypeBinary
+
+		case bool:
+			properties[name] = strconv.FormatBool(value)
+			types[name] = storageTableEntityTypeBoolean
+
+		case time.Time:
+			properties[name] = value.UTC().Format(time.RFC3339)
+			types[name] = storageTableEntityTypeDateTime
+
+		case uuid.UUID:
+			properties[name] = value.String()
+			types[name] = storageTableEntityTypeGuid
+
+		case int64:
+			properties[name] = strconv.FormatInt(value, 10)
+			types[name] = storageTableEntityTypeInt64
+
+		case float64:
+			propertyType := configuredType
+			if propertyType != storageTableEntityTypeInt32 && propertyType != storageTableEntityTypeDouble {
+				propertyType = storageTableEntityTypeDouble
+				if value == math.Trunc(value) && value >= math.MinInt32 && value <= math.MaxInt32 {
+					propertyType = storageTableEntityTypeInt32
+				}
+			}
+
+			properties[name] = strconv.FormatFloat(value, 'f', -1, 64)
+			types[name] = propertyType
+
+		case string:
+			properties[name] = value
+			// Strings are the default, so the type is only set when it's been explicitly configured
+			if configuredType == storageTableEntityTypeString {
+				types[name] = storageTableEntityTypeString
+			}
+
+		default:
+			log.Printf("[DEBUG] Skipping the Property %q since it has an unsupported type %T", name, v)
+		}
+	}
+
+	return properties, types
+}
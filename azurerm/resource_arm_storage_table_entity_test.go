@@ -0,0 +1,518 @@
+package azurerm
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAzureRMStorageTableEntity_basic(t *testing.T) {
+	resourceName := "azurerm_storage_table_entity.test"
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	config := testAccAzureRMStorageTableEntity_basic(ri, rs, testLocation())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageTableEntityDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageTableEntityExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "properties.%", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "etag"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageTableEntity_typed(t *testing.T) {
+	resourceName := "azurerm_storage_table_entity.test"
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageTableEntityDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMStorageTableEntity_basic(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageTableEntityExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "properties.%", "1"),
+				),
+			},
+			{
+				Config: testAccAzureRMStorageTableEntity_typed(ri, rs, location),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageTableEntityExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "properties.%", "8"),
+					resource.TestCheckResourceAttr(resourceName, "property_types.%", "7"),
+					resource.TestCheckResourceAttr(resourceName, "properties.ratio", "2"),
+					resource.TestCheckResourceAttr(resourceName, "property_types.ratio", "Edm.Double"),
+				),
+			},
+			{
+				// importing infers the types from the values, which can't distinguish whole Doubles from Int32's
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"property_types.ratio"},
+			},
+		},
+	})
+}
+
+func testCheckAzureRMStorageTableEntityExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		tableName := rs.Primary.Attributes["table_name"]
+		storageAccountName := rs.Primary.Attributes["storage_account_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+		partitionKey := rs.Primary.Attributes["partition_key"]
+		rowKey := rs.Primary.Attributes["row_key"]
+
+		armClient := testAccProvider.Meta().(*ArmClient)
+		ctx := armClient.StopContext
+		tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(ctx, resourceGroup, storageAccountName)
+		if err != nil {
+			return err
+		}
+		if !accountExists {
+			return fmt.Errorf("Bad: Storage Account %q does not exist", storageAccountName)
+		}
+
+		entity := tableClient.GetTableReference(tableName).GetEntityReference(partitionKey, rowKey)
+		if err := entity.Get(60, storage.MinimalMetadata, &storage.GetEntityOptions{}); err != nil {
+			return fmt.Errorf("Bad: Entity (Partition Key %q / Row Key %q / Table %q) does not exist: %+v", partitionKey, rowKey, tableName, err)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMStorageTableEntityDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_storage_table_entity" {
+			continue
+		}
+
+		tableName := rs.Primary.Attributes["table_name"]
+		storageAccountName := rs.Primary.Attributes["storage_account_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+		partitionKey := rs.Primary.Attributes["partition_key"]
+		rowKey := rs.Primary.Attributes["row_key"]
+
+		armClient := testAccProvider.Meta().(*ArmClient)
+		ctx := armClient.StopContext
+		tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(ctx, resourceGroup, storageAccountName)
+		if err != nil {
+			// if we can't get the keys then the Entity can't exist
+			return nil
+		}
+		if !accountExists {
+			return nil
+		}
+
+		entity := tableClient.GetTableReference(tableName).GetEntityReference(partitionKey, rowKey)
+		err = entity.Get(60, storage.MinimalMetadata, &storage.GetEntityOptions{})
+		if err == nil {
+			return fmt.Errorf("Bad: Entity (Partition Key %q / Row Key %q / Table %q) still exists", partitionKey, rowKey, tableName)
+		}
+		if !isStorageTableEntityNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestParseStorageTableEntityID(t *testing.T) {
+	environment := azure.PublicCloud
+
+	testData := []struct {
+		Input        string
+		PartitionKey string
+		RowKey       string
+		Error        bool
+	}{
+		{
+			Input: fmt.Sprintf("https://example.table.%s/table1", environment.StorageEndpointSuffix),
+			Error: true,
+		},
+		{
+			Input: fmt.Sprintf("https://example.table.%s/table1/partition", environment.StorageEndpointSuffix),
+			Error: true,
+		},
+		{
+			Input:        fmt.Sprintf("https://example.table.%s/table1/partition/row", environment.StorageEndpointSuffix),
+			PartitionKey: "partition",
+			RowKey:       "row",
+		},
+		{
+			Input:        storageTableEntityID("example", environment, "table1", "feature flags", "50%"),
+			PartitionKey: "feature flags",
+			RowKey:       "50%",
+		},
+		{
+			Input: fmt.Sprintf("https://example.table.%s/table1/partition/row/extra", environment.StorageEndpointSuffix),
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := parseStorageTableEntityID(v.Input, environment)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expected a value but got an error: %s", err)
+		}
+
+		if v.Error {
+			t.Fatalf("Expected an error but didn't get one for %q", v.Input)
+		}
+
+		if actual.storageAccountName != "example" || actual.tableName != "table1" {
+			t.Fatalf("Expected the Storage Account and Table to be `example` and `table1` but got %q and %q", actual.storageAccountName, actual.tableName)
+		}
+
+		if actual.partitionKey != v.PartitionKey || actual.rowKey != v.RowKey {
+			t.Fatalf("Expected the Partition Key and Row Key to be %q and %q but got %q and %q", v.PartitionKey, v.RowKey, actual.partitionKey, actual.rowKey)
+		}
+	}
+}
+
+func TestValidateArmStorageTableEntityKey(t *testing.T) {
+	validKeys := []string{
+		"partition",
+		"feature flags",
+		"tenant-1_eu.west",
+		strings.Repeat("k", 1024),
+	}
+	for _, v := range validKeys {
+		_, errors := validateArmStorageTableEntityKey(v, "row_key")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid Key: %q", v, errors)
+		}
+	}
+
+	invalidKeys := []string{
+		"",
+		"with/slash",
+		`with\backslash`,
+		"with#hash",
+		"with?question",
+		"o'brien",
+		"tab\tseparated",
+		strings.Repeat("k", 1025),
+	}
+	for _, v := range invalidKeys {
+		_, errors := validateArmStorageTableEntityKey(v, "row_key")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid Key", v)
+		}
+	}
+}
+
+func TestValidateArmStorageTableEntityPropertyTypes(t *testing.T) {
+	cases := []struct {
+		Value    map[string]interface{}
+		ErrCount int
+	}{
+		{
+			Value: map[string]interface{}{
+				"count":   "Edm.Int32",
+				"enabled": "Edm.Boolean",
+			},
+			ErrCount: 0,
+		},
+		{
+			Value: map[string]interface{}{
+				"count": "Edm.Decimal",
+			},
+			ErrCount: 1,
+		},
+		{
+			Value: map[string]interface{}{
+				"Timestamp": "Edm.DateTime",
+			},
+			ErrCount: 1,
+		},
+		{
+			Value: map[string]interface{}{
+				"1count": "Edm.Int32",
+			},
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateArmStorageTableEntityPropertyTypes(tc.Value, "property_types")
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d errors for %+v but got %d: %+v", tc.ErrCount, tc.Value, len(errors), errors)
+		}
+	}
+}
+
+func TestExpandStorageTableEntityProperties(t *testing.T) {
+	properties := map[string]interface{}{
+		"name":     "example",
+		"enabled":  "true",
+		"count":    "42",
+		"total":    "9007199254740993",
+		"ratio":    "2",
+		"released": "2019-07-02T11:38:21+02:00",
+		"owner":    "C1E5D8E4-2E87-4E5E-8E64-12CF8F14B8C5",
+		"payload":  "aGVsbG8=",
+	}
+	types := map[string]interface{}{
+		"enabled":  "Edm.Boolean",
+		"count":    "Edm.Int32",
+		"total":    "Edm.Int64",
+		"ratio":    "Edm.Double",
+		"released": "Edm.DateTime",
+		"owner":    "Edm.Guid",
+		"payload":  "Edm.Binary",
+	}
+
+	expanded, err := expandStorageTableEntityProperties(properties, types)
+	if err != nil {
+		t.Fatalf("Error expanding the Properties: %+v", err)
+	}
+
+	entity := storage.Entity{
+		PartitionKey: "partition",
+		RowKey:       "row",
+		Properties:   expanded,
+	}
+	serialized, err := json.Marshal(&entity)
+	if err != nil {
+		t.Fatalf("Error serializing the Entity: %+v", err)
+	}
+
+	var actual map[string]interface{}
+	if err := json.Unmarshal(serialized, &actual); err != nil {
+		t.Fatalf("Error deserializing the Entity: %+v", err)
+	}
+
+	expected := map[string]interface{}{
+		"PartitionKey":        "partition",
+		"RowKey":              "row",
+		"name":                "example",
+		"enabled":             true,
+		"count":               float64(42),
+		"total":               "9007199254740993",
+		"total@odata.type":    "Edm.Int64",
+		"ratio":               float64(2),
+		"released":            "2019-07-02T09:38:21Z",
+		"released@odata.type": "Edm.DateTime",
+		"owner":               "c1e5d8e4-2e87-4e5e-8e64-12cf8f14b8c5",
+		"owner@odata.type":    "Edm.Guid",
+		"payload":             "aGVsbG8=",
+		"payload@odata.type":  "Edm.Binary",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+
+	// whole Doubles need a decimal point, otherwise the Table Service stores them as an Int32
+	if !strings.Contains(string(serialized), `"ratio":2.0`) {
+		t.Fatalf("Expected the Double to be serialized with a decimal point but got %s", serialized)
+	}
+
+	// reading the serialized Entity back should give the original values, using the canonical formats
+	var roundTripped storage.Entity
+	if err := json.Unmarshal(serialized, &roundTripped); err != nil {
+		t.Fatalf("Error deserializing the Entity: %+v", err)
+	}
+
+	flattenedProperties, flattenedTypes := flattenStorageTableEntityProperties(roundTripped.Properties, types)
+
+	expectedProperties := map[string]interface{}{
+		"name":     "example",
+		"enabled":  "true",
+		"count":    "42",
+		"total":    "9007199254740993",
+		"ratio":    "2",
+		"released": "2019-07-02T09:38:21Z",
+		"owner":    "c1e5d8e4-2e87-4e5e-8e64-12cf8f14b8c5",
+		"payload":  "aGVsbG8=",
+	}
+	if !reflect.DeepEqual(flattenedProperties, expectedProperties) {
+		t.Fatalf("Expected the Properties %+v but got %+v", expectedProperties, flattenedProperties)
+	}
+	if !reflect.DeepEqual(flattenedTypes, types) {
+		t.Fatalf("Expected the Property Types %+v but got %+v", types, flattenedTypes)
+	}
+}
+
+func TestExpandStorageTableEntityProperties_invalid(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Properties map[string]interface{}
+		Types      map[string]interface{}
+	}{
+		{
+			Name:       "Type without a Property",
+			Properties: map[string]interface{}{"name": "example"},
+			Types:      map[string]interface{}{"count": "Edm.Int32"},
+		},
+		{
+			Name:       "Int32 out of range",
+			Properties: map[string]interface{}{"count": "2147483648"},
+			Types:      map[string]interface{}{"count": "Edm.Int32"},
+		},
+		{
+			Name:       "Fractional seconds",
+			Properties: map[string]interface{}{"released": "2019-07-02T09:38:21.5Z"},
+			Types:      map[string]interface{}{"released": "Edm.DateTime"},
+		},
+		{
+			Name:       "Infinite Double",
+			Properties: map[string]interface{}{"ratio": "Inf"},
+			Types:      map[string]interface{}{"ratio": "Edm.Double"},
+		},
+		{
+			Name:       "Invalid Binary",
+			Properties: map[string]interface{}{"payload": "not base64!"},
+			Types:      map[string]interface{}{"payload": "Edm.Binary"},
+		},
+	}
+
+	for _, tc := range cases {
+		if _, err := expandStorageTableEntityProperties(tc.Properties, tc.Types); err == nil {
+			t.Fatalf("Expected an error for %q but didn't get one", tc.Name)
+		}
+	}
+}
+
+func TestFlattenStorageTableEntityProperties_inferredTypes(t *testing.T) {
+	input := map[string]interface{}{
+		"name":  "example",
+		"count": float64(42),
+		"ratio": float64(1.5),
+	}
+
+	properties, types := flattenStorageTableEntityProperties(input, map[string]interface{}{})
+
+	expectedProperties := map[string]interface{}{
+		"name":  "example",
+		"count": "42",
+		"ratio": "1.5",
+	}
+	if !reflect.DeepEqual(properties, expectedProperties) {
+		t.Fatalf("Expected the Properties %+v but got %+v", expectedProperties, properties)
+	}
+
+	expectedTypes := map[string]interface{}{
+		"count": "Edm.Int32",
+		"ratio": "Edm.Double",
+	}
+	if !reflect.DeepEqual(types, expectedTypes) {
+		t.Fatalf("Expected the Property Types %+v but got %+v", expectedTypes, types)
+	}
+}
+
+func testAccAzureRMStorageTableEntity_template(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_table" "test" {
+  name                 = "acctestst%d"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_name = "${azurerm_storage_account.test.name}"
+}
+`, rInt, location, rString, rInt)
+}
+
+func testAccAzureRMStorageTableEntity_basic(rInt int, rString string, location string) string {
+	template := testAccAzureRMStorageTableEntity_template(rInt, rString, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_table_entity" "test" {
+  table_name           = "${azurerm_storage_table.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_name = "${azurerm_storage_account.test.name}"
+  partition_key        = "feature flags"
+  row_key              = "dark-mode"
+
+  properties = {
+    description = "Enables the dark theme"
+  }
+}
+`, template)
+}
+
+func testAccAzureRMStorageTableEntity_typed(rInt int, rString string, location string) string {
+	template := testAccAzureRMStorageTableEntity_template(rInt, rString, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_table_entity" "test" {
+  table_name           = "${azurerm_storage_table.test.name}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_name = "${azurerm_storage_account.test.name}"
+  partition_key        = "feature flags"
+  row_key              = "dark-mode"
+
+  properties = {
+    description = "Enables the dark theme"
+    enabled     = "true"
+    rollout     = "25"
+    requests    = "9007199254740993"
+    ratio       = "2.0"
+    enabled_at  = "2019-07-02T09:38:21Z"
+    owner       = "c1e5d8e4-2e87-4e5e-8e64-12cf8f14b8c5"
+    signature   = "aGVsbG8="
+  }
+
+  property_types = {
+    enabled    = "Edm.Boolean"
+    rollout    = "Edm.Int32"
+    requests   = "Edm.Int64"
+    ratio      = "Edm.Double"
+    enabled_at = "Edm.DateTime"
+    owner      = "Edm.Guid"
+    signature  = "Edm.Binary"
+  }
+}
+`, template)
+}
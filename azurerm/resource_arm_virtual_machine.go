@@ -548,6 +548,17 @@ func resourceArmVirtualMachine() *schema.Resource {
 				Optional: true,
 			},
 
+			"power_state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"running",
+					"stopped",
+					"deallocated",
+				}, false),
+			},
+
 			"tags": tagsSchema(),
 		},
 	}
@@ -673,6 +684,13 @@ func resourceArmVirtualMachineCreate(d *schema.ResourceData, meta interface{}) e
 
 	d.SetId(*read.ID)
 
+	// updating a Virtual Machine can start it, so the requested (or previously known) power state is re-applied
+	if v, ok := d.GetOk("power_state"); ok {
+		if err := resourceArmVirtualMachineUpdatePowerState(ctx, client, resGroup, name, v.(string)); err != nil {
+			return err
+		}
+	}
+
 	ipAddress, err := determineVirtualMachineIPAddress(ctx, meta, read.VirtualMachineProperties)
 	if err != nil {
 		return fmt.Errorf("Error determining IP Address for Virtual Machine %q (Resource Group %q): %+v", name, resGroup, err)
@@ -811,6 +829,12 @@ func resourceArmVirtualMachineRead(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
+	powerState, err := resourceArmVirtualMachineRetrievePowerState(ctx, vmClient, resGroup, name)
+	if err != nil {
+		return err
+	}
+	d.Set("power_state", powerState)
+
 	flattenAndSetTags(d, resp.Tags)
 
 	return nil
@@ -963,6 +987,96 @@ func resourceArmVirtualMachineDeleteManagedDisk(managedDiskID string, meta inter
 	return nil
 }
 
+func resourceArmVirtualMachineRetrievePowerState(ctx context.Context, client compute.VirtualMachinesClient, resGroup string, name string) (string, error) {
+	instanceView, err := client.InstanceView(ctx, resGroup, name)
+	if err != nil {
+		return "", fmt.Errorf("Error retrieving Instance View for Virtual Machine %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	if instanceView.Statuses == nil {
+		return "", nil
+	}
+
+	for _, status := range *instanceView.Statuses {
+		if status.Code == nil {
+			continue
+		}
+
+		if powerState := normalizeAzureRmVirtualMachinePowerState(*status.Code); powerState != "" {
+			return powerState, nil
+		}
+	}
+
+	return "", nil
+}
+
+func resourceArmVirtualMachineUpdatePowerState(ctx context.Context, client compute.VirtualMachinesClient, resGroup string, name string, powerState string) error {
+	current, err := resourceArmVirtualMachineRetrievePowerState(ctx, client, resGroup, name)
+	if err != nil {
+		return err
+	}
+
+	if current == powerState {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Changing Power State of Virtual Machine %q (Resource Group %q) from %q to %q", name, resGroup, current, powerState)
+
+	switch powerState {
+	case "running":
+		future, err := client.Start(ctx, resGroup, name)
+		if err != nil {
+			return fmt.Errorf("Error starting Virtual Machine %q (Resource Group %q): %+v", name, resGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for Virtual Machine %q (Resource Group %q) to start: %+v", name, resGroup, err)
+		}
+
+	case "stopped":
+		future, err := client.PowerOff(ctx, resGroup, name)
+		if err != nil {
+			return fmt.Errorf("Error stopping Virtual Machine %q (Resource Group %q): %+v", name, resGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for Virtual Machine %q (Resource Group %q) to stop: %+v", name, resGroup, err)
+		}
+
+	case "deallocated":
+		future, err := client.Deallocate(ctx, resGroup, name)
+		if err != nil {
+			return fmt.Errorf("Error deallocating Virtual Machine %q (Resource Group %q): %+v", name, resGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for Virtual Machine %q (Resource Group %q) to deallocate: %+v", name, resGroup, err)
+		}
+	}
+
+	return nil
+}
+
+// normalizeAzureRmVirtualMachinePowerState converts an Instance View status code (e.g. `PowerState/deallocating`)
+// into one of the power states exposed by the provider, reporting transitional states as the state being moved to.
+// An empty string is returned for status codes which aren't a power state.
+func normalizeAzureRmVirtualMachinePowerState(code string) string {
+	if !strings.HasPrefix(code, "PowerState/") {
+		return ""
+	}
+
+	switch state := strings.TrimPrefix(code, "PowerState/"); state {
+	case "starting":
+		return "running"
+	case "stopping":
+		return "stopped"
+	case "deallocating":
+		return "deallocated"
+	default:
+		return state
+	}
+}
+
 func flattenAzureRmVirtualMachinePlan(plan *compute.Plan) []interface{} {
 	if plan == nil {
 		return []interface{}{}
@@ -0,0 +1,370 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/response"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmVirtualMachineCapture() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmVirtualMachineCaptureCreate,
+		Read:   resourceArmVirtualMachineCaptureRead,
+		Delete: resourceArmVirtualMachineCaptureDelete,
+
+		Schema: map[string]*schema.Schema{
+			"virtual_machine_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"image_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"shared_image_version": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validate.SharedImageVersionName,
+						},
+
+						"gallery_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validate.SharedImageGalleryName,
+						},
+
+						"image_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validate.SharedImageName,
+						},
+
+						"resource_group_name": resourceGroupNameSchema(),
+
+						"target_region": {
+							Type:     schema.TypeSet,
+							Required: true,
+							ForceNew: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:             schema.TypeString,
+										Required:         true,
+										ForceNew:         true,
+										StateFunc:        azureRMNormalizeLocation,
+										DiffSuppressFunc: azureRMSuppressLocationDiff,
+									},
+
+									"regional_replica_count": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ForceNew:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+								},
+							},
+						},
+
+						"exclude_from_latest": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"tags": tagsForceNewSchema(),
+
+			"location": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"image_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceArmVirtualMachineCaptureCreate(d *schema.ResourceData, meta interface{}) error {
+	vmClient := meta.(*ArmClient).vmClient
+	imageClient := meta.(*ArmClient).imageClient
+	ctx := meta.(*ArmClient).StopContext
+
+	virtualMachineId := d.Get("virtual_machine_id").(string)
+	id, err := parseAzureResourceID(virtualMachineId)
+	if err != nil {
+		return err
+	}
+	virtualMachineResourceGroup := id.ResourceGroup
+	virtualMachineName := id.Path["virtualMachines"]
+
+	imageName := d.Get("image_name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	tags := d.Get("tags").(map[string]interface{})
+
+	// the Image has to be created in the same location as the source Virtual Machine
+	vm, err := vmClient.Get(ctx, virtualMachineResourceGroup, virtualMachineName, "")
+	if err != nil {
+		return fmt.Errorf("Error retrieving source Virtual Machine %q (Resource Group %q): %+v", virtualMachineName, virtualMachineResourceGroup, err)
+	}
+	if vm.Location == nil {
+		return fmt.Errorf("Error retrieving source Virtual Machine %q (Resource Group %q): `location` was nil", virtualMachineName, virtualMachineResourceGroup)
+	}
+	location := azureRMNormalizeLocation(*vm.Location)
+
+	azureRMLockByName(virtualMachineName, virtualMachineResourceName)
+	defer azureRMUnlockByName(virtualMachineName, virtualMachineResourceName)
+
+	log.Printf("[DEBUG] Deallocating Virtual Machine %q (Resource Group %q) prior to capture", virtualMachineName, virtualMachineResourceGroup)
+	deallocateFuture, err := vmClient.Deallocate(ctx, virtualMachineResourceGroup, virtualMachineName)
+	if err != nil {
+		return fmt.Errorf("Error capturing Virtual Machine %q (Resource Group %q): deallocating: %+v", virtualMachineName, virtualMachineResourceGroup, err)
+	}
+	if err = deallocateFuture.WaitForCompletionRef(ctx, vmClient.Client); err != nil {
+		return fmt.Errorf("Error capturing Virtual Machine %q (Resource Group %q): waiting for deallocation: %+v", virtualMachineName, virtualMachineResourceGroup, err)
+	}
+
+	log.Printf("[DEBUG] Generalizing Virtual Machine %q (Resource Group %q)", virtualMachineName, virtualMachineResourceGroup)
+	if _, err = vmClient.Generalize(ctx, virtualMachineResourceGroup, virtualMachineName); err != nil {
+		return fmt.Errorf("Error capturing Virtual Machine %q (Resource Group %q): generalizing: %+v", virtualMachineName, virtualMachineResourceGroup, err)
+	}
+
+	log.Printf("[DEBUG] Creating Image %q (Resource Group %q) from Virtual Machine %q", imageName, resourceGroup, virtualMachineName)
+	image := compute.Image{
+		Location: utils.String(location),
+		ImageProperties: &compute.ImageProperties{
+			SourceVirtualMachine: &compute.SubResource{
+				ID: utils.String(virtualMachineId),
+			},
+		},
+		Tags: expandTags(tags),
+	}
+	imageFuture, err := imageClient.CreateOrUpdate(ctx, resourceGroup, imageName, image)
+	if err != nil {
+		return fmt.Errorf("Error capturing Virtual Machine %q (Resource Group %q): creating Image %q (Resource Group %q): %+v", virtualMachineName, virtualMachineResourceGroup, imageName, resourceGroup, err)
+	}
+	if err = imageFuture.WaitForCompletionRef(ctx, imageClient.Client); err != nil {
+		return fmt.Errorf("Error capturing Virtual Machine %q (Resource Group %q): waiting for creation of Image %q (Resource Group %q): %+v", virtualMachineName, virtualMachineResourceGroup, imageName, resourceGroup, err)
+	}
+
+	read, err := imageClient.Get(ctx, resourceGroup, imageName, "")
+	if err != nil {
+		return fmt.Errorf("Error retrieving Image %q (Resource Group %q): %+v", imageName, resourceGroup, err)
+	}
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read ID for Image %q (Resource Group %q)", imageName, resourceGroup)
+	}
+
+	// the Image is tracked from this point so that it's cleaned up should publishing the Shared Image Version fail
+	d.SetId(*read.ID)
+
+	if v, ok := d.GetOk("shared_image_version"); ok {
+		if err := createVirtualMachineCaptureSharedImageVersion(meta, v.([]interface{}), *read.ID, location, tags); err != nil {
+			return fmt.Errorf("Error capturing Virtual Machine %q (Resource Group %q): %+v", virtualMachineName, virtualMachineResourceGroup, err)
+		}
+	}
+
+	return resourceArmVirtualMachineCaptureRead(d, meta)
+}
+
+func resourceArmVirtualMachineCaptureRead(d *schema.ResourceData, meta interface{}) error {
+	imageClient := meta.(*ArmClient).imageClient
+	versionsClient := meta.(*ArmClient).galleryImageVersionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	imageName := id.Path["images"]
+
+	resp, err := imageClient.Get(ctx, resourceGroup, imageName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Image %q (Resource Group %q) was not found - removing from state", imageName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Image %q (Resource Group %q): %+v", imageName, resourceGroup, err)
+	}
+
+	d.Set("image_name", imageName)
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("image_id", resp.ID)
+	if location := resp.Location; location != nil {
+		d.Set("location", azureRMNormalizeLocation(*location))
+	}
+
+	if v, ok := d.GetOk("shared_image_version"); ok {
+		config := v.([]interface{})[0].(map[string]interface{})
+		versionName := config["name"].(string)
+		galleryName := config["gallery_name"].(string)
+		galleryImageName := config["image_name"].(string)
+		galleryResourceGroup := config["resource_group_name"].(string)
+
+		version, err := versionsClient.Get(ctx, galleryResourceGroup, galleryName, galleryImageName, versionName, "")
+		if err != nil {
+			if utils.ResponseWasNotFound(version.Response) {
+				log.Printf("[DEBUG] Shared Image Version %q (Image %q / Gallery %q / Resource Group %q) was not found - removing from state", versionName, galleryImageName, galleryName, galleryResourceGroup)
+				d.SetId("")
+				return nil
+			}
+
+			return fmt.Errorf("Error retrieving Shared Image Version %q (Image %q / Gallery %q / Resource Group %q): %+v", versionName, galleryImageName, galleryName, galleryResourceGroup, err)
+		}
+
+		config["id"] = ""
+		if version.ID != nil {
+			config["id"] = *version.ID
+		}
+		if props := version.GalleryImageVersionProperties; props != nil && props.PublishingProfile != nil {
+			config["target_region"] = flattenSharedImageVersionTargetRegions(props.PublishingProfile.TargetRegions)
+			if v := props.PublishingProfile.ExcludeFromLatest; v != nil {
+				config["exclude_from_latest"] = *v
+			}
+		}
+
+		if err := d.Set("shared_image_version", []interface{}{config}); err != nil {
+			return fmt.Errorf("Error setting `shared_image_version`: %+v", err)
+		}
+	}
+
+	flattenAndSetTags(d, resp.Tags)
+
+	return nil
+}
+
+func resourceArmVirtualMachineCaptureDelete(d *schema.ResourceData, meta interface{}) error {
+	imageClient := meta.(*ArmClient).imageClient
+	versionsClient := meta.(*ArmClient).galleryImageVersionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	imageName := id.Path["images"]
+
+	// the Shared Image Version is sourced from the Image, so needs to be removed first
+	if v, ok := d.GetOk("shared_image_version"); ok {
+		config := v.([]interface{})[0].(map[string]interface{})
+		versionName := config["name"].(string)
+		galleryName := config["gallery_name"].(string)
+		galleryImageName := config["image_name"].(string)
+		galleryResourceGroup := config["resource_group_name"].(string)
+
+		future, err := versionsClient.Delete(ctx, galleryResourceGroup, galleryName, galleryImageName, versionName)
+		if err != nil {
+			if !response.WasNotFound(future.Response()) {
+				return fmt.Errorf("Error deleting Shared Image Version %q (Image %q / Gallery %q / Resource Group %q): %+v", versionName, galleryImageName, galleryName, galleryResourceGroup, err)
+			}
+		} else if err = future.WaitForCompletionRef(ctx, versionsClient.Client); err != nil {
+			if !response.WasNotFound(future.Response()) {
+				return fmt.Errorf("Error waiting for deletion of Shared Image Version %q (Image %q / Gallery %q / Resource Group %q): %+v", versionName, galleryImageName, galleryName, galleryResourceGroup, err)
+			}
+		}
+	}
+
+	future, err := imageClient.Delete(ctx, resourceGroup, imageName)
+	if err != nil {
+		if response.WasNotFound(future.Response()) {
+			return nil
+		}
+
+		return fmt.Errorf("Error deleting Image %q (Resource Group %q): %+v", imageName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, imageClient.Client); err != nil {
+		if !response.WasNotFound(future.Response()) {
+			return fmt.Errorf("Error waiting for deletion of Image %q (Resource Group %q): %+v", imageName, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func createVirtualMachineCaptureSharedImageVersion(meta interface{}, input []interface{}, imageId string, location string, tags map[string]interface{}) error {
+	client := meta.(*ArmClient).galleryImageVersionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	config := input[0].(map[string]interface{})
+	versionName := config["name"].(string)
+	galleryName := config["gallery_name"].(string)
+	imageName := config["image_name"].(string)
+	resourceGroup := config["resource_group_name"].(string)
+	excludeFromLatest := config["exclude_from_latest"].(bool)
+
+	targetRegions := make([]compute.TargetRegion, 0)
+	for _, v := range config["target_region"].(*schema.Set).List() {
+		region := v.(map[string]interface{})
+		targetRegions = append(targetRegions, compute.TargetRegion{
+			Name:                 utils.String(region["name"].(string)),
+			RegionalReplicaCount: utils.Int32(int32(region["regional_replica_count"].(int))),
+		})
+	}
+
+	version := compute.GalleryImageVersion{
+		Location: utils.String(location),
+		GalleryImageVersionProperties: &compute.GalleryImageVersionProperties{
+			PublishingProfile: &compute.GalleryImageVersionPublishingProfile{
+				ExcludeFromLatest: utils.Bool(excludeFromLatest),
+				TargetRegions:     &targetRegions,
+				Source: &compute.GalleryArtifactSource{
+					ManagedImage: &compute.ManagedArtifact{
+						ID: utils.String(imageId),
+					},
+				},
+			},
+		},
+		Tags: expandTags(tags),
+	}
+
+	log.Printf("[DEBUG] Creating Shared Image Version %q (Image %q / Gallery %q / Resource Group %q)", versionName, imageName, galleryName, resourceGroup)
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, galleryName, imageName, versionName, version)
+	if err != nil {
+		return fmt.Errorf("creating Shared Image Version %q (Image %q / Gallery %q / Resource Group %q): %+v", versionName, imageName, galleryName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for creation of Shared Image Version %q (Image %q / Gallery %q / Resource Group %q): %+v", versionName, imageName, galleryName, resourceGroup, err)
+	}
+
+	return nil
+}
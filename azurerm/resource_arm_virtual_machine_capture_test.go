@@ -0,0 +1,255 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestAccAzureRMVirtualMachineCapture_image(t *testing.T) {
+	resourceName := "azurerm_virtual_machine_capture.test"
+	ri := acctest.RandInt()
+	userName := "testadmin"
+	password := "Password1234!"
+	hostName := fmt.Sprintf("tftestcapturesrc%d", ri)
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualMachineCaptureDestroy,
+		Steps: []resource.TestStep{
+			{
+				// the guest OS needs to be deprovisioned before the Virtual Machine can be captured
+				Config:  testAccAzureRMVirtualMachineCapture_setup(ri, location, userName, password, hostName),
+				Destroy: false,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureVMExists("azurerm_virtual_machine.testsource", true),
+					testDeprovisionVirtualMachine(userName, password, hostName, "22", location),
+				),
+			},
+			{
+				Config: testAccAzureRMVirtualMachineCapture_image(ri, location, userName, password, hostName),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineCaptureExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "image_id"),
+					testCheckAzureRMVirtualMachinePowerState("azurerm_virtual_machine.testsource", "deallocated"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMVirtualMachineCapture_sharedImageVersion(t *testing.T) {
+	resourceName := "azurerm_virtual_machine_capture.test"
+	ri := acctest.RandInt()
+	userName := "testadmin"
+	password := "Password1234!"
+	hostName := fmt.Sprintf("tftestcapturesrc%d", ri)
+	location := testLocation()
+	altLocation := testAltLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualMachineCaptureDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:  testAccAzureRMVirtualMachineCapture_setup(ri, location, userName, password, hostName),
+				Destroy: false,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureVMExists("azurerm_virtual_machine.testsource", true),
+					testDeprovisionVirtualMachine(userName, password, hostName, "22", location),
+				),
+			},
+			{
+				Config: testAccAzureRMVirtualMachineCapture_sharedImageVersion(ri, location, altLocation, userName, password, hostName),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineCaptureExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "image_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "shared_image_version.0.id"),
+					resource.TestCheckResourceAttr(resourceName, "shared_image_version.0.target_region.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testDeprovisionVirtualMachine(userName string, password string, hostName string, port string, location string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		armClient := testAccProvider.Meta().(*ArmClient)
+		normalizedLocation := azureRMNormalizeLocation(location)
+		suffix := armClient.environment.ResourceManagerVMDNSSuffix
+		dnsName := fmt.Sprintf("%s.%s.%s", hostName, normalizedLocation, suffix)
+
+		if err := deprovisionVM(userName, password, dnsName, port); err != nil {
+			return fmt.Errorf("Bad: Deprovisioning error %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMVirtualMachinePowerState(name string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		vmName := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).vmClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		powerState, err := resourceArmVirtualMachineRetrievePowerState(ctx, client, resourceGroup, vmName)
+		if err != nil {
+			return err
+		}
+
+		if powerState != expected {
+			return fmt.Errorf("Bad: expected Virtual Machine %q (Resource Group %q) to be %q but was %q", vmName, resourceGroup, expected, powerState)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMVirtualMachineCaptureExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		imageName := rs.Primary.Attributes["image_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).imageClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, imageName, "")
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return fmt.Errorf("Bad: Image %q (Resource Group %q) does not exist", imageName, resourceGroup)
+			}
+
+			return fmt.Errorf("Bad: Get on imageClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMVirtualMachineCaptureDestroy(s *terraform.State) error {
+	imageClient := testAccProvider.Meta().(*ArmClient).imageClient
+	versionsClient := testAccProvider.Meta().(*ArmClient).galleryImageVersionsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_virtual_machine_capture" {
+			continue
+		}
+
+		imageName := rs.Primary.Attributes["image_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		resp, err := imageClient.Get(ctx, resourceGroup, imageName, "")
+		if err != nil {
+			if !utils.ResponseWasNotFound(resp.Response) {
+				return err
+			}
+		} else {
+			return fmt.Errorf("Image %q (Resource Group %q) still exists", imageName, resourceGroup)
+		}
+
+		if versionName := rs.Primary.Attributes["shared_image_version.0.name"]; versionName != "" {
+			galleryName := rs.Primary.Attributes["shared_image_version.0.gallery_name"]
+			galleryImageName := rs.Primary.Attributes["shared_image_version.0.image_name"]
+			galleryResourceGroup := rs.Primary.Attributes["shared_image_version.0.resource_group_name"]
+
+			resp, err := versionsClient.Get(ctx, galleryResourceGroup, galleryName, galleryImageName, versionName, "")
+			if err != nil {
+				if !utils.ResponseWasNotFound(resp.Response) {
+					return err
+				}
+			} else {
+				return fmt.Errorf("Shared Image Version %q (Image %q / Gallery %q / Resource Group %q) still exists", versionName, galleryImageName, galleryName, galleryResourceGroup)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccAzureRMVirtualMachineCapture_setup(rInt int, location, username, password, hostname string) string {
+	return testAccAzureRMImage_standaloneImage_setup(rInt, username, password, hostname, location)
+}
+
+func testAccAzureRMVirtualMachineCapture_image(rInt int, location, username, password, hostname string) string {
+	template := testAccAzureRMVirtualMachineCapture_setup(rInt, location, username, password, hostname)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_virtual_machine_capture" "test" {
+  virtual_machine_id  = "${azurerm_virtual_machine.testsource.id}"
+  image_name          = "acctestimage%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+`, template, rInt)
+}
+
+func testAccAzureRMVirtualMachineCapture_sharedImageVersion(rInt int, location, altLocation, username, password, hostname string) string {
+	template := testAccAzureRMVirtualMachineCapture_setup(rInt, location, username, password, hostname)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_shared_image_gallery" "test" {
+  name                = "acctestsig%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+}
+
+resource "azurerm_shared_image" "test" {
+  name                = "acctestimg%d"
+  gallery_name        = "${azurerm_shared_image_gallery.test.name}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  location            = "${azurerm_resource_group.test.location}"
+  os_type             = "Linux"
+
+  identifier {
+    publisher = "AccTesPublisher%d"
+    offer     = "AccTesOffer%d"
+    sku       = "AccTesSku%d"
+  }
+}
+
+resource "azurerm_virtual_machine_capture" "test" {
+  virtual_machine_id  = "${azurerm_virtual_machine.testsource.id}"
+  image_name          = "acctestimage%d"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+
+  shared_image_version {
+    name                = "0.0.1"
+    gallery_name        = "${azurerm_shared_image_gallery.test.name}"
+    image_name          = "${azurerm_shared_image.test.name}"
+    resource_group_name = "${azurerm_resource_group.test.name}"
+
+    target_region {
+      name                   = "${azurerm_resource_group.test.location}"
+      regional_replica_count = 1
+    }
+
+    target_region {
+      name                   = "%s"
+      regional_replica_count = 1
+    }
+  }
+}
+`, template, rInt, rInt, rInt, rInt, rInt, rInt, altLocation)
+}
@@ -0,0 +1,233 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmVirtualMachineRunCommand() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmVirtualMachineRunCommandCreate,
+		Read:   resourceArmVirtualMachineRunCommandRead,
+		Delete: resourceArmVirtualMachineRunCommandDelete,
+
+		Schema: map[string]*schema.Schema{
+			"virtual_machine_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: azure.ValidateResourceID,
+			},
+
+			"command_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"script": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"parameters": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"provisioning_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"stdout": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"stderr": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceArmVirtualMachineRunCommandCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmClient
+	ctx := meta.(*ArmClient).StopContext
+
+	virtualMachineId := d.Get("virtual_machine_id").(string)
+	id, err := parseAzureResourceID(virtualMachineId)
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	virtualMachineName := id.Path["virtualMachines"]
+
+	commandId := d.Get("command_id").(string)
+	input := compute.RunCommandInput{
+		CommandID:  utils.String(commandId),
+		Parameters: expandAzureRmVirtualMachineRunCommandParameters(d.Get("parameters").(map[string]interface{})),
+	}
+
+	if v, ok := d.GetOk("script"); ok {
+		script := make([]string, 0)
+		for _, line := range v.([]interface{}) {
+			script = append(script, line.(string))
+		}
+		input.Script = &script
+	}
+
+	// only a single Run Command can be executed on a Virtual Machine at any one time
+	azureRMLockByName(virtualMachineName, virtualMachineResourceName)
+	defer azureRMUnlockByName(virtualMachineName, virtualMachineResourceName)
+
+	log.Printf("[DEBUG] Running Command %q on Virtual Machine %q (Resource Group %q)", commandId, virtualMachineName, resourceGroup)
+	future, err := client.RunCommand(ctx, resourceGroup, virtualMachineName, input)
+	if err != nil {
+		return fmt.Errorf("Error running Command %q on Virtual Machine %q (Resource Group %q): %+v", commandId, virtualMachineName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for Command %q to complete on Virtual Machine %q (Resource Group %q): %+v", commandId, virtualMachineName, resourceGroup, err)
+	}
+
+	result, err := future.Result(client)
+	if err != nil {
+		return fmt.Errorf("Error retrieving result of Command %q on Virtual Machine %q (Resource Group %q): %+v", commandId, virtualMachineName, resourceGroup, err)
+	}
+
+	// Run Commands aren't a resource within Azure, so we generate a unique ID scoped to the Virtual Machine
+	runId, err := uuid.GenerateUUID()
+	if err != nil {
+		return fmt.Errorf("Error generating ID for Command %q on Virtual Machine %q (Resource Group %q): %+v", commandId, virtualMachineName, resourceGroup, err)
+	}
+	d.SetId(fmt.Sprintf("%s/runCommands/%s", virtualMachineId, runId))
+
+	provisioningState, stdout, stderr := flattenAzureRmVirtualMachineRunCommandResult(result.Value)
+	d.Set("provisioning_state", provisioningState)
+	d.Set("stdout", stdout)
+	d.Set("stderr", stderr)
+
+	return resourceArmVirtualMachineRunCommandRead(d, meta)
+}
+
+func resourceArmVirtualMachineRunCommandRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Get("virtual_machine_id").(string))
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	virtualMachineName := id.Path["virtualMachines"]
+
+	// the output of a Run Command can't be retrieved once it's completed, so all we can check is the Virtual Machine still exists
+	resp, err := client.Get(ctx, resourceGroup, virtualMachineName, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Virtual Machine %q (Resource Group %q) was not found - removing Run Command from state", virtualMachineName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Virtual Machine %q (Resource Group %q): %+v", virtualMachineName, resourceGroup, err)
+	}
+
+	return nil
+}
+
+func resourceArmVirtualMachineRunCommandDelete(d *schema.ResourceData, meta interface{}) error {
+	// a Run Command can't be undone, so this is just removed from the state
+	log.Printf("[DEBUG] Removing Run Command %q from state - the Command has already been run on the Virtual Machine", d.Id())
+	return nil
+}
+
+func expandAzureRmVirtualMachineRunCommandParameters(input map[string]interface{}) *[]compute.RunCommandInputParameter {
+	parameters := make([]compute.RunCommandInputParameter, 0)
+
+	for name, value := range input {
+		parameters = append(parameters, compute.RunCommandInputParameter{
+			Name:  utils.String(name),
+			Value: utils.String(value.(string)),
+		})
+	}
+
+	return &parameters
+}
+
+// flattenAzureRmVirtualMachineRunCommandResult returns the provisioning state, stdout and stderr from the result of a Run
+// Command. Windows returns separate `ComponentStatus/StdOut/{state}` and `ComponentStatus/StdErr/{state}` statuses,
+// whereas Linux returns a single `ProvisioningState/{state}` status with both streams in the message. The state is
+// whether the Command could be run - the exit code of the script isn't returned, so a failing script can still succeed.
+func flattenAzureRmVirtualMachineRunCommandResult(input *[]compute.InstanceViewStatus) (string, string, string) {
+	provisioningState := ""
+	stdout := ""
+	stderr := ""
+
+	if input == nil {
+		return provisioningState, stdout, stderr
+	}
+
+	for _, v := range *input {
+		if v.Code == nil {
+			continue
+		}
+
+		code := *v.Code
+		message := ""
+		if v.Message != nil {
+			message = *v.Message
+		}
+
+		if provisioningState == "" {
+			segments := strings.Split(code, "/")
+			provisioningState = segments[len(segments)-1]
+		}
+
+		switch {
+		case strings.Contains(code, "/StdOut/"):
+			stdout = message
+		case strings.Contains(code, "/StdErr/"):
+			stderr = message
+		case strings.HasPrefix(code, "ProvisioningState/"):
+			stdout, stderr = splitAzureRmVirtualMachineRunCommandLinuxOutput(message)
+		}
+	}
+
+	return provisioningState, stdout, stderr
+}
+
+func splitAzureRmVirtualMachineRunCommandLinuxOutput(message string) (string, string) {
+	stdoutIndex := strings.Index(message, "[stdout]\n")
+	stderrIndex := strings.Index(message, "[stderr]\n")
+	if stdoutIndex == -1 || stderrIndex == -1 || stderrIndex < stdoutIndex {
+		return message, ""
+	}
+
+	stdout := message[stdoutIndex+len("[stdout]\n") : stderrIndex]
+	stderr := message[stderrIndex+len("[stderr]\n"):]
+	return strings.TrimSuffix(stdout, "\n"), strings.TrimSuffix(stderr, "\n")
+}
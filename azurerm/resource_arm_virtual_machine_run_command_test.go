@@ -0,0 +1,129 @@
+package azurerm
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestAzureRMVirtualMachineRunCommand_flattenResult(t *testing.T) {
+	cases := []struct {
+		Input                     []compute.InstanceViewStatus
+		ExpectedProvisioningState string
+		ExpectedStdOut            string
+		ExpectedStdErr            string
+	}{
+		{
+			Input: []compute.InstanceViewStatus{},
+		},
+		{
+			Input: []compute.InstanceViewStatus{
+				{
+					Code:    utils.String("ComponentStatus/StdOut/succeeded"),
+					Message: utils.String("hello"),
+				},
+				{
+					Code:    utils.String("ComponentStatus/StdErr/succeeded"),
+					Message: utils.String("world"),
+				},
+			},
+			ExpectedProvisioningState: "succeeded",
+			ExpectedStdOut:            "hello",
+			ExpectedStdErr:            "world",
+		},
+		{
+			Input: []compute.InstanceViewStatus{
+				{
+					Code:    utils.String("ProvisioningState/succeeded"),
+					Message: utils.String("Enable succeeded: \n[stdout]\nhello\n\n[stderr]\nworld\n"),
+				},
+			},
+			ExpectedProvisioningState: "succeeded",
+			ExpectedStdOut:            "hello\n",
+			ExpectedStdErr:            "world",
+		},
+		{
+			Input: []compute.InstanceViewStatus{
+				{
+					Code:    utils.String("ProvisioningState/failed"),
+					Message: utils.String("Enable failed"),
+				},
+			},
+			ExpectedProvisioningState: "failed",
+			ExpectedStdOut:            "Enable failed",
+		},
+	}
+
+	for _, tc := range cases {
+		provisioningState, stdout, stderr := flattenAzureRmVirtualMachineRunCommandResult(&tc.Input)
+
+		if provisioningState != tc.ExpectedProvisioningState {
+			t.Fatalf("Expected the provisioning state to be %q but got %q", tc.ExpectedProvisioningState, provisioningState)
+		}
+
+		if stdout != tc.ExpectedStdOut {
+			t.Fatalf("Expected stdout to be %q but got %q", tc.ExpectedStdOut, stdout)
+		}
+
+		if stderr != tc.ExpectedStdErr {
+			t.Fatalf("Expected stderr to be %q but got %q", tc.ExpectedStdErr, stderr)
+		}
+	}
+}
+
+func TestAccAzureRMVirtualMachineRunCommand_script(t *testing.T) {
+	resourceName := "azurerm_virtual_machine_run_command.test"
+	var vm compute.VirtualMachine
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualMachineDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMVirtualMachineRunCommand_script(ri, location, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineExists("azurerm_virtual_machine.test", &vm),
+					resource.TestCheckResourceAttr(resourceName, "provisioning_state", "succeeded"),
+					resource.TestMatchResourceAttr(resourceName, "stdout", regexp.MustCompile("hello first")),
+				),
+			},
+			{
+				Config: testAccAzureRMVirtualMachineRunCommand_script(ri, location, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineExists("azurerm_virtual_machine.test", &vm),
+					resource.TestCheckResourceAttr(resourceName, "provisioning_state", "succeeded"),
+					resource.TestMatchResourceAttr(resourceName, "stdout", regexp.MustCompile("hello second")),
+				),
+			},
+		},
+	})
+}
+
+func testAccAzureRMVirtualMachineRunCommand_script(rInt int, location string, trigger string) string {
+	template := testAccAzureRMVirtualMachine_powerState(rInt, location, "running")
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_virtual_machine_run_command" "test" {
+  virtual_machine_id = "${azurerm_virtual_machine.test.id}"
+  command_id         = "RunShellScript"
+  script             = ["echo hello $1"]
+
+  parameters {
+    arg1 = "%s"
+  }
+
+  triggers {
+    run = "%s"
+  }
+}
+`, template, trigger, trigger)
+}
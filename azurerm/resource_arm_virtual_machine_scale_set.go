@@ -2,13 +2,16 @@ package azurerm
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/structure"
 	"github.com/hashicorp/terraform/helper/validation"
@@ -18,6 +21,8 @@ import (
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+var virtualMachineScaleSetResourceName = "azurerm_virtual_machine_scale_set"
+
 func resourceArmVirtualMachineScaleSet() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmVirtualMachineScaleSetCreate,
@@ -26,7 +31,7 @@ func resourceArmVirtualMachineScaleSet() *schema.Resource {
 		Delete: resourceArmVirtualMachineScaleSetDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceArmVirtualMachineScaleSetImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -175,6 +180,29 @@ func resourceArmVirtualMachineScaleSet() *schema.Resource {
 				DiffSuppressFunc: azureRmVirtualMachineScaleSetSuppressRollingUpgradePolicyDiff,
 			},
 
+			// the following fields only control how an update is rolled out, so they aren't returned by the API
+			// and intentionally have no default values (which would otherwise show as a diff after an import)
+			"update_instances_on_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"wait_for_rolling_upgrade": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"rolling_upgrade_timeout_in_minutes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"cancel_rolling_upgrade_on_timeout": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
 			"overprovision": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -732,6 +760,17 @@ func resourceArmVirtualMachineScaleSet() *schema.Resource {
 				Set: resourceArmVirtualMachineScaleSetExtensionHash,
 			},
 
+			"power_state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"running",
+					"stopped",
+					"deallocated",
+				}, false),
+			},
+
 			"tags": tagsSchema(),
 		},
 
@@ -784,11 +823,6 @@ func resourceArmVirtualMachineScaleSetCreate(d *schema.ResourceData, meta interf
 		return err
 	}
 
-	extensions, err := expandAzureRMVirtualMachineScaleSetExtensions(d)
-	if err != nil {
-		return err
-	}
-
 	upgradePolicy := d.Get("upgrade_policy_mode").(string)
 	automaticOsUpgrade := d.Get("automatic_os_upgrade").(bool)
 	overprovision := d.Get("overprovision").(bool)
@@ -803,16 +837,25 @@ func resourceArmVirtualMachineScaleSetCreate(d *schema.ResourceData, meta interf
 			RollingUpgradePolicy: expandAzureRmRollingUpgradePolicy(d),
 		},
 		VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{
-			NetworkProfile:   expandAzureRmVirtualMachineScaleSetNetworkProfile(d),
-			StorageProfile:   &storageProfile,
-			OsProfile:        osProfile,
-			ExtensionProfile: extensions,
-			Priority:         compute.VirtualMachinePriorityTypes(priority),
+			NetworkProfile: expandAzureRmVirtualMachineScaleSetNetworkProfile(d),
+			StorageProfile: &storageProfile,
+			OsProfile:      osProfile,
+			Priority:       compute.VirtualMachinePriorityTypes(priority),
 		},
 		Overprovision:        &overprovision,
 		SinglePlacementGroup: &singlePlacementGroup,
 	}
 
+	// on update the Extension Profile is omitted and the in-line Extensions are changed individually instead, so that
+	// Extensions managed by the `azurerm_virtual_machine_scale_set_extension` resource are left untouched
+	if d.IsNewResource() {
+		extensions, err := expandAzureRMVirtualMachineScaleSetExtensions(d)
+		if err != nil {
+			return err
+		}
+		scaleSetProps.VirtualMachineProfile.ExtensionProfile = extensions
+	}
+
 	if strings.EqualFold(priority, string(compute.Low)) {
 		scaleSetProps.VirtualMachineProfile.EvictionPolicy = compute.VirtualMachineEvictionPolicyTypes(evictionPolicy)
 	}
@@ -854,6 +897,15 @@ func resourceArmVirtualMachineScaleSetCreate(d *schema.ResourceData, meta interf
 		properties.Plan = plan
 	}
 
+	// the latest Rolling Upgrade is recorded before updating, so that we can tell when the update triggers a new one
+	previousRollingUpgrade := ""
+	if !d.IsNewResource() && d.Get("wait_for_rolling_upgrade").(bool) {
+		previousRollingUpgrade, err = resourceArmVirtualMachineScaleSetLatestRollingUpgradeStartTime(ctx, meta, resGroup, name)
+		if err != nil {
+			return err
+		}
+	}
+
 	future, err := client.CreateOrUpdate(ctx, resGroup, name, properties)
 	if err != nil {
 		return err
@@ -873,6 +925,26 @@ func resourceArmVirtualMachineScaleSetCreate(d *schema.ResourceData, meta interf
 
 	d.SetId(*read.ID)
 
+	if !d.IsNewResource() && d.HasChange("extension") {
+		if err := resourceArmVirtualMachineScaleSetUpdateExtensions(ctx, d, meta, resGroup, name); err != nil {
+			return err
+		}
+	}
+
+	// new instances are always created from the latest model, so there's only something to roll out on update
+	if !d.IsNewResource() {
+		if err := resourceArmVirtualMachineScaleSetUpgradeInstances(ctx, d, meta, resGroup, name, previousRollingUpgrade); err != nil {
+			return err
+		}
+	}
+
+	// updating a Virtual Machine Scale Set can start its instances, so the requested (or previously known) power state is re-applied
+	if v, ok := d.GetOk("power_state"); ok {
+		if err := resourceArmVirtualMachineScaleSetUpdatePowerState(ctx, client, resGroup, name, v.(string)); err != nil {
+			return err
+		}
+	}
+
 	return resourceArmVirtualMachineScaleSetRead(d, meta)
 }
 
@@ -1011,6 +1083,9 @@ func resourceArmVirtualMachineScaleSetRead(d *schema.ResourceData, meta interfac
 				if err != nil {
 					return fmt.Errorf("[DEBUG] Error setting Virtual Machine Scale Set Extension Profile error: %#v", err)
 				}
+				// only the in-line Extensions are read, so that those managed by the `azurerm_virtual_machine_scale_set_extension`
+				// resource don't show up as a diff
+				extension = filterAzureRmVirtualMachineScaleSetInlineExtensions(extension, d.Get("extension").(*schema.Set).List())
 				if err := d.Set("extension", extension); err != nil {
 					return fmt.Errorf("[DEBUG] Error setting `extension`: %#v", err)
 				}
@@ -1026,6 +1101,12 @@ func resourceArmVirtualMachineScaleSetRead(d *schema.ResourceData, meta interfac
 		}
 	}
 
+	powerState, err := resourceArmVirtualMachineScaleSetRetrievePowerState(ctx, client, resGroup, name)
+	if err != nil {
+		return err
+	}
+	d.Set("power_state", powerState)
+
 	flattenAndSetTags(d, resp.Tags)
 
 	return nil
@@ -1054,6 +1135,346 @@ func resourceArmVirtualMachineScaleSetDelete(d *schema.ResourceData, meta interf
 	return nil
 }
 
+// resourceArmVirtualMachineScaleSetUpgradeInstances rolls the latest model out to existing instances - either by
+// updating the outdated instances directly (Manual mode) or by waiting on the Rolling Upgrade Azure starts (Rolling mode).
+func resourceArmVirtualMachineScaleSetUpgradeInstances(ctx context.Context, d *schema.ResourceData, meta interface{}, resGroup string, name string, previousRollingUpgrade string) error {
+	mode := strings.ToLower(d.Get("upgrade_policy_mode").(string))
+	updateInstances := mode == strings.ToLower(string(compute.Manual)) && d.Get("update_instances_on_change").(bool)
+	waitForRollingUpgrade := mode == strings.ToLower(string(compute.Rolling)) && d.Get("wait_for_rolling_upgrade").(bool)
+	if !updateInstances && !waitForRollingUpgrade {
+		return nil
+	}
+
+	instanceIds, err := resourceArmVirtualMachineScaleSetOutdatedInstanceIds(ctx, meta, resGroup, name)
+	if err != nil {
+		return err
+	}
+
+	if len(instanceIds) == 0 {
+		log.Printf("[DEBUG] All instances of Virtual Machine Scale Set %q (Resource Group %q) are running the latest model", name, resGroup)
+		return nil
+	}
+
+	if updateInstances {
+		client := meta.(*ArmClient).vmScaleSetClient
+
+		log.Printf("[DEBUG] Updating %d instance(s) of Virtual Machine Scale Set %q (Resource Group %q) to the latest model", len(instanceIds), name, resGroup)
+		input := compute.VirtualMachineScaleSetVMInstanceRequiredIDs{
+			InstanceIds: &instanceIds,
+		}
+		future, err := client.UpdateInstances(ctx, resGroup, name, input)
+		if err != nil {
+			return fmt.Errorf("Error updating instances of Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for instances of Virtual Machine Scale Set %q (Resource Group %q) to be updated: %+v", name, resGroup, err)
+		}
+
+		return nil
+	}
+
+	timeoutInMinutes := 60
+	if v, ok := d.GetOk("rolling_upgrade_timeout_in_minutes"); ok {
+		timeoutInMinutes = v.(int)
+	}
+	log.Printf("[DEBUG] Waiting up to %d minutes for the Rolling Upgrade of Virtual Machine Scale Set %q (Resource Group %q) to complete", timeoutInMinutes, name, resGroup)
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"Pending", string(compute.RollingUpgradeStatusCodeRollingForward)},
+		Target:     []string{string(compute.RollingUpgradeStatusCodeCompleted)},
+		Refresh:    virtualMachineScaleSetRollingUpgradeStateRefreshFunc(ctx, meta, resGroup, name, previousRollingUpgrade),
+		Timeout:    time.Duration(timeoutInMinutes) * time.Minute,
+		MinTimeout: 15 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		if _, ok := err.(*resource.TimeoutError); !ok || !d.Get("cancel_rolling_upgrade_on_timeout").(bool) {
+			return fmt.Errorf("Error waiting for the Rolling Upgrade of Virtual Machine Scale Set %q (Resource Group %q) to complete: %+v", name, resGroup, err)
+		}
+
+		rollingUpgradesClient := meta.(*ArmClient).vmScaleSetRollingUpgradesClient
+		log.Printf("[DEBUG] Cancelling the Rolling Upgrade of Virtual Machine Scale Set %q (Resource Group %q)", name, resGroup)
+		future, cancelErr := rollingUpgradesClient.Cancel(ctx, resGroup, name)
+		if cancelErr != nil {
+			return fmt.Errorf("Error cancelling the Rolling Upgrade of Virtual Machine Scale Set %q (Resource Group %q) after it timed out: %+v", name, resGroup, cancelErr)
+		}
+
+		if cancelErr = future.WaitForCompletionRef(ctx, rollingUpgradesClient.Client); cancelErr != nil {
+			return fmt.Errorf("Error waiting for the Rolling Upgrade of Virtual Machine Scale Set %q (Resource Group %q) to be cancelled after it timed out: %+v", name, resGroup, cancelErr)
+		}
+
+		return fmt.Errorf("The Rolling Upgrade of Virtual Machine Scale Set %q (Resource Group %q) was cancelled since it didn't complete within %d minutes: %+v", name, resGroup, timeoutInMinutes, err)
+	}
+
+	return nil
+}
+
+func resourceArmVirtualMachineScaleSetOutdatedInstanceIds(ctx context.Context, meta interface{}, resGroup string, name string) ([]string, error) {
+	client := meta.(*ArmClient).vmScaleSetVMsClient
+
+	results, err := client.ListComplete(ctx, resGroup, name, "", "", "")
+	if err != nil {
+		return nil, fmt.Errorf("Error listing instances of Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	instanceIds := make([]string, 0)
+	for results.NotDone() {
+		instance := results.Value()
+		if props := instance.VirtualMachineScaleSetVMProperties; props != nil && instance.InstanceID != nil {
+			if props.LatestModelApplied != nil && !*props.LatestModelApplied {
+				instanceIds = append(instanceIds, *instance.InstanceID)
+			}
+		}
+
+		if err = results.Next(); err != nil {
+			return nil, fmt.Errorf("Error going to next instance of Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resGroup, err)
+		}
+	}
+
+	return instanceIds, nil
+}
+
+// resourceArmVirtualMachineScaleSetLatestRollingUpgradeStartTime returns the start time of the latest Rolling Upgrade
+// of the Virtual Machine Scale Set, or an empty string if it's never had one.
+func resourceArmVirtualMachineScaleSetLatestRollingUpgradeStartTime(ctx context.Context, meta interface{}, resGroup string, name string) (string, error) {
+	client := meta.(*ArmClient).vmScaleSetRollingUpgradesClient
+
+	resp, err := client.GetLatest(ctx, resGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("Error retrieving the latest Rolling Upgrade of Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	if props := resp.RollingUpgradeStatusInfoProperties; props != nil {
+		if status := props.RunningStatus; status != nil && status.StartTime != nil {
+			return status.StartTime.String(), nil
+		}
+	}
+
+	return "", nil
+}
+
+func virtualMachineScaleSetRollingUpgradeStateRefreshFunc(ctx context.Context, meta interface{}, resGroup string, name string, previousRollingUpgrade string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		client := meta.(*ArmClient).vmScaleSetRollingUpgradesClient
+
+		resp, err := client.GetLatest(ctx, resGroup, name)
+		if err != nil {
+			if utils.ResponseWasNotFound(resp.Response) {
+				return resp, "Pending", nil
+			}
+
+			return nil, "", fmt.Errorf("Error retrieving the latest Rolling Upgrade of Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resGroup, err)
+		}
+
+		props := resp.RollingUpgradeStatusInfoProperties
+		if props == nil || props.RunningStatus == nil || props.RunningStatus.StartTime == nil {
+			return resp, "Pending", nil
+		}
+
+		// Azure hasn't started the Rolling Upgrade for this update yet
+		if props.RunningStatus.StartTime.String() == previousRollingUpgrade {
+			return resp, "Pending", nil
+		}
+
+		code := props.RunningStatus.Code
+		if code == compute.RollingUpgradeStatusCodeFaulted || code == compute.RollingUpgradeStatusCodeCancelled {
+			failed := int32(0)
+			successful := int32(0)
+			if progress := props.Progress; progress != nil {
+				if progress.FailedInstanceCount != nil {
+					failed = *progress.FailedInstanceCount
+				}
+				if progress.SuccessfulInstanceCount != nil {
+					successful = *progress.SuccessfulInstanceCount
+				}
+			}
+
+			message := ""
+			if props.Error != nil && props.Error.Message != nil {
+				message = *props.Error.Message
+			}
+
+			return resp, string(code), fmt.Errorf("Rolling Upgrade finished with status %q (%d instance(s) failed, %d succeeded): %s", string(code), failed, successful, message)
+		}
+
+		return resp, string(code), nil
+	}
+}
+
+// resourceArmVirtualMachineScaleSetRetrievePowerState returns the power state shared by all instances in the
+// Virtual Machine Scale Set, or `mixed` when the instances are in different power states.
+func resourceArmVirtualMachineScaleSetRetrievePowerState(ctx context.Context, client compute.VirtualMachineScaleSetsClient, resGroup string, name string) (string, error) {
+	instanceView, err := client.GetInstanceView(ctx, resGroup, name)
+	if err != nil {
+		return "", fmt.Errorf("Error retrieving Instance View for Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	if instanceView.VirtualMachine == nil || instanceView.VirtualMachine.StatusesSummary == nil {
+		return "", nil
+	}
+
+	powerState := ""
+	for _, summary := range *instanceView.VirtualMachine.StatusesSummary {
+		if summary.Code == nil {
+			continue
+		}
+
+		state := normalizeAzureRmVirtualMachinePowerState(*summary.Code)
+		if state == "" {
+			continue
+		}
+
+		if powerState != "" && powerState != state {
+			return "mixed", nil
+		}
+		powerState = state
+	}
+
+	return powerState, nil
+}
+
+// resourceArmVirtualMachineScaleSetUpdateExtensions applies the changes to the in-line Extensions individually, so that
+// any other Extensions on the Virtual Machine Scale Set are left untouched
+func resourceArmVirtualMachineScaleSetUpdateExtensions(ctx context.Context, d *schema.ResourceData, meta interface{}, resGroup string, name string) error {
+	client := meta.(*ArmClient).vmScaleSetExtensionsClient
+
+	o, n := d.GetChange("extension")
+	oldExtensions := o.(*schema.Set)
+	newExtensions := n.(*schema.Set)
+
+	changed, err := expandAzureRMVirtualMachineScaleSetExtensionList(newExtensions.Difference(oldExtensions).List())
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]struct{})
+	for _, v := range newExtensions.List() {
+		names[v.(map[string]interface{})["name"].(string)] = struct{}{}
+	}
+
+	// only a single operation can be performed on a Virtual Machine Scale Set at any one time
+	azureRMLockByName(name, virtualMachineScaleSetResourceName)
+	defer azureRMUnlockByName(name, virtualMachineScaleSetResourceName)
+
+	for _, v := range oldExtensions.Difference(newExtensions).List() {
+		extensionName := v.(map[string]interface{})["name"].(string)
+		if _, ok := names[extensionName]; ok {
+			// this Extension has been changed rather than removed
+			continue
+		}
+
+		log.Printf("[DEBUG] Removing Extension %q from Virtual Machine Scale Set %q (Resource Group %q)", extensionName, name, resGroup)
+		future, err := client.Delete(ctx, resGroup, name, extensionName)
+		if err != nil {
+			return fmt.Errorf("Error removing Extension %q from Virtual Machine Scale Set %q (Resource Group %q): %+v", extensionName, name, resGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for removal of Extension %q from Virtual Machine Scale Set %q (Resource Group %q): %+v", extensionName, name, resGroup, err)
+		}
+	}
+
+	for _, extension := range changed {
+		extensionName := *extension.Name
+
+		log.Printf("[DEBUG] Creating/updating Extension %q on Virtual Machine Scale Set %q (Resource Group %q)", extensionName, name, resGroup)
+		future, err := client.CreateOrUpdate(ctx, resGroup, name, extensionName, extension)
+		if err != nil {
+			return fmt.Errorf("Error creating/updating Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", extensionName, name, resGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for creation/update of Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", extensionName, name, resGroup, err)
+		}
+	}
+
+	return nil
+}
+
+// resourceArmVirtualMachineScaleSetImport includes all of the Extensions when importing, since otherwise only the
+// Extensions which are already defined in-line are read
+func resourceArmVirtualMachineScaleSetImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*ArmClient).vmScaleSetClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	resGroup := id.ResourceGroup
+	name := id.Path["virtualMachineScaleSets"]
+
+	resp, err := client.Get(ctx, resGroup, name)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resGroup, err)
+	}
+
+	if props := resp.VirtualMachineScaleSetProperties; props != nil && props.VirtualMachineProfile != nil {
+		if extensionProfile := props.VirtualMachineProfile.ExtensionProfile; extensionProfile != nil {
+			extensions, err := flattenAzureRmVirtualMachineScaleSetExtensionProfile(extensionProfile)
+			if err != nil {
+				return nil, fmt.Errorf("Error flattening the Extensions of Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resGroup, err)
+			}
+			if err := d.Set("extension", extensions); err != nil {
+				return nil, fmt.Errorf("Error setting `extension`: %+v", err)
+			}
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceArmVirtualMachineScaleSetUpdatePowerState(ctx context.Context, client compute.VirtualMachineScaleSetsClient, resGroup string, name string, powerState string) error {
+	current, err := resourceArmVirtualMachineScaleSetRetrievePowerState(ctx, client, resGroup, name)
+	if err != nil {
+		return err
+	}
+
+	if current == powerState {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Changing Power State of Virtual Machine Scale Set %q (Resource Group %q) from %q to %q", name, resGroup, current, powerState)
+
+	// omitting the Instance IDs applies the operation to every instance in the Scale Set
+	switch powerState {
+	case "running":
+		future, err := client.Start(ctx, resGroup, name, nil)
+		if err != nil {
+			return fmt.Errorf("Error starting Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for Virtual Machine Scale Set %q (Resource Group %q) to start: %+v", name, resGroup, err)
+		}
+
+	case "stopped":
+		future, err := client.PowerOff(ctx, resGroup, name, nil)
+		if err != nil {
+			return fmt.Errorf("Error stopping Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for Virtual Machine Scale Set %q (Resource Group %q) to stop: %+v", name, resGroup, err)
+		}
+
+	case "deallocated":
+		future, err := client.Deallocate(ctx, resGroup, name, nil)
+		if err != nil {
+			return fmt.Errorf("Error deallocating Virtual Machine Scale Set %q (Resource Group %q): %+v", name, resGroup, err)
+		}
+
+		if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return fmt.Errorf("Error waiting for Virtual Machine Scale Set %q (Resource Group %q) to deallocate: %+v", name, resGroup, err)
+		}
+	}
+
+	return nil
+}
+
 func flattenAzureRmVirtualMachineScaleSetIdentity(identity *compute.VirtualMachineScaleSetIdentity) []interface{} {
 	if identity == nil {
 		return make([]interface{}, 0)
@@ -1431,6 +1852,29 @@ func flattenAzureRmVirtualMachineScaleSetSku(sku *compute.Sku) []interface{} {
 	return []interface{}{result}
 }
 
+// filterAzureRmVirtualMachineScaleSetInlineExtensions returns the Extensions which are defined in-line, retaining their
+// `protected_settings` from the state since these aren't returned by the API
+func filterAzureRmVirtualMachineScaleSetInlineExtensions(extensions []map[string]interface{}, existing []interface{}) []map[string]interface{} {
+	protectedSettings := make(map[string]interface{})
+	for _, v := range existing {
+		extension := v.(map[string]interface{})
+		protectedSettings[extension["name"].(string)] = extension["protected_settings"]
+	}
+
+	result := make([]map[string]interface{}, 0)
+	for _, extension := range extensions {
+		settings, ok := protectedSettings[extension["name"].(string)]
+		if !ok {
+			continue
+		}
+
+		extension["protected_settings"] = settings
+		result = append(result, extension)
+	}
+
+	return result
+}
+
 func flattenAzureRmVirtualMachineScaleSetExtensionProfile(profile *compute.VirtualMachineScaleSetExtensionProfile) ([]map[string]interface{}, error) {
 	if profile.Extensions == nil {
 		return nil, nil
@@ -2113,7 +2557,17 @@ func expandAzureRmVirtualMachineScaleSetOsProfileSecrets(d *schema.ResourceData)
 }
 
 func expandAzureRMVirtualMachineScaleSetExtensions(d *schema.ResourceData) (*compute.VirtualMachineScaleSetExtensionProfile, error) {
-	extensions := d.Get("extension").(*schema.Set).List()
+	resources, err := expandAzureRMVirtualMachineScaleSetExtensionList(d.Get("extension").(*schema.Set).List())
+	if err != nil {
+		return nil, err
+	}
+
+	return &compute.VirtualMachineScaleSetExtensionProfile{
+		Extensions: &resources,
+	}, nil
+}
+
+func expandAzureRMVirtualMachineScaleSetExtensionList(extensions []interface{}) ([]compute.VirtualMachineScaleSetExtension, error) {
 	resources := make([]compute.VirtualMachineScaleSetExtension, 0, len(extensions))
 	for _, e := range extensions {
 		config := e.(map[string]interface{})
@@ -2155,9 +2609,7 @@ func expandAzureRMVirtualMachineScaleSetExtensions(d *schema.ResourceData) (*com
 		resources = append(resources, extension)
 	}
 
-	return &compute.VirtualMachineScaleSetExtensionProfile{
-		Extensions: &resources,
-	}, nil
+	return resources, nil
 }
 
 func expandAzureRmVirtualMachineScaleSetPlan(d *schema.ResourceData) (*compute.Plan, error) {
@@ -2208,6 +2660,19 @@ func azureRmVirtualMachineScaleSetCustomizeDiff(d *schema.ResourceDiff, _ interf
 				return fmt.Errorf("If `upgrade_policy_mode` is `%s`, `rolling_upgrade_policy` must be removed or set to default values", mode)
 			}
 		}
+
+		if d.Get("wait_for_rolling_upgrade").(bool) {
+			return fmt.Errorf("`wait_for_rolling_upgrade` can only be set when `upgrade_policy_mode` is `Rolling`")
+		}
+	}
+
+	if strings.ToLower(mode) != "manual" && d.Get("update_instances_on_change").(bool) {
+		return fmt.Errorf("`update_instances_on_change` can only be set when `upgrade_policy_mode` is `Manual`")
 	}
+
+	if d.Get("cancel_rolling_upgrade_on_timeout").(bool) && !d.Get("wait_for_rolling_upgrade").(bool) {
+		return fmt.Errorf("`cancel_rolling_upgrade_on_timeout` can only be set when `wait_for_rolling_upgrade` is `true`")
+	}
+
 	return nil
 }
@@ -0,0 +1,229 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-06-01/compute"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/structure"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func resourceArmVirtualMachineScaleSetExtension() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmVirtualMachineScaleSetExtensionCreateUpdate,
+		Read:   resourceArmVirtualMachineScaleSetExtensionRead,
+		Update: resourceArmVirtualMachineScaleSetExtensionCreateUpdate,
+		Delete: resourceArmVirtualMachineScaleSetExtensionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": resourceGroupNameSchema(),
+
+			"virtual_machine_scale_set_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"publisher": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"type_handler_version": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"auto_upgrade_minor_version": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"force_update_tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"settings": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.ValidateJsonString,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+
+			"protected_settings": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				ValidateFunc:     validation.ValidateJsonString,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+		},
+	}
+}
+
+func resourceArmVirtualMachineScaleSetExtensionCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmScaleSetExtensionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+	scaleSetName := d.Get("virtual_machine_scale_set_name").(string)
+
+	props := compute.VirtualMachineScaleSetExtensionProperties{
+		Publisher:               utils.String(d.Get("publisher").(string)),
+		Type:                    utils.String(d.Get("type").(string)),
+		TypeHandlerVersion:      utils.String(d.Get("type_handler_version").(string)),
+		AutoUpgradeMinorVersion: utils.Bool(d.Get("auto_upgrade_minor_version").(bool)),
+	}
+
+	if v, ok := d.GetOk("force_update_tag"); ok {
+		props.ForceUpdateTag = utils.String(v.(string))
+	}
+
+	if settingsString := d.Get("settings").(string); settingsString != "" {
+		settings, err := structure.ExpandJsonFromString(settingsString)
+		if err != nil {
+			return fmt.Errorf("unable to parse settings: %s", err)
+		}
+		props.Settings = &settings
+	}
+
+	if protectedSettingsString := d.Get("protected_settings").(string); protectedSettingsString != "" {
+		protectedSettings, err := structure.ExpandJsonFromString(protectedSettingsString)
+		if err != nil {
+			return fmt.Errorf("unable to parse protected_settings: %s", err)
+		}
+		props.ProtectedSettings = &protectedSettings
+	}
+
+	extension := compute.VirtualMachineScaleSetExtension{
+		Name: utils.String(name),
+		VirtualMachineScaleSetExtensionProperties: &props,
+	}
+
+	// only a single operation can be performed on a Virtual Machine Scale Set at any one time
+	azureRMLockByName(scaleSetName, virtualMachineScaleSetResourceName)
+	defer azureRMUnlockByName(scaleSetName, virtualMachineScaleSetResourceName)
+
+	future, err := client.CreateOrUpdate(ctx, resourceGroup, scaleSetName, name, extension)
+	if err != nil {
+		return fmt.Errorf("Error creating/updating Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", name, scaleSetName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for creation/update of Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", name, scaleSetName, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, scaleSetName, name, "")
+	if err != nil {
+		return fmt.Errorf("Error retrieving Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", name, scaleSetName, resourceGroup, err)
+	}
+
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read ID for Extension %q (Virtual Machine Scale Set %q / Resource Group %q)", name, scaleSetName, resourceGroup)
+	}
+
+	d.SetId(*read.ID)
+
+	return resourceArmVirtualMachineScaleSetExtensionRead(d, meta)
+}
+
+func resourceArmVirtualMachineScaleSetExtensionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmScaleSetExtensionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	scaleSetName := id.Path["virtualMachineScaleSets"]
+	name := id.Path["extensions"]
+
+	resp, err := client.Get(ctx, resourceGroup, scaleSetName, name, "")
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Extension %q was not found in Virtual Machine Scale Set %q (Resource Group %q) - removing from state", name, scaleSetName, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", name, scaleSetName, resourceGroup, err)
+	}
+
+	d.Set("name", name)
+	d.Set("resource_group_name", resourceGroup)
+	d.Set("virtual_machine_scale_set_name", scaleSetName)
+
+	if props := resp.VirtualMachineScaleSetExtensionProperties; props != nil {
+		d.Set("publisher", props.Publisher)
+		d.Set("type", props.Type)
+		d.Set("type_handler_version", props.TypeHandlerVersion)
+		d.Set("auto_upgrade_minor_version", props.AutoUpgradeMinorVersion)
+		d.Set("force_update_tag", props.ForceUpdateTag)
+
+		if settings := props.Settings; settings != nil {
+			settingsVal := settings.(map[string]interface{})
+			settingsJson, err := structure.FlattenJsonToString(settingsVal)
+			if err != nil {
+				return fmt.Errorf("unable to parse settings from response: %s", err)
+			}
+			d.Set("settings", settingsJson)
+		}
+	}
+
+	return nil
+}
+
+func resourceArmVirtualMachineScaleSetExtensionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).vmScaleSetExtensionsClient
+	ctx := meta.(*ArmClient).StopContext
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	scaleSetName := id.Path["virtualMachineScaleSets"]
+	name := id.Path["extensions"]
+
+	azureRMLockByName(scaleSetName, virtualMachineScaleSetResourceName)
+	defer azureRMUnlockByName(scaleSetName, virtualMachineScaleSetResourceName)
+
+	future, err := client.Delete(ctx, resourceGroup, scaleSetName, name)
+	if err != nil {
+		return fmt.Errorf("Error deleting Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", name, scaleSetName, resourceGroup, err)
+	}
+
+	if err = future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("Error waiting for deletion of Extension %q (Virtual Machine Scale Set %q / Resource Group %q): %+v", name, scaleSetName, resourceGroup, err)
+	}
+
+	return nil
+}
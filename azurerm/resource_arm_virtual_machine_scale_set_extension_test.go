@@ -0,0 +1,308 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAzureRMVirtualMachineScaleSetExtension_basic(t *testing.T) {
+	resourceName := "azurerm_virtual_machine_scale_set_extension.test"
+	ri := acctest.RandInt()
+	config := testAccAzureRMVirtualMachineScaleSetExtension_basic(ri, testLocation(), "hostname")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualMachineScaleSetExtensionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineScaleSetExtensionExists(resourceName),
+					resource.TestMatchResourceAttr(resourceName, "settings", regexp.MustCompile("hostname")),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"protected_settings"},
+			},
+		},
+	})
+}
+
+func TestAccAzureRMVirtualMachineScaleSetExtension_update(t *testing.T) {
+	resourceName := "azurerm_virtual_machine_scale_set_extension.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualMachineScaleSetExtensionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMVirtualMachineScaleSetExtension_basic(ri, location, "hostname"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineScaleSetExtensionExists(resourceName),
+					resource.TestMatchResourceAttr(resourceName, "settings", regexp.MustCompile("hostname")),
+				),
+			},
+			{
+				Config: testAccAzureRMVirtualMachineScaleSetExtension_basic(ri, location, "whoami"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineScaleSetExtensionExists(resourceName),
+					resource.TestMatchResourceAttr(resourceName, "settings", regexp.MustCompile("whoami")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMVirtualMachineScaleSetExtension_withInlineExtensions(t *testing.T) {
+	resourceName := "azurerm_virtual_machine_scale_set_extension.test"
+	scaleSetResourceName := "azurerm_virtual_machine_scale_set.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMVirtualMachineScaleSetExtensionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMVirtualMachineScaleSetExtension_withInlineExtensions(ri, location, true, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineScaleSetExtensionExists(resourceName),
+					resource.TestCheckResourceAttr(scaleSetResourceName, "extension.#", "1"),
+				),
+			},
+			{
+				// updating the Scale Set and removing the in-line Extension shouldn't remove the standalone Extension
+				Config: testAccAzureRMVirtualMachineScaleSetExtension_withInlineExtensions(ri, location, false, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMVirtualMachineScaleSetExtensionExists(resourceName),
+					testCheckAzureRMVirtualMachineScaleSetExtensionRemoved(scaleSetResourceName, "inline"),
+					resource.TestCheckResourceAttr(scaleSetResourceName, "extension.#", "0"),
+					resource.TestCheckResourceAttr(scaleSetResourceName, "tags.environment", "second"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMVirtualMachineScaleSetExtensionExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		// Ensure we have enough information in state to look up in API
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		name := rs.Primary.Attributes["name"]
+		scaleSetName := rs.Primary.Attributes["virtual_machine_scale_set_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).vmScaleSetExtensionsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, scaleSetName, name, "")
+		if err != nil {
+			if resp.StatusCode == http.StatusNotFound {
+				return fmt.Errorf("Bad: Extension %q (Virtual Machine Scale Set %q / Resource Group %q) does not exist", name, scaleSetName, resourceGroup)
+			}
+
+			return fmt.Errorf("Bad: Get on vmScaleSetExtensionsClient: %+v", err)
+		}
+
+		return nil
+	}
+}
+
+func testCheckAzureRMVirtualMachineScaleSetExtensionRemoved(scaleSetResourceName string, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[scaleSetResourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", scaleSetResourceName)
+		}
+
+		scaleSetName := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		client := testAccProvider.Meta().(*ArmClient).vmScaleSetExtensionsClient
+		ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+		resp, err := client.Get(ctx, resourceGroup, scaleSetName, name, "")
+		if err != nil {
+			if resp.StatusCode == http.StatusNotFound {
+				return nil
+			}
+
+			return fmt.Errorf("Bad: Get on vmScaleSetExtensionsClient: %+v", err)
+		}
+
+		return fmt.Errorf("Bad: Extension %q (Virtual Machine Scale Set %q / Resource Group %q) still exists", name, scaleSetName, resourceGroup)
+	}
+}
+
+func testCheckAzureRMVirtualMachineScaleSetExtensionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ArmClient).vmScaleSetExtensionsClient
+	ctx := testAccProvider.Meta().(*ArmClient).StopContext
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "azurerm_virtual_machine_scale_set_extension" {
+			continue
+		}
+
+		name := rs.Primary.Attributes["name"]
+		scaleSetName := rs.Primary.Attributes["virtual_machine_scale_set_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		resp, err := client.Get(ctx, resourceGroup, scaleSetName, name, "")
+		if err != nil {
+			return nil
+		}
+
+		if resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("Virtual Machine Scale Set Extension still exists:\n%#v", resp.VirtualMachineScaleSetExtensionProperties)
+		}
+	}
+
+	return nil
+}
+
+func testAccAzureRMVirtualMachineScaleSetExtension_basic(rInt int, location string, command string) string {
+	template := testAccAzureRMVirtualMachineScaleSet_basicLinux_managedDisk(rInt, location)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_virtual_machine_scale_set_extension" "test" {
+  name                           = "acctvmssext-%d"
+  resource_group_name            = "${azurerm_resource_group.test.name}"
+  virtual_machine_scale_set_name = "${azurerm_virtual_machine_scale_set.test.name}"
+  publisher                      = "Microsoft.Azure.Extensions"
+  type                           = "CustomScript"
+  type_handler_version           = "2.0"
+  auto_upgrade_minor_version     = true
+
+  settings = <<SETTINGS
+	{
+		"commandToExecute": "%s"
+	}
+SETTINGS
+}
+`, template, rInt, command)
+}
+
+func testAccAzureRMVirtualMachineScaleSetExtension_withInlineExtensions(rInt int, location string, inline bool, environment string) string {
+	inlineExtension := ""
+	if inline {
+		inlineExtension = `
+  extension {
+    name                       = "inline"
+    publisher                  = "Microsoft.OSTCExtensions"
+    type                       = "LinuxDiagnostic"
+    type_handler_version       = "2.3"
+    auto_upgrade_minor_version = true
+  }
+`
+	}
+
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctvn-%[1]d"
+  address_space       = ["10.0.0.0/16"]
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+}
+
+resource "azurerm_subnet" "test" {
+  name                 = "acctsub-%[1]d"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  virtual_network_name = "${azurerm_virtual_network.test.name}"
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurerm_virtual_machine_scale_set" "test" {
+  name                = "acctvmss-%[1]d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  upgrade_policy_mode = "Manual"
+
+  sku {
+    name     = "Standard_D1_v2"
+    tier     = "Standard"
+    capacity = 2
+  }
+
+  os_profile {
+    computer_name_prefix = "testvm-%[1]d"
+    admin_username       = "myadmin"
+    admin_password       = "Passwword1234"
+  }
+
+  network_profile {
+    name    = "TestNetworkProfile-%[1]d"
+    primary = true
+
+    ip_configuration {
+      name      = "TestIPConfiguration"
+      primary   = true
+      subnet_id = "${azurerm_subnet.test.id}"
+    }
+  }
+
+  storage_profile_os_disk {
+    name              = ""
+    caching           = "ReadWrite"
+    create_option     = "FromImage"
+    managed_disk_type = "Standard_LRS"
+  }
+
+  storage_profile_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+%[3]s
+  tags {
+    environment = "%[4]s"
+  }
+}
+
+resource "azurerm_virtual_machine_scale_set_extension" "test" {
+  name                           = "acctvmssext-%[1]d"
+  resource_group_name            = "${azurerm_resource_group.test.name}"
+  virtual_machine_scale_set_name = "${azurerm_virtual_machine_scale_set.test.name}"
+  publisher                      = "Microsoft.Azure.Extensions"
+  type                           = "CustomScript"
+  type_handler_version           = "2.0"
+  auto_upgrade_minor_version     = true
+
+  settings = <<SETTINGS
+	{
+		"commandToExecute": "hostname"
+	}
+SETTINGS
+
+  protected_settings = <<SETTINGS
+	{
+		"storageAccountName": "example"
+	}
+SETTINGS
+}
+`, rInt, location, inlineExtension, environment)
+}
@@ -247,6 +247,24 @@ func resourceArmVirtualNetworkGatewayConnectionCreateUpdate(d *schema.ResourceDa
 		VirtualNetworkGatewayConnectionPropertiesFormat: properties,
 	}
 
+	// the Shared Key of an existing connection can't be changed via CreateOrUpdate, so it's rotated in-place first
+	if !d.IsNewResource() && d.HasChange("shared_key") {
+		if v := d.Get("shared_key").(string); v != "" {
+			sharedKey := network.ConnectionSharedKey{
+				Value: utils.String(v),
+			}
+
+			keyFuture, err := client.SetSharedKey(ctx, resGroup, name, sharedKey)
+			if err != nil {
+				return fmt.Errorf("Error setting Shared Key for Virtual Network Gateway Connection %q (Resource Group %q): %+v", name, resGroup, err)
+			}
+
+			if err = keyFuture.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("Error waiting for Shared Key to be set for Virtual Network Gateway Connection %q (Resource Group %q): %+v", name, resGroup, err)
+			}
+		}
+	}
+
 	future, err := client.CreateOrUpdate(ctx, resGroup, name, connection)
 	if err != nil {
 		return fmt.Errorf("Error Creating/Updating AzureRM Virtual Network Gateway Connection %q (Resource Group %q): %+v", name, resGroup, err)
@@ -0,0 +1,207 @@
+package azurerm
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	mainStorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/suppress"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// storageAccessPolicySchema returns the schema for the Stored Access Policies (`acl`) of a Container, Share, Queue or Table,
+// where `permissions` are the permissions supported by that service in the order in which they must be specified
+func storageAccessPolicySchema(permissions string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		// the Storage API supports up to 5 Stored Access Policies per resource
+		MaxItems: 5,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringLenBetween(1, 64),
+				},
+
+				"access_policy": {
+					Type:     schema.TypeList,
+					Required: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"start": {
+								Type:             schema.TypeString,
+								Required:         true,
+								ValidateFunc:     validate.RFC3339Time,
+								DiffSuppressFunc: suppress.RFC3339Time,
+							},
+
+							"expiry": {
+								Type:             schema.TypeString,
+								Required:         true,
+								ValidateFunc:     validate.RFC3339Time,
+								DiffSuppressFunc: suppress.RFC3339Time,
+							},
+
+							"permissions": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validateStorageAccessPolicyPermissions(permissions),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateStorageAccessPolicyPermissions validates that the permissions are a subset of those supported by the
+// service, specified in the same order as the API returns them (which avoids a diff after they're read back)
+func validateStorageAccessPolicyPermissions(supported string) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) (warnings []string, errors []error) {
+		value, ok := i.(string)
+		if !ok {
+			errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+			return
+		}
+
+		if value == "" {
+			errors = append(errors, fmt.Errorf("%q must not be empty", k))
+			return
+		}
+
+		remaining := supported
+		for _, permission := range value {
+			index := strings.IndexRune(remaining, permission)
+			if index == -1 {
+				errors = append(errors, fmt.Errorf("%q must only contain the permissions %q, each at most once and in that order: got %q", k, supported, value))
+				return
+			}
+			remaining = remaining[index+1:]
+		}
+
+		return warnings, errors
+	}
+}
+
+func expandStorageAccessPolicies(input []interface{}) ([]mainStorage.SignedIdentifier, error) {
+	output := make([]mainStorage.SignedIdentifier, 0)
+
+	for _, v := range input {
+		acl := v.(map[string]interface{})
+		id := acl["id"].(string)
+
+		policies := acl["access_policy"].([]interface{})
+		if len(policies) == 0 || policies[0] == nil {
+			return nil, fmt.Errorf("An `access_policy` block must be specified for the Stored Access Policy %q", id)
+		}
+		policy := policies[0].(map[string]interface{})
+
+		start, err := time.Parse(time.RFC3339, policy["start"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing `start` for the Stored Access Policy %q: %+v", id, err)
+		}
+
+		expiry, err := time.Parse(time.RFC3339, policy["expiry"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing `expiry` for the Stored Access Policy %q: %+v", id, err)
+		}
+
+		output = append(output, mainStorage.SignedIdentifier{
+			ID: id,
+			AccessPolicy: mainStorage.AccessPolicyDetailsXML{
+				StartTime:  start.UTC(),
+				ExpiryTime: expiry.UTC(),
+				Permission: policy["permissions"].(string),
+			},
+		})
+	}
+
+	return output, nil
+}
+
+func flattenStorageAccessPolicies(input []mainStorage.SignedIdentifier) []interface{} {
+	output := make([]interface{}, 0)
+
+	for _, v := range input {
+		output = append(output, map[string]interface{}{
+			"id": v.ID,
+			"access_policy": []interface{}{
+				map[string]interface{}{
+					"start":       v.AccessPolicy.StartTime.UTC().Format(time.RFC3339),
+					"expiry":      v.AccessPolicy.ExpiryTime.UTC().Format(time.RFC3339),
+					"permissions": v.AccessPolicy.Permission,
+				},
+			},
+		})
+	}
+
+	return output
+}
+
+// getStorageAccessPolicies retrieves the Stored Access Policies from the `?comp=acl` endpoint at `uri`. This is used for
+// Containers and Shares, since the Storage SDK either doesn't expose these or only exposes a subset of the permissions
+func (c *ArmClient) getStorageAccessPolicies(ctx context.Context, resourceGroupName, storageAccountName string, supportsAzureAD bool, uri string) ([]mainStorage.SignedIdentifier, http.Header, error) {
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error building the request: %+v", err)
+	}
+
+	resp, err := c.sendStorageDataPlaneRequest(ctx, resourceGroupName, storageAccountName, supportsAzureAD, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer utils.IoCloseAndLogError(resp.Body, "Error closing the response body when retrieving the Stored Access Policies")
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("unexpected status %d (%s)", resp.StatusCode, resp.Header.Get("x-ms-error-code"))
+	}
+
+	var identifiers mainStorage.SignedIdentifiers
+	if err := xml.NewDecoder(resp.Body).Decode(&identifiers); err != nil {
+		return nil, nil, fmt.Errorf("Error decoding the Stored Access Policies: %+v", err)
+	}
+
+	return identifiers.SignedIdentifiers, resp.Header, nil
+}
+
+// setStorageAccessPolicies replaces the Stored Access Policies at the `?comp=acl` endpoint at `uri`
+func (c *ArmClient) setStorageAccessPolicies(ctx context.Context, resourceGroupName, storageAccountName string, supportsAzureAD bool, uri string, headers map[string]string, identifiers []mainStorage.SignedIdentifier) error {
+	body, err := xml.Marshal(mainStorage.SignedIdentifiers{
+		SignedIdentifiers: identifiers,
+	})
+	if err != nil {
+		return fmt.Errorf("Error serializing the Stored Access Policies: %+v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, uri, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("Error building the request: %+v", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.sendStorageDataPlaneRequest(ctx, resourceGroupName, storageAccountName, supportsAzureAD, req)
+	if err != nil {
+		return err
+	}
+	defer utils.IoCloseAndLogError(resp.Body, "Error closing the response body when setting the Stored Access Policies")
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d (%s)", resp.StatusCode, resp.Header.Get("x-ms-error-code"))
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package azurerm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateStorageAccessPolicyPermissions(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "",
+			ErrCount: 1,
+		},
+		{
+			Value:    "r",
+			ErrCount: 0,
+		},
+		{
+			Value:    "rwdl",
+			ErrCount: 0,
+		},
+		{
+			Value:    "racwdl",
+			ErrCount: 0,
+		},
+		{
+			// out of order
+			Value:    "wr",
+			ErrCount: 1,
+		},
+		{
+			// duplicated
+			Value:    "rr",
+			ErrCount: 1,
+		},
+		{
+			// unsupported
+			Value:    "rp",
+			ErrCount: 1,
+		},
+	}
+
+	validateFunc := validateStorageAccessPolicyPermissions("racwdl")
+	for _, tc := range cases {
+		_, errors := validateFunc(tc.Value, "permissions")
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d errors for %q but got %d: %+v", tc.ErrCount, tc.Value, len(errors), errors)
+		}
+	}
+}
+
+func TestExpandFlattenStorageAccessPolicies(t *testing.T) {
+	input := []interface{}{
+		map[string]interface{}{
+			"id": "first",
+			"access_policy": []interface{}{
+				map[string]interface{}{
+					"start":       "2019-07-02T09:38:21Z",
+					"expiry":      "2019-07-02T10:38:21Z",
+					"permissions": "rwd",
+				},
+			},
+		},
+		map[string]interface{}{
+			"id": "second",
+			"access_policy": []interface{}{
+				map[string]interface{}{
+					"start":       "2019-07-02T11:38:21+02:00",
+					"expiry":      "2019-07-02T12:38:21+02:00",
+					"permissions": "r",
+				},
+			},
+		},
+	}
+
+	expanded, err := expandStorageAccessPolicies(input)
+	if err != nil {
+		t.Fatalf("Error expanding the Stored Access Policies: %+v", err)
+	}
+
+	if len(expanded) != 2 {
+		t.Fatalf("Expected 2 Stored Access Policies but got %d", len(expanded))
+	}
+
+	flattened := flattenStorageAccessPolicies(expanded)
+
+	// the times are returned in UTC
+	expected := input
+	expected[1].(map[string]interface{})["access_policy"] = []interface{}{
+		map[string]interface{}{
+			"start":       "2019-07-02T09:38:21Z",
+			"expiry":      "2019-07-02T10:38:21Z",
+			"permissions": "r",
+		},
+	}
+
+	if !reflect.DeepEqual(flattened, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, flattened)
+	}
+}
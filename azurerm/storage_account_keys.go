@@ -0,0 +1,56 @@
+package azurerm
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	mainStorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// storageAuthenticationFailedSender invalidates the cached key for a Storage Account when the Storage API
+// returns an `AuthenticationFailed` error, which happens when the key has been regenerated
+type storageAuthenticationFailedSender struct {
+	sender             mainStorage.Sender
+	resourceGroupName  string
+	storageAccountName string
+}
+
+func (s storageAuthenticationFailedSender) Send(c *mainStorage.Client, req *http.Request) (*http.Response, error) {
+	resp, err := s.sender.Send(c, req)
+	if resp != nil && resp.StatusCode == http.StatusForbidden && resp.Header.Get("x-ms-error-code") == "AuthenticationFailed" {
+		log.Printf("[DEBUG] Authentication failed for Storage Account %q (Resource Group %q) - invalidating the cached key", s.storageAccountName, s.resourceGroupName)
+		invalidateKeyForStorageAccount(s.resourceGroupName, s.storageAccountName)
+	}
+	return resp, err
+}
+
+// retryOnStorageAuthenticationFailure retries the specified function once should it fail with an `AuthenticationFailed`
+// error after the cached key for the resource's Storage Account was invalidated, such that it's retried using a fresh key
+func retryOnStorageAuthenticationFailure(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		// this isn't known when importing, in which case the operation isn't retried
+		storageAccountName := d.Get("storage_account_name").(string)
+		invalidations := getKeyInvalidationsForStorageAccount(storageAccountName)
+
+		err := f(d, meta)
+		if err == nil || storageAccountName == "" || !isStorageAuthenticationFailure(err) {
+			return err
+		}
+
+		if getKeyInvalidationsForStorageAccount(storageAccountName) != invalidations {
+			log.Printf("[DEBUG] The key for Storage Account %q was invalidated during the operation - retrying with a fresh key: %+v", storageAccountName, err)
+			return f(d, meta)
+		}
+
+		return err
+	}
+}
+
+// isStorageAuthenticationFailure returns whether the error was caused by a request being rejected with a 403
+// `AuthenticationFailed` - the errors returned by the Storage SDK (and for requests sent directly to the data plane)
+// include the error code, but are wrapped by the time they're returned from the resource
+func isStorageAuthenticationFailure(err error) bool {
+	return strings.Contains(err.Error(), "AuthenticationFailed")
+}
@@ -0,0 +1,172 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	mainStorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+type testStorageSender struct {
+	resp *http.Response
+}
+
+func (s testStorageSender) Send(c *mainStorage.Client, req *http.Request) (*http.Response, error) {
+	return s.resp, nil
+}
+
+func TestStorageAuthenticationFailedSender(t *testing.T) {
+	testCases := []struct {
+		statusCode        int
+		errorCode         string
+		expectInvalidated bool
+	}{
+		{
+			statusCode:        http.StatusOK,
+			expectInvalidated: false,
+		},
+		{
+			statusCode:        http.StatusForbidden,
+			errorCode:         "AuthorizationPermissionMismatch",
+			expectInvalidated: false,
+		},
+		{
+			statusCode:        http.StatusNotFound,
+			errorCode:         "ContainerNotFound",
+			expectInvalidated: false,
+		},
+		{
+			statusCode:        http.StatusForbidden,
+			errorCode:         "AuthenticationFailed",
+			expectInvalidated: true,
+		},
+	}
+
+	for _, test := range testCases {
+		resourceGroup := "example-resources"
+		accountName := fmt.Sprintf("example%d%s", test.statusCode, test.errorCode)
+		cacheIndex := resourceGroup + "/" + accountName
+
+		storageKeyCacheMu.Lock()
+		storageKeyCache[cacheIndex] = "a2V5"
+		storageKeyCacheMu.Unlock()
+
+		resp := &http.Response{
+			StatusCode: test.statusCode,
+			Header:     http.Header{},
+		}
+		if test.errorCode != "" {
+			resp.Header.Set("x-ms-error-code", test.errorCode)
+		}
+
+		sender := storageAuthenticationFailedSender{
+			sender:             testStorageSender{resp: resp},
+			resourceGroupName:  resourceGroup,
+			storageAccountName: accountName,
+		}
+		if _, err := sender.Send(nil, nil); err != nil {
+			t.Fatalf("Unexpected error for status %d / %q: %+v", test.statusCode, test.errorCode, err)
+		}
+
+		storageKeyCacheMu.RLock()
+		_, cached := storageKeyCache[cacheIndex]
+		storageKeyCacheMu.RUnlock()
+
+		if test.expectInvalidated == cached {
+			t.Fatalf("Expected the key to be invalidated to be %t for status %d / %q", test.expectInvalidated, test.statusCode, test.errorCode)
+		}
+	}
+}
+
+func TestRetryOnStorageAuthenticationFailure(t *testing.T) {
+	testCases := []struct {
+		name             string
+		invalidate       string
+		failure          string
+		failures         int
+		expectedAttempts int
+		expectError      bool
+	}{
+		{
+			name:             "success",
+			expectedAttempts: 1,
+		},
+		{
+			name:             "authentication failure without invalidation",
+			failure:          "AuthenticationFailed",
+			failures:         1,
+			expectedAttempts: 1,
+			expectError:      true,
+		},
+		{
+			name:             "authentication failure with invalidation",
+			invalidate:       "example",
+			failure:          "AuthenticationFailed",
+			failures:         1,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "repeated authentication failure with invalidation",
+			invalidate:       "example",
+			failure:          "AuthenticationFailed",
+			failures:         2,
+			expectedAttempts: 2,
+			expectError:      true,
+		},
+		{
+			name:             "authentication failure with invalidation of another account",
+			invalidate:       "other",
+			failure:          "AuthenticationFailed",
+			failures:         1,
+			expectedAttempts: 1,
+			expectError:      true,
+		},
+		{
+			name:             "other failure with invalidation",
+			invalidate:       "example",
+			failure:          "ContainerNotFound",
+			failures:         1,
+			expectedAttempts: 1,
+			expectError:      true,
+		},
+	}
+
+	resourceSchema := map[string]*schema.Schema{
+		"storage_account_name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+
+	for _, test := range testCases {
+		d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+			"storage_account_name": "example",
+		})
+
+		attempts := 0
+		f := retryOnStorageAuthenticationFailure(func(d *schema.ResourceData, meta interface{}) error {
+			attempts++
+			if attempts > test.failures {
+				return nil
+			}
+
+			if test.invalidate != "" {
+				invalidateKeyForStorageAccount("example-resources", test.invalidate)
+			}
+			return fmt.Errorf("storage: service returned error: StatusCode=403, ErrorCode=%s", test.failure)
+		})
+
+		err := f(d, nil)
+		if test.expectError && err == nil {
+			t.Fatalf("Test case %q: expected an error but didn't get one", test.name)
+		}
+		if !test.expectError && err != nil {
+			t.Fatalf("Test case %q: unexpected error: %+v", test.name, err)
+		}
+		if attempts != test.expectedAttempts {
+			t.Fatalf("Test case %q: expected %d attempts but got %d", test.name, test.expectedAttempts, attempts)
+		}
+	}
+}
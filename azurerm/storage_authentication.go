@@ -0,0 +1,322 @@
+package azurerm
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-10-01/storage"
+	mainStorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+const (
+	// storageAzureADResource is the resource used when requesting an Azure Active Directory token for the Storage data plane
+	storageAzureADResource = "https://storage.azure.com/"
+
+	// storageAzureADAPIVersion is the first API Version of the Storage data plane which supports Azure Active Directory
+	storageAzureADAPIVersion = "2017-11-09"
+
+	// storageAzureADPlaceholderKey is used to construct a client which is authenticated using Azure Active Directory, since
+	// the client requires an Access Key - the Shared Key signature this generates is replaced by a Bearer Token before sending
+	storageAzureADPlaceholderKey = "YXp1cmUtYWN0aXZlLWRpcmVjdG9yeQ=="
+
+	// storageSASTokenLifetime is the lifetime of the Account SAS tokens used when the Access Keys can't be listed
+	storageSASTokenLifetime = 4 * time.Hour
+)
+
+// errStorageAccountKeysForbidden is returned when the Access Keys for a Storage Account can't be listed
+var errStorageAccountKeysForbidden = errors.New("Unable to list the Access Keys for the Storage Account")
+
+var (
+	storageSASCacheMu sync.Mutex
+	storageSASCache   = make(map[string]storageSASToken)
+)
+
+type storageSASToken struct {
+	token   string
+	expires time.Time
+}
+
+// storageBearerTokenSender authenticates requests to the Storage data plane using an Azure Active Directory token
+type storageBearerTokenSender struct {
+	sender     mainStorage.Sender
+	authorizer autorest.Authorizer
+}
+
+func (s storageBearerTokenSender) Send(c *mainStorage.Client, req *http.Request) (*http.Response, error) {
+	req.Header.Del("Authorization")
+
+	req, err := autorest.Prepare(req, s.authorizer.WithAuthorization())
+	if err != nil {
+		return nil, fmt.Errorf("Error authenticating the request using Azure Active Directory: %+v", err)
+	}
+
+	return s.sender.Send(c, req)
+}
+
+func (c *ArmClient) getStorageAuthorizer() (autorest.Authorizer, error) {
+	c.storageAuthorizerMu.Lock()
+	defer c.storageAuthorizerMu.Unlock()
+
+	if c.storageAuthorizer == nil {
+		if c.storageAuthorizerFunc == nil {
+			return nil, fmt.Errorf("Azure Active Directory authentication isn't configured for the Storage data plane")
+		}
+
+		authorizer, err := c.storageAuthorizerFunc()
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving an Azure Active Directory token for Storage: %+v", err)
+		}
+
+		c.storageAuthorizer = authorizer
+	}
+
+	return c.storageAuthorizer, nil
+}
+
+func (c *ArmClient) getAzureADStorageClientForStorageAccount(ctx context.Context, resourceGroupName, storageAccountName string) (*mainStorage.Client, bool, error) {
+	account, err := c.storageServiceClient.GetProperties(ctx, resourceGroupName, storageAccountName)
+	if err != nil {
+		if utils.ResponseWasNotFound(account.Response) {
+			return nil, false, nil
+		}
+
+		return nil, true, fmt.Errorf("Error retrieving Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroupName, err)
+	}
+
+	authorizer, err := c.getStorageAuthorizer()
+	if err != nil {
+		return nil, true, err
+	}
+
+	storageClient, err := mainStorage.NewClient(storageAccountName, storageAzureADPlaceholderKey, c.environment.StorageEndpointSuffix,
+		storageAzureADAPIVersion, true)
+	if err != nil {
+		return nil, true, fmt.Errorf("Error creating storage client for storage storeAccount %q: %s", storageAccountName, err)
+	}
+
+	storageClient.Sender = storageBearerTokenSender{
+		sender:     storageClient.Sender,
+		authorizer: authorizer,
+	}
+
+	return &storageClient, true, nil
+}
+
+// sendStorageDataPlaneRequest sends a request to the Storage data plane for operations which aren't available in the
+// Storage SDK. When `supportsAzureAD` is true (the Blob and Queue services) and `storage_use_azuread` is enabled an Azure
+// Active Directory token is used, otherwise the request is signed using an Access Key - falling back to an Account SAS
+// when the Access Keys can't be listed
+func (c *ArmClient) sendStorageDataPlaneRequest(ctx context.Context, resourceGroupName, storageAccountName string, supportsAzureAD bool, req *http.Request) (*http.Response, error) {
+	// some operations (such as configuring a Static Website) require a newer API Version, which is set by the caller
+	if req.Header.Get("x-ms-version") == "" {
+		req.Header.Set("x-ms-version", storageAzureADAPIVersion)
+	}
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+
+	if supportsAzureAD && c.storageUseAzureAD {
+		authorizer, err := c.getStorageAuthorizer()
+		if err != nil {
+			return nil, err
+		}
+
+		req, err = autorest.Prepare(req, authorizer.WithAuthorization())
+		if err != nil {
+			return nil, fmt.Errorf("Error authenticating the request using Azure Active Directory: %+v", err)
+		}
+
+		return c.doStorageDataPlaneRequest(ctx, req)
+	}
+
+	key, accountExists, err := c.getKeyForStorageAccount(ctx, resourceGroupName, storageAccountName)
+	if err == errStorageAccountKeysForbidden {
+		log.Printf("[DEBUG] Unable to list the Access Keys for Storage Account %q (Resource Group %q) - falling back to an Account SAS", storageAccountName, resourceGroupName)
+		token, accountExists, err := c.getSASTokenForStorageAccount(ctx, resourceGroupName, storageAccountName)
+		if err != nil {
+			return nil, err
+		}
+		if !accountExists {
+			return nil, fmt.Errorf("Storage Account %q (Resource Group %q) was not found", storageAccountName, resourceGroupName)
+		}
+
+		if req.URL.RawQuery == "" {
+			req.URL.RawQuery = token
+		} else {
+			req.URL.RawQuery = req.URL.RawQuery + "&" + token
+		}
+
+		return c.doStorageDataPlaneRequest(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !accountExists {
+		return nil, fmt.Errorf("Storage Account %q (Resource Group %q) was not found", storageAccountName, resourceGroupName)
+	}
+
+	if err := signStorageSharedKeyRequest(req, storageAccountName, key); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doStorageDataPlaneRequest(ctx, req)
+	if resp != nil && resp.StatusCode == http.StatusForbidden && resp.Header.Get("x-ms-error-code") == "AuthenticationFailed" {
+		log.Printf("[DEBUG] Authentication failed for Storage Account %q (Resource Group %q) - invalidating the cached key", storageAccountName, resourceGroupName)
+		invalidateKeyForStorageAccount(resourceGroupName, storageAccountName)
+	}
+	return resp, err
+}
+
+// doStorageDataPlaneRequest sends an authenticated request to the Storage data plane using the provider's Sender,
+// such that it's logged, uses the provider's User Agent and is retried when it fails with a transient error
+func (c *ArmClient) doStorageDataPlaneRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
+	client := c.storageDataPlaneClient
+	return autorest.SendWithSender(client, req.WithContext(ctx),
+		autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+// signStorageSharedKeyRequest signs a request to the Blob, File or Queue data planes using the Shared Key scheme
+// - see https://docs.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func signStorageSharedKeyRequest(req *http.Request, storageAccountName, storageAccountKey string) error {
+	key, err := base64.StdEncoding.DecodeString(storageAccountKey)
+	if err != nil {
+		return fmt.Errorf("Error decoding the Access Key for Storage Account %q: %+v", storageAccountName, err)
+	}
+
+	headers := make(map[string]string)
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.Join(v, ",")
+	}
+	headers["content-length"] = ""
+	if req.ContentLength > 0 {
+		headers["content-length"] = strconv.FormatInt(req.ContentLength, 10)
+	}
+	if _, ok := headers["x-ms-date"]; ok {
+		headers["date"] = ""
+	}
+
+	canonicalizedHeaders := make([]string, 0)
+	for k, v := range headers {
+		if strings.HasPrefix(k, "x-ms-") {
+			canonicalizedHeaders = append(canonicalizedHeaders, fmt.Sprintf("%s:%s", k, strings.TrimSpace(v)))
+		}
+	}
+	sort.Strings(canonicalizedHeaders)
+
+	params := make(map[string][]string)
+	for k, v := range req.URL.Query() {
+		params[strings.ToLower(k)] = append(params[strings.ToLower(k)], v...)
+	}
+	paramKeys := make([]string, 0)
+	for k := range params {
+		paramKeys = append(paramKeys, k)
+	}
+	sort.Strings(paramKeys)
+
+	canonicalizedResource := fmt.Sprintf("/%s%s", storageAccountName, req.URL.EscapedPath())
+	for _, k := range paramKeys {
+		values := params[k]
+		sort.Strings(values)
+		canonicalizedResource += fmt.Sprintf("\n%s:%s", k, strings.Join(values, ","))
+	}
+
+	stringToSign := strings.Join([]string{
+		req.Method,
+		headers["content-encoding"],
+		headers["content-language"],
+		headers["content-length"],
+		headers["content-md5"],
+		headers["content-type"],
+		headers["date"],
+		headers["if-modified-since"],
+		headers["if-match"],
+		headers["if-none-match"],
+		headers["if-unmodified-since"],
+		headers["range"],
+		strings.Join(canonicalizedHeaders, "\n"),
+		canonicalizedResource,
+	}, "\n")
+
+	hash := hmac.New(sha256.New, key)
+	if _, err := hash.Write([]byte(stringToSign)); err != nil {
+		return fmt.Errorf("Error signing the request for Storage Account %q: %+v", storageAccountName, err)
+	}
+
+	signature := base64.StdEncoding.EncodeToString(hash.Sum(nil))
+	req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", storageAccountName, signature))
+	return nil
+}
+
+func (c *ArmClient) getSASStorageClientForStorageAccount(ctx context.Context, resourceGroupName, storageAccountName string) (*mainStorage.Client, bool, error) {
+	token, accountExists, err := c.getSASTokenForStorageAccount(ctx, resourceGroupName, storageAccountName)
+	if err != nil || !accountExists {
+		return nil, accountExists, err
+	}
+
+	values, err := url.ParseQuery(token)
+	if err != nil {
+		return nil, true, fmt.Errorf("Error parsing the Account SAS for Storage Account %q: %+v", storageAccountName, err)
+	}
+
+	storageClient := mainStorage.NewAccountSASClient(storageAccountName, values, c.environment)
+	return &storageClient, true, nil
+}
+
+// getSASTokenForStorageAccount returns an Account SAS (generated by the Resource Manager API, which doesn't require
+// the Access Keys to be listed) for the Storage Account - which is cached until it's close to expiring
+func (c *ArmClient) getSASTokenForStorageAccount(ctx context.Context, resourceGroupName, storageAccountName string) (string, bool, error) {
+	cacheIndex := resourceGroupName + "/" + storageAccountName
+
+	storageSASCacheMu.Lock()
+	defer storageSASCacheMu.Unlock()
+
+	if v, ok := storageSASCache[cacheIndex]; ok && time.Now().Add(30*time.Minute).Before(v.expires) {
+		return v.token, true, nil
+	}
+
+	now := time.Now().UTC()
+	expires := now.Add(storageSASTokenLifetime)
+	parameters := storage.AccountSasParameters{
+		Services:      storage.Services("bfqt"),
+		ResourceTypes: storage.SignedResourceTypes("sco"),
+		Permissions:   storage.Permissions("rwdlacup"),
+		Protocols:     storage.HTTPS,
+		// allow for clock skew between the API and the Storage Account
+		SharedAccessStartTime:  &date.Time{Time: now.Add(-15 * time.Minute)},
+		SharedAccessExpiryTime: &date.Time{Time: expires},
+	}
+
+	resp, err := c.storageServiceClient.ListAccountSAS(ctx, resourceGroupName, storageAccountName, parameters)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return "", false, nil
+		}
+
+		return "", true, fmt.Errorf("Error listing an Account SAS for Storage Account %q (Resource Group %q): %+v", storageAccountName, resourceGroupName, err)
+	}
+
+	if resp.AccountSasToken == nil {
+		return "", true, fmt.Errorf("Error listing an Account SAS for Storage Account %q (Resource Group %q): `accountSasToken` was nil", storageAccountName, resourceGroupName)
+	}
+
+	log.Printf("[DEBUG] Caching the Account SAS for Storage Account %q (Resource Group %q) until %s", storageAccountName, resourceGroupName, expires.Format(time.RFC3339))
+	storageSASCache[cacheIndex] = storageSASToken{
+		token:   *resp.AccountSasToken,
+		expires: expires,
+	}
+
+	return *resp.AccountSasToken, true, nil
+}
@@ -0,0 +1,142 @@
+package azurerm
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	mainStorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/Azure/go-autorest/autorest"
+)
+
+type testStorageAuthorizer struct{}
+
+func (testStorageAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return autorest.WithHeader("Authorization", "Bearer test-token")
+}
+
+type testStorageRecordingSender struct {
+	request *http.Request
+}
+
+func (s *testStorageRecordingSender) Send(c *mainStorage.Client, req *http.Request) (*http.Response, error) {
+	s.request = req
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte{})),
+	}, nil
+}
+
+func TestStorageBearerTokenSender(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://example.blob.core.windows.net/container?restype=container", nil)
+	if err != nil {
+		t.Fatalf("Error creating request: %+v", err)
+	}
+	req.Header.Set("Authorization", "SharedKey example:signature")
+	req.Header.Set("x-ms-version", storageAzureADAPIVersion)
+
+	recorder := &testStorageRecordingSender{}
+	sender := storageBearerTokenSender{
+		sender:     recorder,
+		authorizer: testStorageAuthorizer{},
+	}
+
+	if _, err := sender.Send(nil, req); err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+
+	if recorder.request == nil {
+		t.Fatalf("Expected the request to be sent but it wasn't")
+	}
+
+	if values := recorder.request.Header["Authorization"]; len(values) != 1 || values[0] != "Bearer test-token" {
+		t.Fatalf("Expected the Authorization header to be replaced with a Bearer Token but got %+v", values)
+	}
+
+	if v := recorder.request.Header.Get("x-ms-version"); v != storageAzureADAPIVersion {
+		t.Fatalf("Expected the x-ms-version header to be %q but got %q", storageAzureADAPIVersion, v)
+	}
+}
+
+func TestSignStorageSharedKeyRequest(t *testing.T) {
+	accountName := "example"
+	accountKey := "dGVzdC1hY2Nlc3Mta2V5LWZvci1zaWduaW5nLXJlcXVlc3Rz"
+
+	testCases := []struct {
+		name string
+		send func(client mainStorage.Client) error
+	}{
+		{
+			name: "Set Container ACL",
+			send: func(client mainStorage.Client) error {
+				blobClient := client.GetBlobService()
+				container := blobClient.GetContainerReference("example")
+				permissions := mainStorage.ContainerPermissions{
+					AccessType: mainStorage.ContainerAccessTypeBlob,
+					AccessPolicies: []mainStorage.ContainerAccessPolicy{
+						{
+							ID:         "policy",
+							StartTime:  time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+							ExpiryTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+							CanRead:    true,
+						},
+					},
+				}
+				return container.SetPermissions(permissions, nil)
+			},
+		},
+		{
+			name: "Set Share Metadata",
+			send: func(client mainStorage.Client) error {
+				fileClient := client.GetFileService()
+				share := fileClient.GetShareReference("example")
+				share.Metadata = map[string]string{
+					"hello": "world",
+				}
+				return share.SetMetadata(nil)
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		client, err := mainStorage.NewBasicClient(accountName, accountKey)
+		if err != nil {
+			t.Fatalf("Error creating client: %+v", err)
+		}
+
+		recorder := &testStorageRecordingSender{}
+		client.Sender = recorder
+		if err := test.send(client); err != nil {
+			t.Fatalf("Unexpected error for %q: %+v", test.name, err)
+		}
+
+		expected := recorder.request.Header.Get("Authorization")
+		body := make([]byte, 0)
+		if recorder.request.Body != nil {
+			if body, err = ioutil.ReadAll(recorder.request.Body); err != nil {
+				t.Fatalf("Error reading the body for %q: %+v", test.name, err)
+			}
+		}
+
+		req, err := http.NewRequest(recorder.request.Method, recorder.request.URL.String(), bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("Error creating request for %q: %+v", test.name, err)
+		}
+		for k, v := range recorder.request.Header {
+			if k != "Authorization" {
+				req.Header[k] = v
+			}
+		}
+
+		if err := signStorageSharedKeyRequest(req, accountName, accountKey); err != nil {
+			t.Fatalf("Error signing the request for %q: %+v", test.name, err)
+		}
+
+		if actual := req.Header.Get("Authorization"); actual != expected {
+			t.Fatalf("Expected the Authorization header for %q to be %q but got %q", test.name, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,494 @@
+package azurerm
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	mainStorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+const (
+	// storageAnalyticsVersion is the only version of Storage Analytics Logging and Metrics
+	storageAnalyticsVersion = "1.0"
+
+	// storageStaticWebsiteAPIVersion is the first API Version of the Storage data plane which supports Static Websites
+	storageStaticWebsiteAPIVersion = "2018-03-28"
+
+	// storageWebEndpointAPIVersion is the first API Version of the Resource Manager API which returns the Web Endpoints
+	storageWebEndpointAPIVersion = "2018-07-01"
+)
+
+// storageServicePropertiesSchema returns the schema for the properties of the Blob, Queue or Table service
+// of a Storage Account (`blob_properties`, `queue_properties` and `table_properties`)
+func storageServicePropertiesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cors_rule": {
+					Type:     schema.TypeList,
+					Optional: true,
+					// the Storage API supports up to 5 CORS rules per service
+					MaxItems: 5,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"allowed_origins": {
+								Type:     schema.TypeList,
+								Required: true,
+								MaxItems: 64,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validate.NoEmptyStrings,
+								},
+							},
+
+							"allowed_methods": {
+								Type:     schema.TypeList,
+								Required: true,
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+									ValidateFunc: validation.StringInSlice([]string{
+										"DELETE",
+										"GET",
+										"HEAD",
+										"MERGE",
+										"POST",
+										"OPTIONS",
+										"PUT",
+									}, false),
+								},
+							},
+
+							"allowed_headers": {
+								Type:     schema.TypeList,
+								Required: true,
+								MaxItems: 64,
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+							},
+
+							"exposed_headers": {
+								Type:     schema.TypeList,
+								Required: true,
+								MaxItems: 64,
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+							},
+
+							"max_age_in_seconds": {
+								Type:         schema.TypeInt,
+								Required:     true,
+								ValidateFunc: validation.IntBetween(1, 2000000000),
+							},
+						},
+					},
+				},
+
+				"logging": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"delete": {
+								Type:     schema.TypeBool,
+								Required: true,
+							},
+
+							"read": {
+								Type:     schema.TypeBool,
+								Required: true,
+							},
+
+							"write": {
+								Type:     schema.TypeBool,
+								Required: true,
+							},
+
+							"retention_policy_days": storageServiceRetentionPolicyDaysSchema(),
+						},
+					},
+				},
+
+				"hour_metrics": storageServiceMetricsSchema(),
+
+				"minute_metrics": storageServiceMetricsSchema(),
+			},
+		},
+	}
+}
+
+func storageServiceMetricsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enabled": {
+					Type:     schema.TypeBool,
+					Required: true,
+				},
+
+				"include_apis": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+
+				"retention_policy_days": storageServiceRetentionPolicyDaysSchema(),
+			},
+		},
+	}
+}
+
+func storageServiceRetentionPolicyDaysSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntBetween(1, 365),
+	}
+}
+
+// expandStorageServiceProperties returns the properties of a service - where an empty `input` (i.e. the block has been
+// removed) disables Logging & Metrics and removes all of the CORS rules
+func expandStorageServiceProperties(input []interface{}) mainStorage.ServiceProperties {
+	properties := map[string]interface{}{}
+	if len(input) > 0 && input[0] != nil {
+		properties = input[0].(map[string]interface{})
+	}
+
+	output := mainStorage.ServiceProperties{
+		Logging: &mainStorage.Logging{
+			Version:         storageAnalyticsVersion,
+			RetentionPolicy: expandStorageServiceRetentionPolicy(0),
+		},
+		HourMetrics:   expandStorageServiceMetrics(properties["hour_metrics"]),
+		MinuteMetrics: expandStorageServiceMetrics(properties["minute_metrics"]),
+		Cors: &mainStorage.Cors{
+			CorsRule: make([]mainStorage.CorsRule, 0),
+		},
+	}
+
+	if v, ok := properties["logging"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		logging := v[0].(map[string]interface{})
+		output.Logging.Delete = logging["delete"].(bool)
+		output.Logging.Read = logging["read"].(bool)
+		output.Logging.Write = logging["write"].(bool)
+		output.Logging.RetentionPolicy = expandStorageServiceRetentionPolicy(logging["retention_policy_days"].(int))
+	}
+
+	if v, ok := properties["cors_rule"].([]interface{}); ok {
+		for _, r := range v {
+			rule := r.(map[string]interface{})
+			output.Cors.CorsRule = append(output.Cors.CorsRule, mainStorage.CorsRule{
+				AllowedOrigins:  expandStorageServiceCorsRuleValues(rule["allowed_origins"].([]interface{})),
+				AllowedMethods:  expandStorageServiceCorsRuleValues(rule["allowed_methods"].([]interface{})),
+				AllowedHeaders:  expandStorageServiceCorsRuleValues(rule["allowed_headers"].([]interface{})),
+				ExposedHeaders:  expandStorageServiceCorsRuleValues(rule["exposed_headers"].([]interface{})),
+				MaxAgeInSeconds: rule["max_age_in_seconds"].(int),
+			})
+		}
+	}
+
+	return output
+}
+
+func expandStorageServiceMetrics(input interface{}) *mainStorage.Metrics {
+	output := mainStorage.Metrics{
+		Version:         storageAnalyticsVersion,
+		RetentionPolicy: expandStorageServiceRetentionPolicy(0),
+	}
+
+	v, ok := input.([]interface{})
+	if !ok || len(v) == 0 || v[0] == nil {
+		return &output
+	}
+
+	metrics := v[0].(map[string]interface{})
+	output.Enabled = metrics["enabled"].(bool)
+	output.RetentionPolicy = expandStorageServiceRetentionPolicy(metrics["retention_policy_days"].(int))
+
+	// the API rejects `IncludeAPIs` unless the Metrics are enabled
+	if output.Enabled {
+		output.IncludeAPIs = utils.Bool(metrics["include_apis"].(bool))
+	}
+
+	return &output
+}
+
+// expandStorageServiceRetentionPolicy returns a Retention Policy for the specified number of days, where 0 disables it
+func expandStorageServiceRetentionPolicy(days int) *mainStorage.RetentionPolicy {
+	if days == 0 {
+		return &mainStorage.RetentionPolicy{
+			Enabled: false,
+		}
+	}
+
+	return &mainStorage.RetentionPolicy{
+		Enabled: true,
+		Days:    &days,
+	}
+}
+
+func flattenStorageServiceProperties(input *mainStorage.ServiceProperties) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	corsRules := make([]interface{}, 0)
+	if cors := input.Cors; cors != nil {
+		for _, rule := range cors.CorsRule {
+			corsRules = append(corsRules, map[string]interface{}{
+				"allowed_origins":    flattenStorageServiceCorsRuleValues(rule.AllowedOrigins),
+				"allowed_methods":    flattenStorageServiceCorsRuleValues(rule.AllowedMethods),
+				"allowed_headers":    flattenStorageServiceCorsRuleValues(rule.AllowedHeaders),
+				"exposed_headers":    flattenStorageServiceCorsRuleValues(rule.ExposedHeaders),
+				"max_age_in_seconds": rule.MaxAgeInSeconds,
+			})
+		}
+	}
+
+	// Logging is only output when it's been configured, since nothing being logged is the default
+	logging := make([]interface{}, 0)
+	if v := input.Logging; v != nil && (v.Delete || v.Read || v.Write || flattenStorageServiceRetentionPolicy(v.RetentionPolicy) > 0) {
+		logging = append(logging, map[string]interface{}{
+			"delete":                v.Delete,
+			"read":                  v.Read,
+			"write":                 v.Write,
+			"retention_policy_days": flattenStorageServiceRetentionPolicy(v.RetentionPolicy),
+		})
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"cors_rule":      corsRules,
+			"logging":        logging,
+			"hour_metrics":   flattenStorageServiceMetrics(input.HourMetrics),
+			"minute_metrics": flattenStorageServiceMetrics(input.MinuteMetrics),
+		},
+	}
+}
+
+func flattenStorageServiceMetrics(input *mainStorage.Metrics) []interface{} {
+	// Metrics are only output when they've been configured, since disabled is the default
+	if input == nil || (!input.Enabled && flattenStorageServiceRetentionPolicy(input.RetentionPolicy) == 0) {
+		return []interface{}{}
+	}
+
+	includeAPIs := false
+	if input.IncludeAPIs != nil {
+		includeAPIs = *input.IncludeAPIs
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"enabled":               input.Enabled,
+			"include_apis":          includeAPIs,
+			"retention_policy_days": flattenStorageServiceRetentionPolicy(input.RetentionPolicy),
+		},
+	}
+}
+
+func flattenStorageServiceRetentionPolicy(input *mainStorage.RetentionPolicy) int {
+	if input == nil || !input.Enabled || input.Days == nil {
+		return 0
+	}
+
+	return *input.Days
+}
+
+// expandStorageServiceCorsRuleValues returns the values of a CORS rule as the comma separated list used by the API
+func expandStorageServiceCorsRuleValues(input []interface{}) string {
+	values := make([]string, 0)
+	for _, v := range input {
+		values = append(values, v.(string))
+	}
+	return strings.Join(values, ",")
+}
+
+func flattenStorageServiceCorsRuleValues(input string) []interface{} {
+	output := make([]interface{}, 0)
+	for _, v := range strings.Split(input, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			output = append(output, v)
+		}
+	}
+	return output
+}
+
+// storageStaticWebsiteServiceProperties is the subset of the Blob service properties which configures the Static
+// Website, which isn't available in the Storage SDK. Elements which are omitted when setting the service properties
+// retain their existing values, so this can be used alongside the Storage SDK's `SetServiceProperties`
+type storageStaticWebsiteServiceProperties struct {
+	XMLName       xml.Name              `xml:"StorageServiceProperties"`
+	StaticWebsite *storageStaticWebsite `xml:"StaticWebsite"`
+}
+
+type storageStaticWebsite struct {
+	Enabled              bool   `xml:"Enabled"`
+	IndexDocument        string `xml:"IndexDocument,omitempty"`
+	ErrorDocument404Path string `xml:"ErrorDocument404Path,omitempty"`
+}
+
+func expandStorageStaticWebsite(input []interface{}) storageStaticWebsite {
+	if len(input) == 0 {
+		return storageStaticWebsite{
+			Enabled: false,
+		}
+	}
+
+	output := storageStaticWebsite{
+		Enabled: true,
+	}
+
+	// an empty `static_website` block enables the Static Website without any documents
+	if input[0] != nil {
+		website := input[0].(map[string]interface{})
+		output.IndexDocument = website["index_document"].(string)
+		output.ErrorDocument404Path = website["error_404_document"].(string)
+	}
+
+	return output
+}
+
+func flattenStorageStaticWebsite(input *storageStaticWebsite) []interface{} {
+	if input == nil || !input.Enabled {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"index_document":     input.IndexDocument,
+			"error_404_document": input.ErrorDocument404Path,
+		},
+	}
+}
+
+func storageStaticWebsiteURI(storageAccountName string, environment azure.Environment) string {
+	return fmt.Sprintf("https://%s.blob.%s/?restype=service&comp=properties", storageAccountName, environment.StorageEndpointSuffix)
+}
+
+// getStorageStaticWebsite retrieves the Static Website configuration from the Blob service properties
+func (c *ArmClient) getStorageStaticWebsite(ctx context.Context, resourceGroupName, storageAccountName string) (*storageStaticWebsite, error) {
+	req, err := http.NewRequest(http.MethodGet, storageStaticWebsiteURI(storageAccountName, c.environment), nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error building the request: %+v", err)
+	}
+	req.Header.Set("x-ms-version", storageStaticWebsiteAPIVersion)
+
+	// the service properties can only be managed by the account owner, so this uses the Access Key rather than Azure AD
+	resp, err := c.sendStorageDataPlaneRequest(ctx, resourceGroupName, storageAccountName, false, req)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.IoCloseAndLogError(resp.Body, "Error closing the response body when retrieving the Static Website")
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d (%s)", resp.StatusCode, resp.Header.Get("x-ms-error-code"))
+	}
+
+	var properties storageStaticWebsiteServiceProperties
+	if err := xml.NewDecoder(resp.Body).Decode(&properties); err != nil {
+		return nil, fmt.Errorf("Error decoding the Blob service properties: %+v", err)
+	}
+
+	return properties.StaticWebsite, nil
+}
+
+// setStorageStaticWebsite updates the Static Website configuration within the Blob service properties
+func (c *ArmClient) setStorageStaticWebsite(ctx context.Context, resourceGroupName, storageAccountName string, website storageStaticWebsite) error {
+	body, err := xml.Marshal(storageStaticWebsiteServiceProperties{
+		StaticWebsite: &website,
+	})
+	if err != nil {
+		return fmt.Errorf("Error serializing the Static Website: %+v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, storageStaticWebsiteURI(storageAccountName, c.environment), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("Error building the request: %+v", err)
+	}
+	req.Header.Set("x-ms-version", storageStaticWebsiteAPIVersion)
+
+	resp, err := c.sendStorageDataPlaneRequest(ctx, resourceGroupName, storageAccountName, false, req)
+	if err != nil {
+		return err
+	}
+	defer utils.IoCloseAndLogError(resp.Body, "Error closing the response body when setting the Static Website")
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("unexpected status %d (%s)", resp.StatusCode, resp.Header.Get("x-ms-error-code"))
+	}
+
+	return nil
+}
+
+type storageAccountWebEndpoints struct {
+	Properties *struct {
+		PrimaryEndpoints *struct {
+			Web *string `json:"web,omitempty"`
+		} `json:"primaryEndpoints,omitempty"`
+	} `json:"properties,omitempty"`
+}
+
+// getStorageAccountPrimaryWebEndpoint retrieves the Primary Web Endpoint of the Storage Account, which isn't returned
+// by the API Version used by the Storage SDK - and as such is retrieved using a newer API Version
+func (c *ArmClient) getStorageAccountPrimaryWebEndpoint(ctx context.Context, resourceGroupName, storageAccountName string) (string, error) {
+	client := c.storageServiceClient
+
+	pathParameters := map[string]interface{}{
+		"accountName":       autorest.Encode("path", storageAccountName),
+		"resourceGroupName": autorest.Encode("path", resourceGroupName),
+		"subscriptionId":    autorest.Encode("path", client.SubscriptionID),
+	}
+	queryParameters := map[string]interface{}{
+		"api-version": storageWebEndpointAPIVersion,
+	}
+
+	req, err := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Storage/storageAccounts/{accountName}", pathParameters),
+		autorest.WithQueryParameters(queryParameters)).Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("Error preparing the request: %+v", err)
+	}
+
+	resp, err := autorest.SendWithSender(client, req, azure.DoRetryWithRegistration(client.Client))
+	if err != nil {
+		return "", fmt.Errorf("Error sending the request: %+v", err)
+	}
+
+	var account storageAccountWebEndpoints
+	err = autorest.Respond(
+		resp,
+		client.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&account),
+		autorest.ByClosing())
+	if err != nil {
+		return "", err
+	}
+
+	if props := account.Properties; props != nil {
+		if endpoints := props.PrimaryEndpoints; endpoints != nil && endpoints.Web != nil {
+			return *endpoints.Web, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,134 @@
+package azurerm
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestExpandStorageServiceProperties(t *testing.T) {
+	input := []interface{}{
+		map[string]interface{}{
+			"cors_rule": []interface{}{
+				map[string]interface{}{
+					"allowed_origins":    []interface{}{"https://example.com"},
+					"allowed_methods":    []interface{}{"GET", "HEAD"},
+					"allowed_headers":    []interface{}{"*"},
+					"exposed_headers":    []interface{}{"x-ms-meta-*"},
+					"max_age_in_seconds": 3600,
+				},
+			},
+			"logging": []interface{}{
+				map[string]interface{}{
+					"delete":                true,
+					"read":                  false,
+					"write":                 true,
+					"retention_policy_days": 10,
+				},
+			},
+			"hour_metrics": []interface{}{
+				map[string]interface{}{
+					"enabled":               true,
+					"include_apis":          true,
+					"retention_policy_days": 7,
+				},
+			},
+			"minute_metrics": []interface{}{
+				map[string]interface{}{
+					"enabled":               false,
+					"include_apis":          true,
+					"retention_policy_days": 0,
+				},
+			},
+		},
+	}
+
+	properties := expandStorageServiceProperties(input)
+
+	if len(properties.Cors.CorsRule) != 1 || properties.Cors.CorsRule[0].AllowedMethods != "GET,HEAD" {
+		t.Fatalf("Expected a single CORS rule allowing `GET,HEAD` but got %+v", properties.Cors.CorsRule)
+	}
+	if properties.MinuteMetrics.IncludeAPIs != nil {
+		t.Fatalf("Expected `IncludeAPIs` to be omitted when the Minute Metrics are disabled")
+	}
+	if properties.MinuteMetrics.RetentionPolicy.Enabled {
+		t.Fatalf("Expected the Minute Metrics Retention Policy to be disabled")
+	}
+
+	// the configuration should round-trip, other than the disabled Minute Metrics which aren't output
+	expected := input[0].(map[string]interface{})
+	expected["minute_metrics"] = []interface{}{}
+
+	actual := flattenStorageServiceProperties(&properties)
+	if !reflect.DeepEqual(actual, input) {
+		t.Fatalf("Expected %+v but got %+v", input, actual)
+	}
+}
+
+func TestExpandStorageServicePropertiesRemoved(t *testing.T) {
+	properties := expandStorageServiceProperties([]interface{}{})
+
+	if properties.Logging.Delete || properties.Logging.Read || properties.Logging.Write || properties.Logging.RetentionPolicy.Enabled {
+		t.Fatalf("Expected Logging to be disabled but got %+v", properties.Logging)
+	}
+	if properties.HourMetrics.Enabled || properties.MinuteMetrics.Enabled {
+		t.Fatalf("Expected the Metrics to be disabled")
+	}
+	if properties.Cors == nil || len(properties.Cors.CorsRule) != 0 {
+		t.Fatalf("Expected the CORS rules to be removed but got %+v", properties.Cors)
+	}
+
+	actual := flattenStorageServiceProperties(&properties)[0].(map[string]interface{})
+	for _, key := range []string{"cors_rule", "logging", "hour_metrics", "minute_metrics"} {
+		if v := actual[key].([]interface{}); len(v) != 0 {
+			t.Fatalf("Expected %q to be empty but got %+v", key, v)
+		}
+	}
+}
+
+func TestStorageStaticWebsiteServiceProperties(t *testing.T) {
+	cases := []struct {
+		Input    []interface{}
+		Expected string
+	}{
+		{
+			Input:    []interface{}{},
+			Expected: "<StorageServiceProperties><StaticWebsite><Enabled>false</Enabled></StaticWebsite></StorageServiceProperties>",
+		},
+		{
+			Input:    []interface{}{nil},
+			Expected: "<StorageServiceProperties><StaticWebsite><Enabled>true</Enabled></StaticWebsite></StorageServiceProperties>",
+		},
+		{
+			Input: []interface{}{
+				map[string]interface{}{
+					"index_document":     "index.html",
+					"error_404_document": "404.html",
+				},
+			},
+			Expected: "<StorageServiceProperties><StaticWebsite><Enabled>true</Enabled><IndexDocument>index.html</IndexDocument><ErrorDocument404Path>404.html</ErrorDocument404Path></StaticWebsite></StorageServiceProperties>",
+		},
+	}
+
+	for _, v := range cases {
+		website := expandStorageStaticWebsite(v.Input)
+		body, err := xml.Marshal(storageStaticWebsiteServiceProperties{
+			StaticWebsite: &website,
+		})
+		if err != nil {
+			t.Fatalf("Error serializing %+v: %+v", v.Input, err)
+		}
+
+		if string(body) != v.Expected {
+			t.Fatalf("Expected %q but got %q", v.Expected, string(body))
+		}
+
+		var properties storageStaticWebsiteServiceProperties
+		if err := xml.Unmarshal(body, &properties); err != nil {
+			t.Fatalf("Error deserializing %q: %+v", string(body), err)
+		}
+		if !reflect.DeepEqual(*properties.StaticWebsite, website) {
+			t.Fatalf("Expected %+v but got %+v", website, *properties.StaticWebsite)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package azurerm
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	mainStorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/hashicorp/go-azure-helpers/storage"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+)
+
+// storageServiceSASPermissions maps the `permissions` fields of the Service SAS data sources to the characters used in the SAS
+var storageServiceSASPermissions = map[string]string{
+	"read":   "r",
+	"add":    "a",
+	"create": "c",
+	"write":  "w",
+	"delete": "d",
+	"list":   "l",
+}
+
+// storageServiceSASSchema returns the schema shared by the Service SAS data sources, where `permissions` are the
+// permissions supported by the resource (in the order they appear in the SAS)
+func storageServiceSASSchema(permissions []string) map[string]*schema.Schema {
+	permissionsSchema := make(map[string]*schema.Schema)
+	for _, permission := range permissions {
+		permissionsSchema[permission] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		}
+	}
+
+	output := map[string]*schema.Schema{
+		"connection_string": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+
+		"https_only": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+
+		"ip_address": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateStorageServiceSASIPAddress,
+		},
+
+		"start": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validate.RFC3339Time,
+		},
+
+		"expiry": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validate.RFC3339Time,
+		},
+
+		"access_policy_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		"permissions": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: permissionsSchema,
+			},
+		},
+
+		"sas": {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+
+		"url": {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+	}
+
+	for _, header := range []string{"cache_control", "content_disposition", "content_encoding", "content_language", "content_type"} {
+		output[header] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		}
+	}
+
+	return output
+}
+
+func validateStorageServiceSASIPAddress(i interface{}, k string) (warnings []string, errors []error) {
+	value, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	// either a single IP Address or a range, e.g. `168.1.5.60-168.1.5.70`
+	for _, ip := range strings.SplitN(value, "-", 2) {
+		if net.ParseIP(ip) == nil {
+			errors = append(errors, fmt.Errorf("%q must be an IP Address or a range of IP Addresses (e.g. `168.1.5.60-168.1.5.70`): got %q", k, value))
+			return
+		}
+	}
+
+	return warnings, errors
+}
+
+// storageServiceSAS is a Service SAS for a Container, Blob or Share
+// - see https://docs.microsoft.com/en-us/rest/api/storageservices/constructing-a-service-sas
+type storageServiceSAS struct {
+	// service is the name of the service used in the canonicalized resource, e.g. `blob`
+	service string
+
+	// resourcePath is the path to the resource within the Storage Account, e.g. `container/blob`
+	resourcePath string
+
+	// signedResource is the type of the resource, e.g. `b` for a Blob
+	signedResource string
+
+	permissions     string
+	options         mainStorage.SASOptions
+	overrideHeaders mainStorage.OverrideHeaders
+}
+
+// expandStorageServiceSAS populates the fields shared by the Service SAS data sources
+func expandStorageServiceSAS(d *schema.ResourceData, service, resourcePath, signedResource string, permissions []string) (*storageServiceSAS, error) {
+	sas := storageServiceSAS{
+		service:        service,
+		resourcePath:   resourcePath,
+		signedResource: signedResource,
+		options: mainStorage.SASOptions{
+			APIVersion: sasSignedVersion,
+			IP:         d.Get("ip_address").(string),
+			UseHTTPS:   d.Get("https_only").(bool),
+			Identifier: d.Get("access_policy_id").(string),
+		},
+	}
+
+	if v := d.Get("start").(string); v != "" {
+		start, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing `start`: %+v", err)
+		}
+		sas.options.Start = start
+	}
+
+	if v := d.Get("expiry").(string); v != "" {
+		expiry, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing `expiry`: %+v", err)
+		}
+		sas.options.Expiry = expiry
+	}
+
+	if v := d.Get("permissions").([]interface{}); len(v) > 0 && v[0] != nil {
+		input := v[0].(map[string]interface{})
+		for _, permission := range permissions {
+			if enabled, ok := input[permission].(bool); ok && enabled {
+				sas.permissions += storageServiceSASPermissions[permission]
+			}
+		}
+	}
+
+	// when the SAS isn't associated with a Stored Access Policy, these fields have to be specified in the SAS itself
+	if sas.options.Identifier == "" {
+		if sas.options.Expiry.IsZero() {
+			return nil, fmt.Errorf("`expiry` must be specified when `access_policy_id` isn't set")
+		}
+
+		if sas.permissions == "" {
+			return nil, fmt.Errorf("At least one of the `permissions` must be enabled when `access_policy_id` isn't set")
+		}
+	}
+
+	if v, ok := d.GetOk("cache_control"); ok {
+		sas.overrideHeaders.CacheControl = v.(string)
+	}
+	if v, ok := d.GetOk("content_disposition"); ok {
+		sas.overrideHeaders.ContentDisposition = v.(string)
+	}
+	if v, ok := d.GetOk("content_encoding"); ok {
+		sas.overrideHeaders.ContentEncoding = v.(string)
+	}
+	if v, ok := d.GetOk("content_language"); ok {
+		sas.overrideHeaders.ContentLanguage = v.(string)
+	}
+	if v, ok := d.GetOk("content_type"); ok {
+		sas.overrideHeaders.ContentType = v.(string)
+	}
+
+	return &sas, nil
+}
+
+func (s storageServiceSAS) signedStart() string {
+	if s.options.Start.IsZero() {
+		return ""
+	}
+	return s.options.Start.UTC().Format(time.RFC3339)
+}
+
+func (s storageServiceSAS) signedExpiry() string {
+	if s.options.Expiry.IsZero() {
+		return ""
+	}
+	return s.options.Expiry.UTC().Format(time.RFC3339)
+}
+
+func (s storageServiceSAS) signedProtocols() string {
+	if s.options.UseHTTPS {
+		return "https"
+	}
+	return "https,http"
+}
+
+// stringToSign returns the string which is signed using the Access Key to form the SAS
+func (s storageServiceSAS) stringToSign(accountName string) string {
+	fields := []string{
+		s.permissions,
+		s.signedStart(),
+		s.signedExpiry(),
+		fmt.Sprintf("/%s/%s/%s", s.service, accountName, s.resourcePath),
+		s.options.Identifier,
+		s.options.IP,
+		s.signedProtocols(),
+		s.options.APIVersion,
+		s.overrideHeaders.CacheControl,
+		s.overrideHeaders.ContentDisposition,
+		s.overrideHeaders.ContentEncoding,
+		s.overrideHeaders.ContentLanguage,
+		s.overrideHeaders.ContentType,
+	}
+
+	return strings.Join(fields, "\n")
+}
+
+// token returns the SAS token (including the leading `?`) signed using the specified Access Key
+func (s storageServiceSAS) token(accountName, accountKey string) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(accountKey)
+	if err != nil {
+		return "", fmt.Errorf("Error decoding the Access Key for Storage Account %q: %+v", accountName, err)
+	}
+
+	hash := hmac.New(sha256.New, key)
+	if _, err := hash.Write([]byte(s.stringToSign(accountName))); err != nil {
+		return "", fmt.Errorf("Error signing the SAS for Storage Account %q: %+v", accountName, err)
+	}
+
+	params := url.Values{
+		"sv":  {s.options.APIVersion},
+		"spr": {s.signedProtocols()},
+		"sig": {base64.StdEncoding.EncodeToString(hash.Sum(nil))},
+	}
+
+	optionalParams := map[string]string{
+		"sr":   s.signedResource,
+		"sp":   s.permissions,
+		"st":   s.signedStart(),
+		"se":   s.signedExpiry(),
+		"si":   s.options.Identifier,
+		"sip":  s.options.IP,
+		"rscc": s.overrideHeaders.CacheControl,
+		"rscd": s.overrideHeaders.ContentDisposition,
+		"rsce": s.overrideHeaders.ContentEncoding,
+		"rscl": s.overrideHeaders.ContentLanguage,
+		"rsct": s.overrideHeaders.ContentType,
+	}
+	for k, v := range optionalParams {
+		if v != "" {
+			params.Set(k, v)
+		}
+	}
+
+	return "?" + params.Encode(), nil
+}
+
+// flattenStorageServiceSAS signs the Service SAS using the Access Key from the Connection String and sets the
+// `sas` and `url` attributes along with the ID
+func flattenStorageServiceSAS(d *schema.ResourceData, meta interface{}, sas *storageServiceSAS) error {
+	kvp, err := storage.ParseAccountSASConnectionString(d.Get("connection_string").(string))
+	if err != nil {
+		return err
+	}
+
+	accountName := kvp[connStringAccountNameKey]
+	accountKey := kvp[connStringAccountKeyKey]
+
+	token, err := sas.token(accountName, accountKey)
+	if err != nil {
+		return err
+	}
+
+	endpointSuffix := kvp["EndpointSuffix"]
+	if endpointSuffix == "" {
+		endpointSuffix = meta.(*ArmClient).environment.StorageEndpointSuffix
+	}
+
+	uri := url.URL{
+		Scheme: "https",
+		Host:   fmt.Sprintf("%s.%s.%s", accountName, sas.service, endpointSuffix),
+		Path:   "/" + sas.resourcePath,
+	}
+
+	d.Set("sas", token)
+	d.Set("url", uri.String()+token)
+
+	tokenHash := sha256.Sum256([]byte(token))
+	d.SetId(hex.EncodeToString(tokenHash[:]))
+
+	return nil
+}
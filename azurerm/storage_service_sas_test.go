@@ -0,0 +1,229 @@
+package azurerm
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	mainStorage "github.com/Azure/azure-sdk-for-go/storage"
+)
+
+func TestStorageServiceSAS_stringToSign(t *testing.T) {
+	start := time.Date(2019, 7, 2, 9, 38, 21, 0, time.UTC)
+	expiry := time.Date(2019, 7, 3, 9, 38, 21, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		sas      storageServiceSAS
+		expected []string
+	}{
+		{
+			name: "Share",
+			sas: storageServiceSAS{
+				service:        "file",
+				resourcePath:   "example",
+				signedResource: "s",
+				permissions:    "rl",
+				options: mainStorage.SASOptions{
+					APIVersion: sasSignedVersion,
+					Start:      start,
+					Expiry:     expiry,
+					IP:         "168.1.5.60-168.1.5.70",
+					UseHTTPS:   true,
+				},
+				overrideHeaders: mainStorage.OverrideHeaders{
+					ContentDisposition: "attachment",
+				},
+			},
+			expected: []string{
+				"rl",
+				"2019-07-02T09:38:21Z",
+				"2019-07-03T09:38:21Z",
+				"/file/account/example",
+				"",
+				"168.1.5.60-168.1.5.70",
+				"https",
+				sasSignedVersion,
+				"",
+				"attachment",
+				"",
+				"",
+				"",
+			},
+		},
+		{
+			name: "Blob with a Stored Access Policy",
+			sas: storageServiceSAS{
+				service:        "blob",
+				resourcePath:   "container/some/blob.vhd",
+				signedResource: "b",
+				options: mainStorage.SASOptions{
+					APIVersion: sasSignedVersion,
+					Identifier: "policy",
+				},
+			},
+			expected: []string{
+				"",
+				"",
+				"",
+				"/blob/account/container/some/blob.vhd",
+				"policy",
+				"",
+				"https,http",
+				sasSignedVersion,
+				"",
+				"",
+				"",
+				"",
+				"",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		actual := test.sas.stringToSign("account")
+		expected := strings.Join(test.expected, "\n")
+		if actual != expected {
+			t.Fatalf("Expected the string-to-sign for %q to be %q but got %q", test.name, expected, actual)
+		}
+	}
+}
+
+func TestStorageServiceSAS_matchesStorageSDK(t *testing.T) {
+	accountName := "example"
+	accountKey := "dGVzdC1hY2Nlc3Mta2V5LWZvci1zaWduaW5nLXJlcXVlc3Rz"
+
+	client, err := mainStorage.NewClient(accountName, accountKey, mainStorage.DefaultBaseURL, sasSignedVersion, true)
+	if err != nil {
+		t.Fatalf("Error creating client: %+v", err)
+	}
+	blobClient := client.GetBlobService()
+	container := blobClient.GetContainerReference("container")
+	blob := container.GetBlobReference("some/blob.vhd")
+
+	options := mainStorage.SASOptions{
+		APIVersion: sasSignedVersion,
+		Start:      time.Date(2019, 7, 2, 9, 38, 21, 0, time.UTC),
+		Expiry:     time.Date(2019, 7, 3, 9, 38, 21, 0, time.UTC),
+		IP:         "168.1.5.60",
+		UseHTTPS:   true,
+	}
+	headers := mainStorage.OverrideHeaders{
+		CacheControl:       "no-cache",
+		ContentDisposition: "attachment",
+		ContentType:        "text/plain",
+	}
+
+	containerSAS, err := container.GetSASURI(mainStorage.ContainerSASOptions{
+		ContainerSASPermissions: mainStorage.ContainerSASPermissions{
+			BlobServiceSASPermissions: mainStorage.BlobServiceSASPermissions{
+				Read: true,
+			},
+			List: true,
+		},
+		OverrideHeaders: headers,
+		SASOptions:      options,
+	})
+	if err != nil {
+		t.Fatalf("Error generating the Container SAS: %+v", err)
+	}
+
+	blobSAS, err := blob.GetSASURI(mainStorage.BlobSASOptions{
+		BlobServiceSASPermissions: mainStorage.BlobServiceSASPermissions{
+			Read:  true,
+			Write: true,
+		},
+		OverrideHeaders: headers,
+		SASOptions:      options,
+	})
+	if err != nil {
+		t.Fatalf("Error generating the Blob SAS: %+v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		sas      storageServiceSAS
+		expected string
+	}{
+		{
+			name: "Container",
+			sas: storageServiceSAS{
+				service:         "blob",
+				resourcePath:    "container",
+				signedResource:  "c",
+				permissions:     "rl",
+				options:         options,
+				overrideHeaders: headers,
+			},
+			expected: containerSAS,
+		},
+		{
+			name: "Blob",
+			sas: storageServiceSAS{
+				service:         "blob",
+				resourcePath:    "container/some/blob.vhd",
+				signedResource:  "b",
+				permissions:     "rw",
+				options:         options,
+				overrideHeaders: headers,
+			},
+			expected: blobSAS,
+		},
+	}
+
+	for _, test := range testCases {
+		token, err := test.sas.token(accountName, accountKey)
+		if err != nil {
+			t.Fatalf("Error generating the SAS for %q: %+v", test.name, err)
+		}
+
+		actual, err := url.ParseQuery(strings.TrimPrefix(token, "?"))
+		if err != nil {
+			t.Fatalf("Error parsing the SAS for %q: %+v", test.name, err)
+		}
+
+		expectedURI, err := url.Parse(test.expected)
+		if err != nil {
+			t.Fatalf("Error parsing the SAS URI for %q: %+v", test.name, err)
+		}
+		expected := expectedURI.Query()
+
+		for k := range expected {
+			if actual.Get(k) != expected.Get(k) {
+				t.Fatalf("Expected %q in the SAS for %q to be %q but got %q", k, test.name, expected.Get(k), actual.Get(k))
+			}
+		}
+	}
+}
+
+func TestValidateStorageServiceSASIPAddress(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "168.1.5.60",
+			ErrCount: 0,
+		},
+		{
+			Value:    "168.1.5.60-168.1.5.70",
+			ErrCount: 0,
+		},
+		{
+			Value:    "168.1.5",
+			ErrCount: 1,
+		},
+		{
+			Value:    "168.1.5.60-",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateStorageServiceSASIPAddress(tc.Value, "ip_address")
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected %d errors for %q but got %d", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}